@@ -28,6 +28,9 @@ type OCRParam struct {
 	Bucket    string `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
 	ObjectKey string `protobuf:"bytes,2,opt,name=object_key,json=objectKey,proto3" json:"object_key,omitempty"`
 	FileType  string `protobuf:"bytes,3,opt,name=file_type,json=fileType,proto3" json:"file_type,omitempty"`
+	Language  string `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`
+	SkipCache bool   `protobuf:"varint,5,opt,name=skip_cache,json=skipCache,proto3" json:"skip_cache,omitempty"`
+	FileHash  string `protobuf:"bytes,6,opt,name=file_hash,json=fileHash,proto3" json:"file_hash,omitempty"`
 }
 
 func (x *OCRParam) Reset() {
@@ -83,6 +86,27 @@ func (x *OCRParam) GetFileType() string {
 	return ""
 }
 
+func (x *OCRParam) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *OCRParam) GetSkipCache() bool {
+	if x != nil {
+		return x.SkipCache
+	}
+	return false
+}
+
+func (x *OCRParam) GetFileHash() string {
+	if x != nil {
+		return x.FileHash
+	}
+	return ""
+}
+
 type OCRTaskID struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -130,19 +154,117 @@ func (x *OCRTaskID) GetTaskId() string {
 	return ""
 }
 
+type OCRCancel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *OCRCancel) Reset() {
+	*x = OCRCancel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ocr_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OCRCancel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OCRCancel) ProtoMessage() {}
+
+func (x *OCRCancel) ProtoReflect() protoreflect.Message {
+	mi := &file_ocr_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OCRCancel.ProtoReflect.Descriptor instead.
+func (*OCRCancel) Descriptor() ([]byte, []int) {
+	return file_ocr_proto_rawDescGZIP(), []int{2}
+}
+
+type PageSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Page   int32  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+}
+
+func (x *PageSource) Reset() {
+	*x = PageSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ocr_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PageSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageSource) ProtoMessage() {}
+
+func (x *PageSource) ProtoReflect() protoreflect.Message {
+	mi := &file_ocr_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageSource.ProtoReflect.Descriptor instead.
+func (*PageSource) Descriptor() ([]byte, []int) {
+	return file_ocr_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PageSource) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *PageSource) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
 type OCRText struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Finished bool   `protobuf:"varint,1,opt,name=finished,proto3" json:"finished,omitempty"`
-	Text     string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Finished    bool          `protobuf:"varint,1,opt,name=finished,proto3" json:"finished,omitempty"`
+	Text        string        `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	PagesDone   int32         `protobuf:"varint,3,opt,name=pages_done,json=pagesDone,proto3" json:"pages_done,omitempty"`
+	PagesTotal  int32         `protobuf:"varint,4,opt,name=pages_total,json=pagesTotal,proto3" json:"pages_total,omitempty"`
+	Pages       []*PageSource `protobuf:"bytes,5,rep,name=pages,proto3" json:"pages,omitempty"`
+	FailedPages []int32       `protobuf:"varint,6,rep,packed,name=failed_pages,json=failedPages,proto3" json:"failed_pages,omitempty"`
+	OcrId       string        `protobuf:"bytes,7,opt,name=ocr_id,json=ocrId,proto3" json:"ocr_id,omitempty"`
 }
 
 func (x *OCRText) Reset() {
 	*x = OCRText{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ocr_proto_msgTypes[2]
+		mi := &file_ocr_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -155,7 +277,7 @@ func (x *OCRText) String() string {
 func (*OCRText) ProtoMessage() {}
 
 func (x *OCRText) ProtoReflect() protoreflect.Message {
-	mi := &file_ocr_proto_msgTypes[2]
+	mi := &file_ocr_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -168,7 +290,7 @@ func (x *OCRText) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OCRText.ProtoReflect.Descriptor instead.
 func (*OCRText) Descriptor() ([]byte, []int) {
-	return file_ocr_proto_rawDescGZIP(), []int{2}
+	return file_ocr_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *OCRText) GetFinished() bool {
@@ -185,34 +307,406 @@ func (x *OCRText) GetText() string {
 	return ""
 }
 
+func (x *OCRText) GetPagesDone() int32 {
+	if x != nil {
+		return x.PagesDone
+	}
+	return 0
+}
+
+func (x *OCRText) GetPagesTotal() int32 {
+	if x != nil {
+		return x.PagesTotal
+	}
+	return 0
+}
+
+func (x *OCRText) GetPages() []*PageSource {
+	if x != nil {
+		return x.Pages
+	}
+	return nil
+}
+
+func (x *OCRText) GetFailedPages() []int32 {
+	if x != nil {
+		return x.FailedPages
+	}
+	return nil
+}
+
+func (x *OCRText) GetOcrId() string {
+	if x != nil {
+		return x.OcrId
+	}
+	return ""
+}
+
+type OCRProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Finished    bool          `protobuf:"varint,1,opt,name=finished,proto3" json:"finished,omitempty"`
+	Text        string        `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	PagesDone   int32         `protobuf:"varint,3,opt,name=pages_done,json=pagesDone,proto3" json:"pages_done,omitempty"`
+	PagesTotal  int32         `protobuf:"varint,4,opt,name=pages_total,json=pagesTotal,proto3" json:"pages_total,omitempty"`
+	Page        int32         `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
+	Error       string        `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	Pages       []*PageSource `protobuf:"bytes,7,rep,name=pages,proto3" json:"pages,omitempty"`
+	FailedPages []int32       `protobuf:"varint,8,rep,packed,name=failed_pages,json=failedPages,proto3" json:"failed_pages,omitempty"`
+	OcrId       string        `protobuf:"bytes,9,opt,name=ocr_id,json=ocrId,proto3" json:"ocr_id,omitempty"`
+}
+
+func (x *OCRProgress) Reset() {
+	*x = OCRProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ocr_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OCRProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OCRProgress) ProtoMessage() {}
+
+func (x *OCRProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_ocr_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OCRProgress.ProtoReflect.Descriptor instead.
+func (*OCRProgress) Descriptor() ([]byte, []int) {
+	return file_ocr_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *OCRProgress) GetFinished() bool {
+	if x != nil {
+		return x.Finished
+	}
+	return false
+}
+
+func (x *OCRProgress) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *OCRProgress) GetPagesDone() int32 {
+	if x != nil {
+		return x.PagesDone
+	}
+	return 0
+}
+
+func (x *OCRProgress) GetPagesTotal() int32 {
+	if x != nil {
+		return x.PagesTotal
+	}
+	return 0
+}
+
+func (x *OCRProgress) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *OCRProgress) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *OCRProgress) GetPages() []*PageSource {
+	if x != nil {
+		return x.Pages
+	}
+	return nil
+}
+
+func (x *OCRProgress) GetFailedPages() []int32 {
+	if x != nil {
+		return x.FailedPages
+	}
+	return nil
+}
+
+func (x *OCRProgress) GetOcrId() string {
+	if x != nil {
+		return x.OcrId
+	}
+	return ""
+}
+
+type OCRPage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Page       int32   `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Text       string  `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Source     string  `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	Engine     string  `protobuf:"bytes,4,opt,name=engine,proto3" json:"engine,omitempty"`
+	Confidence float64 `protobuf:"fixed64,5,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	DurationMs int64   `protobuf:"varint,6,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Error      string  `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	Attempts   int32   `protobuf:"varint,8,opt,name=attempts,proto3" json:"attempts,omitempty"`
+}
+
+func (x *OCRPage) Reset() {
+	*x = OCRPage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ocr_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OCRPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OCRPage) ProtoMessage() {}
+
+func (x *OCRPage) ProtoReflect() protoreflect.Message {
+	mi := &file_ocr_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OCRPage.ProtoReflect.Descriptor instead.
+func (*OCRPage) Descriptor() ([]byte, []int) {
+	return file_ocr_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *OCRPage) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *OCRPage) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *OCRPage) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *OCRPage) GetEngine() string {
+	if x != nil {
+		return x.Engine
+	}
+	return ""
+}
+
+func (x *OCRPage) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *OCRPage) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *OCRPage) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *OCRPage) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+type OCRPages struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pages []*OCRPage `protobuf:"bytes,1,rep,name=pages,proto3" json:"pages,omitempty"`
+}
+
+func (x *OCRPages) Reset() {
+	*x = OCRPages{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ocr_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OCRPages) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OCRPages) ProtoMessage() {}
+
+func (x *OCRPages) ProtoReflect() protoreflect.Message {
+	mi := &file_ocr_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OCRPages.ProtoReflect.Descriptor instead.
+func (*OCRPages) Descriptor() ([]byte, []int) {
+	return file_ocr_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *OCRPages) GetPages() []*OCRPage {
+	if x != nil {
+		return x.Pages
+	}
+	return nil
+}
+
 var File_ocr_proto protoreflect.FileDescriptor
 
 var file_ocr_proto_rawDesc = []byte{
 	0x0a, 0x09, 0x6f, 0x63, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0e, 0x6f, 0x63, 0x72,
-	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x22, 0x5e, 0x0a, 0x08, 0x4f,
-	0x43, 0x52, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65,
-	0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12,
-	0x1d, 0x0a, 0x0a, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x1b,
-	0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x22, 0x24, 0x0a, 0x09, 0x4f,
-	0x43, 0x52, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x44, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x61, 0x73, 0x6b,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x73, 0x6b, 0x49,
-	0x64, 0x22, 0x39, 0x0a, 0x07, 0x4f, 0x43, 0x52, 0x54, 0x65, 0x78, 0x74, 0x12, 0x1a, 0x0a, 0x08,
-	0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
-	0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x32, 0x89, 0x01, 0x0a,
-	0x0a, 0x4f, 0x43, 0x52, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3a, 0x0a, 0x03, 0x4f,
-	0x43, 0x52, 0x12, 0x18, 0x2e, 0x6f, 0x63, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x43, 0x52, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x1a, 0x19, 0x2e, 0x6f,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x22, 0xb6, 0x01, 0x0a, 0x08,
+	0x4f, 0x43, 0x52, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x75, 0x63, 0x6b,
+	0x65, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x75, 0x63, 0x6b, 0x65, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x12,
+	0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x6b, 0x69, 0x70,
+	0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x6b,
+	0x69, 0x70, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65,
+	0x48, 0x61, 0x73, 0x68, 0x22, 0x24, 0x0a, 0x09, 0x4f, 0x43, 0x52, 0x54, 0x61, 0x73, 0x6b, 0x49,
+	0x44, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x73, 0x6b, 0x49, 0x64, 0x22, 0x0b, 0x0a, 0x09, 0x4f, 0x43,
+	0x52, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x22, 0x38, 0x0a, 0x0a, 0x50, 0x61, 0x67, 0x65, 0x53,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x22, 0xe5, 0x01, 0x0a, 0x07, 0x4f, 0x43, 0x52, 0x54, 0x65, 0x78, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x70, 0x61, 0x67, 0x65, 0x73, 0x5f, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x73, 0x44, 0x6f, 0x6e, 0x65, 0x12, 0x1f, 0x0a, 0x0b,
+	0x70, 0x61, 0x67, 0x65, 0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x30, 0x0a,
+	0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6f,
+	0x63, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61,
+	0x67, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x12,
+	0x21, 0x0a, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0b, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x67,
+	0x65, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x6f, 0x63, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x6f, 0x63, 0x72, 0x49, 0x64, 0x22, 0x93, 0x02, 0x0a, 0x0b, 0x4f, 0x43,
+	0x52, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6e,
+	0x69, 0x73, 0x68, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x66, 0x69, 0x6e,
+	0x69, 0x73, 0x68, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67,
+	0x65, 0x73, 0x5f, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x70,
+	0x61, 0x67, 0x65, 0x73, 0x44, 0x6f, 0x6e, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x67, 0x65,
+	0x73, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x70,
+	0x61, 0x67, 0x65, 0x73, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x67,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x30, 0x0a, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6f, 0x63, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x05,
+	0x70, 0x61, 0x67, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f,
+	0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0b, 0x66, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x6f, 0x63, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x63, 0x72, 0x49, 0x64, 0x22,
+	0xd4, 0x01, 0x0a, 0x07, 0x4f, 0x43, 0x52, 0x50, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x61, 0x67, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74,
+	0x65, 0x78, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x65,
+	0x6e, 0x67, 0x69, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x65, 0x6e, 0x67,
+	0x69, 0x6e, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65,
+	0x6e, 0x63, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x6d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x74,
+	0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x61, 0x74,
+	0x74, 0x65, 0x6d, 0x70, 0x74, 0x73, 0x22, 0x39, 0x0a, 0x08, 0x4f, 0x43, 0x52, 0x50, 0x61, 0x67,
+	0x65, 0x73, 0x12, 0x2d, 0x0a, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x6f, 0x63, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x4f, 0x43, 0x52, 0x50, 0x61, 0x67, 0x65, 0x52, 0x05, 0x70, 0x61, 0x67, 0x65,
+	0x73, 0x32, 0xd8, 0x03, 0x0a, 0x0a, 0x4f, 0x43, 0x52, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x12, 0x3a, 0x0a, 0x03, 0x4f, 0x43, 0x52, 0x12, 0x18, 0x2e, 0x6f, 0x63, 0x72, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x43, 0x52, 0x50, 0x61, 0x72, 0x61,
+	0x6d, 0x1a, 0x19, 0x2e, 0x6f, 0x63, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x4f, 0x43, 0x52, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x44, 0x12, 0x3f, 0x0a, 0x09,
+	0x47, 0x65, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x19, 0x2e, 0x6f, 0x63, 0x72, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x43, 0x52, 0x54, 0x61,
+	0x73, 0x6b, 0x49, 0x44, 0x1a, 0x17, 0x2e, 0x6f, 0x63, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x43, 0x52, 0x54, 0x65, 0x78, 0x74, 0x12, 0x47, 0x0a,
+	0x0b, 0x57, 0x61, 0x74, 0x63, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x19, 0x2e, 0x6f,
 	0x63, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x43,
-	0x52, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x44, 0x12, 0x3f, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x53, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x12, 0x19, 0x2e, 0x6f, 0x63, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x43, 0x52, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x44, 0x1a,
-	0x17, 0x2e, 0x6f, 0x63, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31,
-	0x2e, 0x4f, 0x43, 0x52, 0x54, 0x65, 0x78, 0x74, 0x42, 0x19, 0x5a, 0x17, 0x2e, 0x2f, 0x61, 0x70,
-	0x69, 0x2f, 0x6f, 0x63, 0x72, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x76, 0x31,
-	0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x44, 0x1a, 0x1b, 0x2e, 0x6f, 0x63, 0x72, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x43, 0x52, 0x50, 0x72, 0x6f, 0x67,
+	0x72, 0x65, 0x73, 0x73, 0x30, 0x01, 0x12, 0x3e, 0x0a, 0x06, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x12, 0x19, 0x2e, 0x6f, 0x63, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x4f, 0x43, 0x52, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x44, 0x1a, 0x19, 0x2e, 0x6f, 0x63,
+	0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x43, 0x52,
+	0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x12, 0x3f, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x50, 0x61, 0x67,
+	0x65, 0x73, 0x12, 0x19, 0x2e, 0x6f, 0x63, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x43, 0x52, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x44, 0x1a, 0x18, 0x2e,
+	0x6f, 0x63, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4f,
+	0x43, 0x52, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x42, 0x0a, 0x0a, 0x52, 0x65, 0x74, 0x72, 0x79,
+	0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x19, 0x2e, 0x6f, 0x63, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x43, 0x52, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x44,
+	0x1a, 0x19, 0x2e, 0x6f, 0x63, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x4f, 0x43, 0x52, 0x54, 0x61, 0x73, 0x6b, 0x49, 0x44, 0x12, 0x3f, 0x0a, 0x09, 0x47,
+	0x65, 0x74, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x19, 0x2e, 0x6f, 0x63, 0x72, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x43, 0x52, 0x54, 0x61, 0x73,
+	0x6b, 0x49, 0x44, 0x1a, 0x17, 0x2e, 0x6f, 0x63, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x43, 0x52, 0x54, 0x65, 0x78, 0x74, 0x42, 0x19, 0x5a, 0x17,
+	0x2e, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x6f, 0x63, 0x72, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -227,22 +721,40 @@ func file_ocr_proto_rawDescGZIP() []byte {
 	return file_ocr_proto_rawDescData
 }
 
-var file_ocr_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_ocr_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_ocr_proto_goTypes = []interface{}{
-	(*OCRParam)(nil),  // 0: ocr.service.v1.OCRParam
-	(*OCRTaskID)(nil), // 1: ocr.service.v1.OCRTaskID
-	(*OCRText)(nil),   // 2: ocr.service.v1.OCRText
+	(*OCRParam)(nil),    // 0: ocr.service.v1.OCRParam
+	(*OCRTaskID)(nil),   // 1: ocr.service.v1.OCRTaskID
+	(*OCRCancel)(nil),   // 2: ocr.service.v1.OCRCancel
+	(*PageSource)(nil),  // 3: ocr.service.v1.PageSource
+	(*OCRText)(nil),     // 4: ocr.service.v1.OCRText
+	(*OCRProgress)(nil), // 5: ocr.service.v1.OCRProgress
+	(*OCRPage)(nil),     // 6: ocr.service.v1.OCRPage
+	(*OCRPages)(nil),    // 7: ocr.service.v1.OCRPages
 }
 var file_ocr_proto_depIdxs = []int32{
-	0, // 0: ocr.service.v1.OCRService.OCR:input_type -> ocr.service.v1.OCRParam
-	1, // 1: ocr.service.v1.OCRService.GetStatus:input_type -> ocr.service.v1.OCRTaskID
-	1, // 2: ocr.service.v1.OCRService.OCR:output_type -> ocr.service.v1.OCRTaskID
-	2, // 3: ocr.service.v1.OCRService.GetStatus:output_type -> ocr.service.v1.OCRText
-	2, // [2:4] is the sub-list for method output_type
-	0, // [0:2] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	3,  // 0: ocr.service.v1.OCRText.pages:type_name -> ocr.service.v1.PageSource
+	3,  // 1: ocr.service.v1.OCRProgress.pages:type_name -> ocr.service.v1.PageSource
+	6,  // 2: ocr.service.v1.OCRPages.pages:type_name -> ocr.service.v1.OCRPage
+	0,  // 3: ocr.service.v1.OCRService.OCR:input_type -> ocr.service.v1.OCRParam
+	1,  // 4: ocr.service.v1.OCRService.GetStatus:input_type -> ocr.service.v1.OCRTaskID
+	1,  // 5: ocr.service.v1.OCRService.WatchStatus:input_type -> ocr.service.v1.OCRTaskID
+	1,  // 6: ocr.service.v1.OCRService.Cancel:input_type -> ocr.service.v1.OCRTaskID
+	1,  // 7: ocr.service.v1.OCRService.GetPages:input_type -> ocr.service.v1.OCRTaskID
+	1,  // 8: ocr.service.v1.OCRService.RetryPages:input_type -> ocr.service.v1.OCRTaskID
+	1,  // 9: ocr.service.v1.OCRService.GetResult:input_type -> ocr.service.v1.OCRTaskID
+	1,  // 10: ocr.service.v1.OCRService.OCR:output_type -> ocr.service.v1.OCRTaskID
+	4,  // 11: ocr.service.v1.OCRService.GetStatus:output_type -> ocr.service.v1.OCRText
+	5,  // 12: ocr.service.v1.OCRService.WatchStatus:output_type -> ocr.service.v1.OCRProgress
+	2,  // 13: ocr.service.v1.OCRService.Cancel:output_type -> ocr.service.v1.OCRCancel
+	7,  // 14: ocr.service.v1.OCRService.GetPages:output_type -> ocr.service.v1.OCRPages
+	1,  // 15: ocr.service.v1.OCRService.RetryPages:output_type -> ocr.service.v1.OCRTaskID
+	4,  // 16: ocr.service.v1.OCRService.GetResult:output_type -> ocr.service.v1.OCRText
+	10, // [10:17] is the sub-list for method output_type
+	3,  // [3:10] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_ocr_proto_init() }
@@ -276,6 +788,30 @@ func file_ocr_proto_init() {
 			}
 		}
 		file_ocr_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OCRCancel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ocr_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PageSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ocr_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*OCRText); i {
 			case 0:
 				return &v.state
@@ -287,6 +823,42 @@ func file_ocr_proto_init() {
 				return nil
 			}
 		}
+		file_ocr_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OCRProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ocr_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OCRPage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ocr_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OCRPages); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -294,7 +866,7 @@ func file_ocr_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_ocr_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -38,6 +38,11 @@ func NewOCRServiceEndpoints() []*api.Endpoint {
 type OCRService interface {
 	OCR(ctx context.Context, in *OCRParam, opts ...client.CallOption) (*OCRTaskID, error)
 	GetStatus(ctx context.Context, in *OCRTaskID, opts ...client.CallOption) (*OCRText, error)
+	WatchStatus(ctx context.Context, in *OCRTaskID, opts ...client.CallOption) (OCRService_WatchStatusService, error)
+	Cancel(ctx context.Context, in *OCRTaskID, opts ...client.CallOption) (*OCRCancel, error)
+	GetPages(ctx context.Context, in *OCRTaskID, opts ...client.CallOption) (*OCRPages, error)
+	RetryPages(ctx context.Context, in *OCRTaskID, opts ...client.CallOption) (*OCRTaskID, error)
+	GetResult(ctx context.Context, in *OCRTaskID, opts ...client.CallOption) (*OCRText, error)
 }
 
 type oCRService struct {
@@ -72,17 +77,121 @@ func (c *oCRService) GetStatus(ctx context.Context, in *OCRTaskID, opts ...clien
 	return out, nil
 }
 
+func (c *oCRService) WatchStatus(ctx context.Context, in *OCRTaskID, opts ...client.CallOption) (OCRService_WatchStatusService, error) {
+	req := c.c.NewRequest(c.name, "OCRService.WatchStatus", &OCRTaskID{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &oCRServiceWatchStatus{stream}, nil
+}
+
+type OCRService_WatchStatusService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	CloseSend() error
+	Close() error
+	Recv() (*OCRProgress, error)
+}
+
+type oCRServiceWatchStatus struct {
+	stream client.Stream
+}
+
+func (x *oCRServiceWatchStatus) CloseSend() error {
+	return x.stream.CloseSend()
+}
+
+func (x *oCRServiceWatchStatus) Close() error {
+	return x.stream.Close()
+}
+
+func (x *oCRServiceWatchStatus) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *oCRServiceWatchStatus) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *oCRServiceWatchStatus) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *oCRServiceWatchStatus) Recv() (*OCRProgress, error) {
+	m := new(OCRProgress)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *oCRService) Cancel(ctx context.Context, in *OCRTaskID, opts ...client.CallOption) (*OCRCancel, error) {
+	req := c.c.NewRequest(c.name, "OCRService.Cancel", in)
+	out := new(OCRCancel)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oCRService) GetPages(ctx context.Context, in *OCRTaskID, opts ...client.CallOption) (*OCRPages, error) {
+	req := c.c.NewRequest(c.name, "OCRService.GetPages", in)
+	out := new(OCRPages)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oCRService) RetryPages(ctx context.Context, in *OCRTaskID, opts ...client.CallOption) (*OCRTaskID, error) {
+	req := c.c.NewRequest(c.name, "OCRService.RetryPages", in)
+	out := new(OCRTaskID)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oCRService) GetResult(ctx context.Context, in *OCRTaskID, opts ...client.CallOption) (*OCRText, error) {
+	req := c.c.NewRequest(c.name, "OCRService.GetResult", in)
+	out := new(OCRText)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for OCRService service
 
 type OCRServiceHandler interface {
 	OCR(context.Context, *OCRParam, *OCRTaskID) error
 	GetStatus(context.Context, *OCRTaskID, *OCRText) error
+	WatchStatus(context.Context, *OCRTaskID, OCRService_WatchStatusStream) error
+	Cancel(context.Context, *OCRTaskID, *OCRCancel) error
+	GetPages(context.Context, *OCRTaskID, *OCRPages) error
+	RetryPages(context.Context, *OCRTaskID, *OCRTaskID) error
+	GetResult(context.Context, *OCRTaskID, *OCRText) error
 }
 
 func RegisterOCRServiceHandler(s server.Server, hdlr OCRServiceHandler, opts ...server.HandlerOption) error {
 	type oCRService interface {
 		OCR(ctx context.Context, in *OCRParam, out *OCRTaskID) error
 		GetStatus(ctx context.Context, in *OCRTaskID, out *OCRText) error
+		WatchStatus(ctx context.Context, stream server.Stream) error
+		Cancel(ctx context.Context, in *OCRTaskID, out *OCRCancel) error
+		GetPages(ctx context.Context, in *OCRTaskID, out *OCRPages) error
+		RetryPages(ctx context.Context, in *OCRTaskID, out *OCRTaskID) error
+		GetResult(ctx context.Context, in *OCRTaskID, out *OCRText) error
 	}
 	type OCRService struct {
 		oCRService
@@ -102,3 +211,59 @@ func (h *oCRServiceHandler) OCR(ctx context.Context, in *OCRParam, out *OCRTaskI
 func (h *oCRServiceHandler) GetStatus(ctx context.Context, in *OCRTaskID, out *OCRText) error {
 	return h.OCRServiceHandler.GetStatus(ctx, in, out)
 }
+
+func (h *oCRServiceHandler) WatchStatus(ctx context.Context, stream server.Stream) error {
+	m := new(OCRTaskID)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.OCRServiceHandler.WatchStatus(ctx, m, &oCRServiceWatchStatusStream{stream})
+}
+
+type OCRService_WatchStatusStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*OCRProgress) error
+}
+
+type oCRServiceWatchStatusStream struct {
+	stream server.Stream
+}
+
+func (x *oCRServiceWatchStatusStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *oCRServiceWatchStatusStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *oCRServiceWatchStatusStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *oCRServiceWatchStatusStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *oCRServiceWatchStatusStream) Send(m *OCRProgress) error {
+	return x.stream.Send(m)
+}
+
+func (h *oCRServiceHandler) Cancel(ctx context.Context, in *OCRTaskID, out *OCRCancel) error {
+	return h.OCRServiceHandler.Cancel(ctx, in, out)
+}
+
+func (h *oCRServiceHandler) GetPages(ctx context.Context, in *OCRTaskID, out *OCRPages) error {
+	return h.OCRServiceHandler.GetPages(ctx, in, out)
+}
+
+func (h *oCRServiceHandler) RetryPages(ctx context.Context, in *OCRTaskID, out *OCRTaskID) error {
+	return h.OCRServiceHandler.RetryPages(ctx, in, out)
+}
+
+func (h *oCRServiceHandler) GetResult(ctx context.Context, in *OCRTaskID, out *OCRText) error {
+	return h.OCRServiceHandler.GetResult(ctx, in, out)
+}
@@ -27,6 +27,7 @@ const (
 	Paper_translation Paper_Status = 1
 	Paper_finished    Paper_Status = 2
 	Paper_failed      Paper_Status = 3
+	Paper_cancelled   Paper_Status = 4
 )
 
 // Enum value maps for Paper_Status.
@@ -36,12 +37,14 @@ var (
 		1: "translation",
 		2: "finished",
 		3: "failed",
+		4: "cancelled",
 	}
 	Paper_Status_value = map[string]int32{
 		"ocr":         0,
 		"translation": 1,
 		"finished":    2,
 		"failed":      3,
+		"cancelled":   4,
 	}
 )
 
@@ -69,7 +72,59 @@ func (x Paper_Status) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Paper_Status.Descriptor instead.
 func (Paper_Status) EnumDescriptor() ([]byte, []int) {
-	return file_paper_proto_rawDescGZIP(), []int{1, 0}
+	return file_paper_proto_rawDescGZIP(), []int{2, 0}
+}
+
+type PaperEvent_Type int32
+
+const (
+	PaperEvent_stage                PaperEvent_Type = 0
+	PaperEvent_ocr_progress         PaperEvent_Type = 1
+	PaperEvent_translation_progress PaperEvent_Type = 2
+	PaperEvent_result               PaperEvent_Type = 3
+)
+
+// Enum value maps for PaperEvent_Type.
+var (
+	PaperEvent_Type_name = map[int32]string{
+		0: "stage",
+		1: "ocr_progress",
+		2: "translation_progress",
+		3: "result",
+	}
+	PaperEvent_Type_value = map[string]int32{
+		"stage":                0,
+		"ocr_progress":         1,
+		"translation_progress": 2,
+		"result":               3,
+	}
+)
+
+func (x PaperEvent_Type) Enum() *PaperEvent_Type {
+	p := new(PaperEvent_Type)
+	*p = x
+	return p
+}
+
+func (x PaperEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PaperEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_paper_proto_enumTypes[1].Descriptor()
+}
+
+func (PaperEvent_Type) Type() protoreflect.EnumType {
+	return &file_paper_proto_enumTypes[1]
+}
+
+func (x PaperEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PaperEvent_Type.Descriptor instead.
+func (PaperEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_paper_proto_rawDescGZIP(), []int{3, 0}
 }
 
 type CreatePaper struct {
@@ -77,9 +132,15 @@ type CreatePaper struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	PaperFileHash  string `protobuf:"bytes,1,opt,name=paper_file_hash,json=paperFileHash,proto3" json:"paper_file_hash,omitempty"`
-	EmailTo        string `protobuf:"bytes,2,opt,name=email_to,json=emailTo,proto3" json:"email_to,omitempty"`
-	TargetLanguage string `protobuf:"bytes,3,opt,name=target_language,json=targetLanguage,proto3" json:"target_language,omitempty"`
+	PaperFileHash   string   `protobuf:"bytes,1,opt,name=paper_file_hash,json=paperFileHash,proto3" json:"paper_file_hash,omitempty"`
+	EmailTo         string   `protobuf:"bytes,2,opt,name=email_to,json=emailTo,proto3" json:"email_to,omitempty"`
+	TargetLanguage  string   `protobuf:"bytes,3,opt,name=target_language,json=targetLanguage,proto3" json:"target_language,omitempty"`
+	GlossaryId      string   `protobuf:"bytes,4,opt,name=glossary_id,json=glossaryId,proto3" json:"glossary_id,omitempty"`
+	Title           string   `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	PromptName      string   `protobuf:"bytes,6,opt,name=prompt_name,json=promptName,proto3" json:"prompt_name,omitempty"`
+	PromptVersion   string   `protobuf:"bytes,7,opt,name=prompt_version,json=promptVersion,proto3" json:"prompt_version,omitempty"`
+	TargetLanguages []string `protobuf:"bytes,8,rep,name=target_languages,json=targetLanguages,proto3" json:"target_languages,omitempty"`
+	SourceLanguage  string   `protobuf:"bytes,9,opt,name=source_language,json=sourceLanguage,proto3" json:"source_language,omitempty"`
 }
 
 func (x *CreatePaper) Reset() {
@@ -135,23 +196,154 @@ func (x *CreatePaper) GetTargetLanguage() string {
 	return ""
 }
 
+func (x *CreatePaper) GetGlossaryId() string {
+	if x != nil {
+		return x.GlossaryId
+	}
+	return ""
+}
+
+func (x *CreatePaper) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreatePaper) GetPromptName() string {
+	if x != nil {
+		return x.PromptName
+	}
+	return ""
+}
+
+func (x *CreatePaper) GetPromptVersion() string {
+	if x != nil {
+		return x.PromptVersion
+	}
+	return ""
+}
+
+func (x *CreatePaper) GetTargetLanguages() []string {
+	if x != nil {
+		return x.TargetLanguages
+	}
+	return nil
+}
+
+func (x *CreatePaper) GetSourceLanguage() string {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return ""
+}
+
+type Failure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stage    string `protobuf:"bytes,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	Code     string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message  string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	FailedAt int64  `protobuf:"varint,4,opt,name=failed_at,json=failedAt,proto3" json:"failed_at,omitempty"`
+	Attempt  int32  `protobuf:"varint,5,opt,name=attempt,proto3" json:"attempt,omitempty"`
+}
+
+func (x *Failure) Reset() {
+	*x = Failure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_paper_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Failure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Failure) ProtoMessage() {}
+
+func (x *Failure) ProtoReflect() protoreflect.Message {
+	mi := &file_paper_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Failure.ProtoReflect.Descriptor instead.
+func (*Failure) Descriptor() ([]byte, []int) {
+	return file_paper_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Failure) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *Failure) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Failure) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Failure) GetFailedAt() int64 {
+	if x != nil {
+		return x.FailedAt
+	}
+	return 0
+}
+
+func (x *Failure) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
 type Paper struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id             string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	FileHash       string       `protobuf:"bytes,2,opt,name=file_hash,json=fileHash,proto3" json:"file_hash,omitempty"`
-	CreateAt       int64        `protobuf:"varint,3,opt,name=create_at,json=createAt,proto3" json:"create_at,omitempty"`
-	Status         Paper_Status `protobuf:"varint,4,opt,name=status,proto3,enum=paper.service.v1.Paper_Status" json:"status,omitempty"`
-	TargetLanguage string       `protobuf:"bytes,5,opt,name=target_language,json=targetLanguage,proto3" json:"target_language,omitempty"`
-	ResultText     string       `protobuf:"bytes,6,opt,name=result_text,json=resultText,proto3" json:"result_text,omitempty"`
+	Id              string       `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	FileHash        string       `protobuf:"bytes,2,opt,name=file_hash,json=fileHash,proto3" json:"file_hash,omitempty"`
+	CreateAt        int64        `protobuf:"varint,3,opt,name=create_at,json=createAt,proto3" json:"create_at,omitempty"`
+	Status          Paper_Status `protobuf:"varint,4,opt,name=status,proto3,enum=paper.service.v1.Paper_Status" json:"status,omitempty"`
+	TargetLanguage  string       `protobuf:"bytes,5,opt,name=target_language,json=targetLanguage,proto3" json:"target_language,omitempty"`
+	ResultText      string       `protobuf:"bytes,6,opt,name=result_text,json=resultText,proto3" json:"result_text,omitempty"`
+	Failure         *Failure     `protobuf:"bytes,7,opt,name=failure,proto3" json:"failure,omitempty"`
+	GlossaryId      string       `protobuf:"bytes,8,opt,name=glossary_id,json=glossaryId,proto3" json:"glossary_id,omitempty"`
+	Title           string       `protobuf:"bytes,9,opt,name=title,proto3" json:"title,omitempty"`
+	PromptName      string       `protobuf:"bytes,10,opt,name=prompt_name,json=promptName,proto3" json:"prompt_name,omitempty"`
+	PromptVersion   string       `protobuf:"bytes,11,opt,name=prompt_version,json=promptVersion,proto3" json:"prompt_version,omitempty"`
+	TargetLanguages []string     `protobuf:"bytes,12,rep,name=target_languages,json=targetLanguages,proto3" json:"target_languages,omitempty"`
+	ParentId        string       `protobuf:"bytes,13,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	Children        []*Paper     `protobuf:"bytes,14,rep,name=children,proto3" json:"children,omitempty"`
+	SourceLanguage  string       `protobuf:"bytes,15,opt,name=source_language,json=sourceLanguage,proto3" json:"source_language,omitempty"`
+	FailedPages     []int32      `protobuf:"varint,16,rep,packed,name=failed_pages,json=failedPages,proto3" json:"failed_pages,omitempty"`
 }
 
 func (x *Paper) Reset() {
 	*x = Paper{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_paper_proto_msgTypes[1]
+		mi := &file_paper_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -164,7 +356,7 @@ func (x *Paper) String() string {
 func (*Paper) ProtoMessage() {}
 
 func (x *Paper) ProtoReflect() protoreflect.Message {
-	mi := &file_paper_proto_msgTypes[1]
+	mi := &file_paper_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -177,7 +369,7 @@ func (x *Paper) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Paper.ProtoReflect.Descriptor instead.
 func (*Paper) Descriptor() ([]byte, []int) {
-	return file_paper_proto_rawDescGZIP(), []int{1}
+	return file_paper_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Paper) GetId() string {
@@ -222,6 +414,179 @@ func (x *Paper) GetResultText() string {
 	return ""
 }
 
+func (x *Paper) GetFailure() *Failure {
+	if x != nil {
+		return x.Failure
+	}
+	return nil
+}
+
+func (x *Paper) GetGlossaryId() string {
+	if x != nil {
+		return x.GlossaryId
+	}
+	return ""
+}
+
+func (x *Paper) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Paper) GetPromptName() string {
+	if x != nil {
+		return x.PromptName
+	}
+	return ""
+}
+
+func (x *Paper) GetPromptVersion() string {
+	if x != nil {
+		return x.PromptVersion
+	}
+	return ""
+}
+
+func (x *Paper) GetTargetLanguages() []string {
+	if x != nil {
+		return x.TargetLanguages
+	}
+	return nil
+}
+
+func (x *Paper) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Paper) GetChildren() []*Paper {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *Paper) GetSourceLanguage() string {
+	if x != nil {
+		return x.SourceLanguage
+	}
+	return ""
+}
+
+func (x *Paper) GetFailedPages() []int32 {
+	if x != nil {
+		return x.FailedPages
+	}
+	return nil
+}
+
+type PaperEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type        PaperEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=paper.service.v1.PaperEvent_Type" json:"type,omitempty"`
+	Status      Paper_Status    `protobuf:"varint,2,opt,name=status,proto3,enum=paper.service.v1.Paper_Status" json:"status,omitempty"`
+	Index       int32           `protobuf:"varint,3,opt,name=index,proto3" json:"index,omitempty"`
+	Done        int32           `protobuf:"varint,4,opt,name=done,proto3" json:"done,omitempty"`
+	Total       int32           `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	Text        string          `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
+	Error       string          `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	FailedPages []int32         `protobuf:"varint,8,rep,packed,name=failed_pages,json=failedPages,proto3" json:"failed_pages,omitempty"`
+}
+
+func (x *PaperEvent) Reset() {
+	*x = PaperEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_paper_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PaperEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaperEvent) ProtoMessage() {}
+
+func (x *PaperEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_paper_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaperEvent.ProtoReflect.Descriptor instead.
+func (*PaperEvent) Descriptor() ([]byte, []int) {
+	return file_paper_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PaperEvent) GetType() PaperEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return PaperEvent_stage
+}
+
+func (x *PaperEvent) GetStatus() Paper_Status {
+	if x != nil {
+		return x.Status
+	}
+	return Paper_ocr
+}
+
+func (x *PaperEvent) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *PaperEvent) GetDone() int32 {
+	if x != nil {
+		return x.Done
+	}
+	return 0
+}
+
+func (x *PaperEvent) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *PaperEvent) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *PaperEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *PaperEvent) GetFailedPages() []int32 {
+	if x != nil {
+		return x.FailedPages
+	}
+	return nil
+}
+
 type PaperID struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -233,7 +598,7 @@ type PaperID struct {
 func (x *PaperID) Reset() {
 	*x = PaperID{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_paper_proto_msgTypes[2]
+		mi := &file_paper_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -246,7 +611,7 @@ func (x *PaperID) String() string {
 func (*PaperID) ProtoMessage() {}
 
 func (x *PaperID) ProtoReflect() protoreflect.Message {
-	mi := &file_paper_proto_msgTypes[2]
+	mi := &file_paper_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -259,7 +624,7 @@ func (x *PaperID) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PaperID.ProtoReflect.Descriptor instead.
 func (*PaperID) Descriptor() ([]byte, []int) {
-	return file_paper_proto_rawDescGZIP(), []int{2}
+	return file_paper_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *PaperID) GetId() string {
@@ -269,6 +634,61 @@ func (x *PaperID) GetId() string {
 	return ""
 }
 
+type ReqRerunStage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Stage string `protobuf:"bytes,2,opt,name=stage,proto3" json:"stage,omitempty"`
+}
+
+func (x *ReqRerunStage) Reset() {
+	*x = ReqRerunStage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_paper_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReqRerunStage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReqRerunStage) ProtoMessage() {}
+
+func (x *ReqRerunStage) ProtoReflect() protoreflect.Message {
+	mi := &file_paper_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReqRerunStage.ProtoReflect.Descriptor instead.
+func (*ReqRerunStage) Descriptor() ([]byte, []int) {
+	return file_paper_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReqRerunStage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReqRerunStage) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
 type DeletePaper struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -278,7 +698,7 @@ type DeletePaper struct {
 func (x *DeletePaper) Reset() {
 	*x = DeletePaper{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_paper_proto_msgTypes[3]
+		mi := &file_paper_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -291,7 +711,7 @@ func (x *DeletePaper) String() string {
 func (*DeletePaper) ProtoMessage() {}
 
 func (x *DeletePaper) ProtoReflect() protoreflect.Message {
-	mi := &file_paper_proto_msgTypes[3]
+	mi := &file_paper_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -304,7 +724,7 @@ func (x *DeletePaper) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeletePaper.ProtoReflect.Descriptor instead.
 func (*DeletePaper) Descriptor() ([]byte, []int) {
-	return file_paper_proto_rawDescGZIP(), []int{3}
+	return file_paper_proto_rawDescGZIP(), []int{6}
 }
 
 type ReqFetchs struct {
@@ -316,7 +736,7 @@ type ReqFetchs struct {
 func (x *ReqFetchs) Reset() {
 	*x = ReqFetchs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_paper_proto_msgTypes[4]
+		mi := &file_paper_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -329,7 +749,7 @@ func (x *ReqFetchs) String() string {
 func (*ReqFetchs) ProtoMessage() {}
 
 func (x *ReqFetchs) ProtoReflect() protoreflect.Message {
-	mi := &file_paper_proto_msgTypes[4]
+	mi := &file_paper_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -342,7 +762,7 @@ func (x *ReqFetchs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReqFetchs.ProtoReflect.Descriptor instead.
 func (*ReqFetchs) Descriptor() ([]byte, []int) {
-	return file_paper_proto_rawDescGZIP(), []int{4}
+	return file_paper_proto_rawDescGZIP(), []int{7}
 }
 
 type RespFetchs struct {
@@ -357,7 +777,7 @@ type RespFetchs struct {
 func (x *RespFetchs) Reset() {
 	*x = RespFetchs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_paper_proto_msgTypes[5]
+		mi := &file_paper_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -370,7 +790,7 @@ func (x *RespFetchs) String() string {
 func (*RespFetchs) ProtoMessage() {}
 
 func (x *RespFetchs) ProtoReflect() protoreflect.Message {
-	mi := &file_paper_proto_msgTypes[5]
+	mi := &file_paper_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -383,7 +803,7 @@ func (x *RespFetchs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RespFetchs.ProtoReflect.Descriptor instead.
 func (*RespFetchs) Descriptor() ([]byte, []int) {
-	return file_paper_proto_rawDescGZIP(), []int{5}
+	return file_paper_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RespFetchs) GetTotal() int32 {
@@ -405,60 +825,148 @@ var File_paper_proto protoreflect.FileDescriptor
 var file_paper_proto_rawDesc = []byte{
 	0x0a, 0x0b, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x10, 0x70,
 	0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x22,
-	0x79, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x61, 0x70, 0x65, 0x72, 0x12, 0x26,
-	0x0a, 0x0f, 0x70, 0x61, 0x70, 0x65, 0x72, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73,
-	0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x61, 0x70, 0x65, 0x72, 0x46, 0x69,
-	0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x5f,
-	0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x54,
-	0x6f, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6c, 0x61, 0x6e, 0x67,
-	0x75, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x91, 0x02, 0x0a, 0x05, 0x50,
-	0x61, 0x70, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73,
-	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73,
-	0x68, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x74, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x74, 0x12, 0x36,
-	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e,
-	0x2e, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76,
-	0x31, 0x2e, 0x50, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06,
-	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
-	0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0e, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12,
-	0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x06,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x54, 0x65, 0x78, 0x74,
-	0x22, 0x3c, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x07, 0x0a, 0x03, 0x6f, 0x63,
-	0x72, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08, 0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64,
-	0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x10, 0x03, 0x22, 0x19,
-	0x0a, 0x07, 0x50, 0x61, 0x70, 0x65, 0x72, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x0d, 0x0a, 0x0b, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x50, 0x61, 0x70, 0x65, 0x72, 0x22, 0x0b, 0x0a, 0x09, 0x52, 0x65, 0x71, 0x46,
-	0x65, 0x74, 0x63, 0x68, 0x73, 0x22, 0x53, 0x0a, 0x0a, 0x52, 0x65, 0x73, 0x70, 0x46, 0x65, 0x74,
-	0x63, 0x68, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x2f, 0x0a, 0x06, 0x70, 0x61, 0x70,
-	0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x70, 0x65,
+	0xcc, 0x02, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50, 0x61, 0x70, 0x65, 0x72, 0x12,
+	0x26, 0x0a, 0x0f, 0x70, 0x61, 0x70, 0x65, 0x72, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x61, 0x70, 0x65, 0x72, 0x46,
+	0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x19, 0x0a, 0x08, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x5f, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6d, 0x61, 0x69, 0x6c,
+	0x54, 0x6f, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6c, 0x61, 0x6e,
+	0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x67,
+	0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x67, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74,
+	0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x5f, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x5f, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x72, 0x6f,
+	0x6d, 0x70, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x18, 0x08,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x4c, 0x61, 0x6e, 0x67,
+	0x75, 0x61, 0x67, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x84,
+	0x01, 0x0a, 0x07, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x41, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x74, 0x74, 0x65, 0x6d, 0x70, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x61, 0x74,
+	0x74, 0x65, 0x6d, 0x70, 0x74, 0x22, 0x9d, 0x05, 0x0a, 0x05, 0x50, 0x61, 0x70, 0x65, 0x72, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1b, 0x0a, 0x09,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x41, 0x74, 0x12, 0x36, 0x0a, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x70, 0x61, 0x70, 0x65,
+	0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x70,
+	0x65, 0x72, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6c, 0x61, 0x6e, 0x67,
+	0x75, 0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x54, 0x65, 0x78, 0x74, 0x12, 0x33, 0x0a, 0x07, 0x66,
+	0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70,
+	0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x52, 0x07, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65,
+	0x12, 0x1f, 0x0a, 0x0b, 0x67, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x5f, 0x69, 0x64, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x67, 0x6c, 0x6f, 0x73, 0x73, 0x61, 0x72, 0x79, 0x49,
+	0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x6d, 0x70,
+	0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72,
+	0x6f, 0x6d, 0x70, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x6d,
+	0x70, 0x74, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12,
+	0x29, 0x0a, 0x10, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61,
+	0x67, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x61, 0x72, 0x67, 0x65,
+	0x74, 0x4c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70,
+	0x61, 0x72, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x33, 0x0a, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64,
+	0x72, 0x65, 0x6e, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x61, 0x70, 0x65,
 	0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x70,
-	0x65, 0x72, 0x52, 0x06, 0x70, 0x61, 0x70, 0x65, 0x72, 0x73, 0x32, 0x96, 0x02, 0x0a, 0x0c, 0x50,
-	0x61, 0x70, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x40, 0x0a, 0x06, 0x43,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x50,
-	0x61, 0x70, 0x65, 0x72, 0x1a, 0x17, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x70, 0x65, 0x72, 0x12, 0x3b, 0x0a,
-	0x05, 0x46, 0x65, 0x74, 0x63, 0x68, 0x12, 0x19, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73,
-	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x70, 0x65, 0x72, 0x49,
-	0x44, 0x1a, 0x17, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x70, 0x65, 0x72, 0x12, 0x42, 0x0a, 0x06, 0x44, 0x65,
-	0x6c, 0x65, 0x74, 0x65, 0x12, 0x19, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72,
+	0x65, 0x72, 0x52, 0x08, 0x63, 0x68, 0x69, 0x6c, 0x64, 0x72, 0x65, 0x6e, 0x12, 0x27, 0x0a, 0x0f,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x61, 0x6e,
+	0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f,
+	0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0b, 0x66, 0x61, 0x69,
+	0x6c, 0x65, 0x64, 0x50, 0x61, 0x67, 0x65, 0x73, 0x22, 0x4b, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x07, 0x0a, 0x03, 0x6f, 0x63, 0x72, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x10, 0x01, 0x12, 0x0c, 0x0a, 0x08,
+	0x66, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x10, 0x02, 0x12, 0x0a, 0x0a, 0x06, 0x66, 0x61,
+	0x69, 0x6c, 0x65, 0x64, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x6c, 0x65, 0x64, 0x10, 0x04, 0x22, 0xd3, 0x02, 0x0a, 0x0a, 0x50, 0x61, 0x70, 0x65, 0x72, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x35, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x21, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x70, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x36, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x70, 0x61,
+	0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x61, 0x70, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x6f, 0x6e,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x64, 0x6f, 0x6e, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x21, 0x0a,
+	0x0c, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x08, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x0b, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x50, 0x61, 0x67, 0x65, 0x73,
+	0x22, 0x49, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67,
+	0x65, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x6f, 0x63, 0x72, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72,
+	0x65, 0x73, 0x73, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x10, 0x02, 0x12,
+	0x0a, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x10, 0x03, 0x22, 0x19, 0x0a, 0x07, 0x50,
+	0x61, 0x70, 0x65, 0x72, 0x49, 0x44, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x35, 0x0a, 0x0d, 0x52, 0x65, 0x71, 0x52, 0x65, 0x72,
+	0x75, 0x6e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65, 0x22, 0x0d, 0x0a,
+	0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x61, 0x70, 0x65, 0x72, 0x22, 0x0b, 0x0a, 0x09,
+	0x52, 0x65, 0x71, 0x46, 0x65, 0x74, 0x63, 0x68, 0x73, 0x22, 0x53, 0x0a, 0x0a, 0x52, 0x65, 0x73,
+	0x70, 0x46, 0x65, 0x74, 0x63, 0x68, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x2f, 0x0a,
+	0x06, 0x70, 0x61, 0x70, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x61, 0x70, 0x65, 0x72, 0x52, 0x06, 0x70, 0x61, 0x70, 0x65, 0x72, 0x73, 0x32, 0x9d,
+	0x04, 0x0a, 0x0c, 0x50, 0x61, 0x70, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x40, 0x0a, 0x06, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x2e, 0x70, 0x61, 0x70, 0x65,
+	0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x50, 0x61, 0x70, 0x65, 0x72, 0x1a, 0x17, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x70, 0x65,
+	0x72, 0x12, 0x3b, 0x0a, 0x05, 0x46, 0x65, 0x74, 0x63, 0x68, 0x12, 0x19, 0x2e, 0x70, 0x61, 0x70,
+	0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61,
+	0x70, 0x65, 0x72, 0x49, 0x44, 0x1a, 0x17, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x70, 0x65, 0x72, 0x12, 0x42,
+	0x0a, 0x06, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x12, 0x19, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x70, 0x65,
+	0x72, 0x49, 0x44, 0x1a, 0x1d, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x61, 0x70,
+	0x65, 0x72, 0x12, 0x43, 0x0a, 0x06, 0x46, 0x65, 0x74, 0x63, 0x68, 0x73, 0x12, 0x1b, 0x2e, 0x70,
+	0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x71, 0x46, 0x65, 0x74, 0x63, 0x68, 0x73, 0x1a, 0x1c, 0x2e, 0x70, 0x61, 0x70, 0x65,
+	0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73,
+	0x70, 0x46, 0x65, 0x74, 0x63, 0x68, 0x73, 0x12, 0x42, 0x0a, 0x05, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x19, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x70, 0x65, 0x72, 0x49, 0x44, 0x1a, 0x1c, 0x2e, 0x70, 0x61,
+	0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x61, 0x70, 0x65, 0x72, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x3b, 0x0a, 0x05, 0x52,
+	0x65, 0x74, 0x72, 0x79, 0x12, 0x19, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72,
 	0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x70, 0x65, 0x72, 0x49, 0x44, 0x1a,
-	0x1d, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
-	0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x50, 0x61, 0x70, 0x65, 0x72, 0x12, 0x43,
-	0x0a, 0x06, 0x46, 0x65, 0x74, 0x63, 0x68, 0x73, 0x12, 0x1b, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72,
-	0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x46,
-	0x65, 0x74, 0x63, 0x68, 0x73, 0x1a, 0x1c, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x46, 0x65, 0x74,
-	0x63, 0x68, 0x73, 0x42, 0x1b, 0x5a, 0x19, 0x2e, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x61, 0x70,
-	0x65, 0x72, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x17, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x50, 0x61, 0x70, 0x65, 0x72, 0x12, 0x3c, 0x0a, 0x06, 0x43, 0x61, 0x6e, 0x63,
+	0x65, 0x6c, 0x12, 0x19, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x70, 0x65, 0x72, 0x49, 0x44, 0x1a, 0x17, 0x2e,
+	0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x61, 0x70, 0x65, 0x72, 0x12, 0x46, 0x0a, 0x0a, 0x52, 0x65, 0x72, 0x75, 0x6e, 0x53,
+	0x74, 0x61, 0x67, 0x65, 0x12, 0x1f, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x52, 0x65, 0x72, 0x75, 0x6e,
+	0x53, 0x74, 0x61, 0x67, 0x65, 0x1a, 0x17, 0x2e, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x70, 0x65, 0x72, 0x42, 0x1b,
+	0x5a, 0x19, 0x2e, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x70, 0x61, 0x70, 0x65, 0x72, 0x2f, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -473,33 +981,49 @@ func file_paper_proto_rawDescGZIP() []byte {
 	return file_paper_proto_rawDescData
 }
 
-var file_paper_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_paper_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_paper_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_paper_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_paper_proto_goTypes = []interface{}{
-	(Paper_Status)(0),   // 0: paper.service.v1.Paper.Status
-	(*CreatePaper)(nil), // 1: paper.service.v1.CreatePaper
-	(*Paper)(nil),       // 2: paper.service.v1.Paper
-	(*PaperID)(nil),     // 3: paper.service.v1.PaperID
-	(*DeletePaper)(nil), // 4: paper.service.v1.DeletePaper
-	(*ReqFetchs)(nil),   // 5: paper.service.v1.ReqFetchs
-	(*RespFetchs)(nil),  // 6: paper.service.v1.RespFetchs
+	(Paper_Status)(0),     // 0: paper.service.v1.Paper.Status
+	(PaperEvent_Type)(0),  // 1: paper.service.v1.PaperEvent.Type
+	(*CreatePaper)(nil),   // 2: paper.service.v1.CreatePaper
+	(*Failure)(nil),       // 3: paper.service.v1.Failure
+	(*Paper)(nil),         // 4: paper.service.v1.Paper
+	(*PaperEvent)(nil),    // 5: paper.service.v1.PaperEvent
+	(*PaperID)(nil),       // 6: paper.service.v1.PaperID
+	(*ReqRerunStage)(nil), // 7: paper.service.v1.ReqRerunStage
+	(*DeletePaper)(nil),   // 8: paper.service.v1.DeletePaper
+	(*ReqFetchs)(nil),     // 9: paper.service.v1.ReqFetchs
+	(*RespFetchs)(nil),    // 10: paper.service.v1.RespFetchs
 }
 var file_paper_proto_depIdxs = []int32{
-	0, // 0: paper.service.v1.Paper.status:type_name -> paper.service.v1.Paper.Status
-	2, // 1: paper.service.v1.RespFetchs.papers:type_name -> paper.service.v1.Paper
-	1, // 2: paper.service.v1.PaperService.Create:input_type -> paper.service.v1.CreatePaper
-	3, // 3: paper.service.v1.PaperService.Fetch:input_type -> paper.service.v1.PaperID
-	3, // 4: paper.service.v1.PaperService.Delete:input_type -> paper.service.v1.PaperID
-	5, // 5: paper.service.v1.PaperService.Fetchs:input_type -> paper.service.v1.ReqFetchs
-	2, // 6: paper.service.v1.PaperService.Create:output_type -> paper.service.v1.Paper
-	2, // 7: paper.service.v1.PaperService.Fetch:output_type -> paper.service.v1.Paper
-	4, // 8: paper.service.v1.PaperService.Delete:output_type -> paper.service.v1.DeletePaper
-	6, // 9: paper.service.v1.PaperService.Fetchs:output_type -> paper.service.v1.RespFetchs
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	0,  // 0: paper.service.v1.Paper.status:type_name -> paper.service.v1.Paper.Status
+	3,  // 1: paper.service.v1.Paper.failure:type_name -> paper.service.v1.Failure
+	4,  // 2: paper.service.v1.Paper.children:type_name -> paper.service.v1.Paper
+	1,  // 3: paper.service.v1.PaperEvent.type:type_name -> paper.service.v1.PaperEvent.Type
+	0,  // 4: paper.service.v1.PaperEvent.status:type_name -> paper.service.v1.Paper.Status
+	4,  // 5: paper.service.v1.RespFetchs.papers:type_name -> paper.service.v1.Paper
+	2,  // 6: paper.service.v1.PaperService.Create:input_type -> paper.service.v1.CreatePaper
+	6,  // 7: paper.service.v1.PaperService.Fetch:input_type -> paper.service.v1.PaperID
+	6,  // 8: paper.service.v1.PaperService.Delete:input_type -> paper.service.v1.PaperID
+	9,  // 9: paper.service.v1.PaperService.Fetchs:input_type -> paper.service.v1.ReqFetchs
+	6,  // 10: paper.service.v1.PaperService.Watch:input_type -> paper.service.v1.PaperID
+	6,  // 11: paper.service.v1.PaperService.Retry:input_type -> paper.service.v1.PaperID
+	6,  // 12: paper.service.v1.PaperService.Cancel:input_type -> paper.service.v1.PaperID
+	7,  // 13: paper.service.v1.PaperService.RerunStage:input_type -> paper.service.v1.ReqRerunStage
+	4,  // 14: paper.service.v1.PaperService.Create:output_type -> paper.service.v1.Paper
+	4,  // 15: paper.service.v1.PaperService.Fetch:output_type -> paper.service.v1.Paper
+	8,  // 16: paper.service.v1.PaperService.Delete:output_type -> paper.service.v1.DeletePaper
+	10, // 17: paper.service.v1.PaperService.Fetchs:output_type -> paper.service.v1.RespFetchs
+	5,  // 18: paper.service.v1.PaperService.Watch:output_type -> paper.service.v1.PaperEvent
+	4,  // 19: paper.service.v1.PaperService.Retry:output_type -> paper.service.v1.Paper
+	4,  // 20: paper.service.v1.PaperService.Cancel:output_type -> paper.service.v1.Paper
+	4,  // 21: paper.service.v1.PaperService.RerunStage:output_type -> paper.service.v1.Paper
+	14, // [14:22] is the sub-list for method output_type
+	6,  // [6:14] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_paper_proto_init() }
@@ -521,7 +1045,7 @@ func file_paper_proto_init() {
 			}
 		}
 		file_paper_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Paper); i {
+			switch v := v.(*Failure); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -533,7 +1057,7 @@ func file_paper_proto_init() {
 			}
 		}
 		file_paper_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PaperID); i {
+			switch v := v.(*Paper); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -545,7 +1069,7 @@ func file_paper_proto_init() {
 			}
 		}
 		file_paper_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeletePaper); i {
+			switch v := v.(*PaperEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -557,7 +1081,7 @@ func file_paper_proto_init() {
 			}
 		}
 		file_paper_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReqFetchs); i {
+			switch v := v.(*PaperID); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -569,6 +1093,42 @@ func file_paper_proto_init() {
 			}
 		}
 		file_paper_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReqRerunStage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_paper_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeletePaper); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_paper_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReqFetchs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_paper_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RespFetchs); i {
 			case 0:
 				return &v.state
@@ -586,8 +1146,8 @@ func file_paper_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_paper_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   6,
+			NumEnums:      2,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -40,6 +40,10 @@ type PaperService interface {
 	Fetch(ctx context.Context, in *PaperID, opts ...client.CallOption) (*Paper, error)
 	Delete(ctx context.Context, in *PaperID, opts ...client.CallOption) (*DeletePaper, error)
 	Fetchs(ctx context.Context, in *ReqFetchs, opts ...client.CallOption) (*RespFetchs, error)
+	Watch(ctx context.Context, in *PaperID, opts ...client.CallOption) (PaperService_WatchService, error)
+	Retry(ctx context.Context, in *PaperID, opts ...client.CallOption) (*Paper, error)
+	Cancel(ctx context.Context, in *PaperID, opts ...client.CallOption) (*Paper, error)
+	RerunStage(ctx context.Context, in *ReqRerunStage, opts ...client.CallOption) (*Paper, error)
 }
 
 type paperService struct {
@@ -94,6 +98,90 @@ func (c *paperService) Fetchs(ctx context.Context, in *ReqFetchs, opts ...client
 	return out, nil
 }
 
+func (c *paperService) Watch(ctx context.Context, in *PaperID, opts ...client.CallOption) (PaperService_WatchService, error) {
+	req := c.c.NewRequest(c.name, "PaperService.Watch", &PaperID{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &paperServiceWatch{stream}, nil
+}
+
+type PaperService_WatchService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	CloseSend() error
+	Close() error
+	Recv() (*PaperEvent, error)
+}
+
+type paperServiceWatch struct {
+	stream client.Stream
+}
+
+func (x *paperServiceWatch) CloseSend() error {
+	return x.stream.CloseSend()
+}
+
+func (x *paperServiceWatch) Close() error {
+	return x.stream.Close()
+}
+
+func (x *paperServiceWatch) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *paperServiceWatch) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *paperServiceWatch) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *paperServiceWatch) Recv() (*PaperEvent, error) {
+	m := new(PaperEvent)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *paperService) Retry(ctx context.Context, in *PaperID, opts ...client.CallOption) (*Paper, error) {
+	req := c.c.NewRequest(c.name, "PaperService.Retry", in)
+	out := new(Paper)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paperService) Cancel(ctx context.Context, in *PaperID, opts ...client.CallOption) (*Paper, error) {
+	req := c.c.NewRequest(c.name, "PaperService.Cancel", in)
+	out := new(Paper)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paperService) RerunStage(ctx context.Context, in *ReqRerunStage, opts ...client.CallOption) (*Paper, error) {
+	req := c.c.NewRequest(c.name, "PaperService.RerunStage", in)
+	out := new(Paper)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for PaperService service
 
 type PaperServiceHandler interface {
@@ -101,6 +189,10 @@ type PaperServiceHandler interface {
 	Fetch(context.Context, *PaperID, *Paper) error
 	Delete(context.Context, *PaperID, *DeletePaper) error
 	Fetchs(context.Context, *ReqFetchs, *RespFetchs) error
+	Watch(context.Context, *PaperID, PaperService_WatchStream) error
+	Retry(context.Context, *PaperID, *Paper) error
+	Cancel(context.Context, *PaperID, *Paper) error
+	RerunStage(context.Context, *ReqRerunStage, *Paper) error
 }
 
 func RegisterPaperServiceHandler(s server.Server, hdlr PaperServiceHandler, opts ...server.HandlerOption) error {
@@ -109,6 +201,10 @@ func RegisterPaperServiceHandler(s server.Server, hdlr PaperServiceHandler, opts
 		Fetch(ctx context.Context, in *PaperID, out *Paper) error
 		Delete(ctx context.Context, in *PaperID, out *DeletePaper) error
 		Fetchs(ctx context.Context, in *ReqFetchs, out *RespFetchs) error
+		Watch(ctx context.Context, stream server.Stream) error
+		Retry(ctx context.Context, in *PaperID, out *Paper) error
+		Cancel(ctx context.Context, in *PaperID, out *Paper) error
+		RerunStage(ctx context.Context, in *ReqRerunStage, out *Paper) error
 	}
 	type PaperService struct {
 		paperService
@@ -136,3 +232,55 @@ func (h *paperServiceHandler) Delete(ctx context.Context, in *PaperID, out *Dele
 func (h *paperServiceHandler) Fetchs(ctx context.Context, in *ReqFetchs, out *RespFetchs) error {
 	return h.PaperServiceHandler.Fetchs(ctx, in, out)
 }
+
+func (h *paperServiceHandler) Watch(ctx context.Context, stream server.Stream) error {
+	m := new(PaperID)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.PaperServiceHandler.Watch(ctx, m, &paperServiceWatchStream{stream})
+}
+
+type PaperService_WatchStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*PaperEvent) error
+}
+
+type paperServiceWatchStream struct {
+	stream server.Stream
+}
+
+func (x *paperServiceWatchStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *paperServiceWatchStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *paperServiceWatchStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *paperServiceWatchStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *paperServiceWatchStream) Send(m *PaperEvent) error {
+	return x.stream.Send(m)
+}
+
+func (h *paperServiceHandler) Retry(ctx context.Context, in *PaperID, out *Paper) error {
+	return h.PaperServiceHandler.Retry(ctx, in, out)
+}
+
+func (h *paperServiceHandler) Cancel(ctx context.Context, in *PaperID, out *Paper) error {
+	return h.PaperServiceHandler.Cancel(ctx, in, out)
+}
+
+func (h *paperServiceHandler) RerunStage(ctx context.Context, in *ReqRerunStage, out *Paper) error {
+	return h.PaperServiceHandler.RerunStage(ctx, in, out)
+}
@@ -38,6 +38,20 @@ func NewTranslationServiceEndpoints() []*api.Endpoint {
 type TranslationService interface {
 	Translate(ctx context.Context, in *Translation, opts ...client.CallOption) (*TranslationID, error)
 	GetStatus(ctx context.Context, in *TranslationID, opts ...client.CallOption) (*TranslatedText, error)
+	WatchStatus(ctx context.Context, in *TranslationID, opts ...client.CallOption) (TranslationService_WatchStatusService, error)
+	Cancel(ctx context.Context, in *TranslationID, opts ...client.CallOption) (*TranslationCancel, error)
+	FetchMemory(ctx context.Context, in *MemoryQuery, opts ...client.CallOption) (*MemoryEntries, error)
+	InvalidateMemory(ctx context.Context, in *MemoryQuery, opts ...client.CallOption) (*MemoryInvalidated, error)
+	ExportMemory(ctx context.Context, in *MemoryQuery, opts ...client.CallOption) (TranslationService_ExportMemoryService, error)
+	ExportTM(ctx context.Context, in *TMExport, opts ...client.CallOption) (TranslationService_ExportTMService, error)
+	ImportTM(ctx context.Context, opts ...client.CallOption) (TranslationService_ImportTMService, error)
+	CreateGlossary(ctx context.Context, in *Glossary, opts ...client.CallOption) (*Glossary, error)
+	UpdateGlossary(ctx context.Context, in *Glossary, opts ...client.CallOption) (*Glossary, error)
+	DeleteGlossary(ctx context.Context, in *GlossaryID, opts ...client.CallOption) (*GlossaryDeleted, error)
+	FetchGlossary(ctx context.Context, in *GlossaryID, opts ...client.CallOption) (*Glossary, error)
+	FetchGlossaries(ctx context.Context, in *GlossaryQuery, opts ...client.CallOption) (*Glossaries, error)
+	SavePrompt(ctx context.Context, in *PromptTemplate, opts ...client.CallOption) (*PromptTemplate, error)
+	FetchPrompts(ctx context.Context, in *PromptQuery, opts ...client.CallOption) (*PromptTemplates, error)
 }
 
 type translationService struct {
@@ -72,17 +86,363 @@ func (c *translationService) GetStatus(ctx context.Context, in *TranslationID, o
 	return out, nil
 }
 
+func (c *translationService) WatchStatus(ctx context.Context, in *TranslationID, opts ...client.CallOption) (TranslationService_WatchStatusService, error) {
+	req := c.c.NewRequest(c.name, "TranslationService.WatchStatus", &TranslationID{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &translationServiceWatchStatus{stream}, nil
+}
+
+type TranslationService_WatchStatusService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	CloseSend() error
+	Close() error
+	Recv() (*TranslationProgress, error)
+}
+
+type translationServiceWatchStatus struct {
+	stream client.Stream
+}
+
+func (x *translationServiceWatchStatus) CloseSend() error {
+	return x.stream.CloseSend()
+}
+
+func (x *translationServiceWatchStatus) Close() error {
+	return x.stream.Close()
+}
+
+func (x *translationServiceWatchStatus) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *translationServiceWatchStatus) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *translationServiceWatchStatus) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *translationServiceWatchStatus) Recv() (*TranslationProgress, error) {
+	m := new(TranslationProgress)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *translationService) Cancel(ctx context.Context, in *TranslationID, opts ...client.CallOption) (*TranslationCancel, error) {
+	req := c.c.NewRequest(c.name, "TranslationService.Cancel", in)
+	out := new(TranslationCancel)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationService) FetchMemory(ctx context.Context, in *MemoryQuery, opts ...client.CallOption) (*MemoryEntries, error) {
+	req := c.c.NewRequest(c.name, "TranslationService.FetchMemory", in)
+	out := new(MemoryEntries)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationService) InvalidateMemory(ctx context.Context, in *MemoryQuery, opts ...client.CallOption) (*MemoryInvalidated, error) {
+	req := c.c.NewRequest(c.name, "TranslationService.InvalidateMemory", in)
+	out := new(MemoryInvalidated)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationService) ExportMemory(ctx context.Context, in *MemoryQuery, opts ...client.CallOption) (TranslationService_ExportMemoryService, error) {
+	req := c.c.NewRequest(c.name, "TranslationService.ExportMemory", &MemoryQuery{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &translationServiceExportMemory{stream}, nil
+}
+
+type TranslationService_ExportMemoryService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	CloseSend() error
+	Close() error
+	Recv() (*MemoryEntry, error)
+}
+
+type translationServiceExportMemory struct {
+	stream client.Stream
+}
+
+func (x *translationServiceExportMemory) CloseSend() error {
+	return x.stream.CloseSend()
+}
+
+func (x *translationServiceExportMemory) Close() error {
+	return x.stream.Close()
+}
+
+func (x *translationServiceExportMemory) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *translationServiceExportMemory) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *translationServiceExportMemory) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *translationServiceExportMemory) Recv() (*MemoryEntry, error) {
+	m := new(MemoryEntry)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *translationService) ExportTM(ctx context.Context, in *TMExport, opts ...client.CallOption) (TranslationService_ExportTMService, error) {
+	req := c.c.NewRequest(c.name, "TranslationService.ExportTM", &TMExport{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := stream.Send(in); err != nil {
+		return nil, err
+	}
+	return &translationServiceExportTM{stream}, nil
+}
+
+type TranslationService_ExportTMService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	CloseSend() error
+	Close() error
+	Recv() (*TMXChunk, error)
+}
+
+type translationServiceExportTM struct {
+	stream client.Stream
+}
+
+func (x *translationServiceExportTM) CloseSend() error {
+	return x.stream.CloseSend()
+}
+
+func (x *translationServiceExportTM) Close() error {
+	return x.stream.Close()
+}
+
+func (x *translationServiceExportTM) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *translationServiceExportTM) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *translationServiceExportTM) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *translationServiceExportTM) Recv() (*TMXChunk, error) {
+	m := new(TMXChunk)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *translationService) ImportTM(ctx context.Context, opts ...client.CallOption) (TranslationService_ImportTMService, error) {
+	req := c.c.NewRequest(c.name, "TranslationService.ImportTM", &TMXChunk{})
+	stream, err := c.c.Stream(ctx, req, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &translationServiceImportTM{stream}, nil
+}
+
+type TranslationService_ImportTMService interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	CloseSend() error
+	Close() error
+	Send(*TMXChunk) error
+	Recv() (*TMImportProgress, error)
+}
+
+type translationServiceImportTM struct {
+	stream client.Stream
+}
+
+func (x *translationServiceImportTM) CloseSend() error {
+	return x.stream.CloseSend()
+}
+
+func (x *translationServiceImportTM) Close() error {
+	return x.stream.Close()
+}
+
+func (x *translationServiceImportTM) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *translationServiceImportTM) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *translationServiceImportTM) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *translationServiceImportTM) Send(m *TMXChunk) error {
+	return x.stream.Send(m)
+}
+
+func (x *translationServiceImportTM) Recv() (*TMImportProgress, error) {
+	m := new(TMImportProgress)
+	err := x.stream.Recv(m)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *translationService) CreateGlossary(ctx context.Context, in *Glossary, opts ...client.CallOption) (*Glossary, error) {
+	req := c.c.NewRequest(c.name, "TranslationService.CreateGlossary", in)
+	out := new(Glossary)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationService) UpdateGlossary(ctx context.Context, in *Glossary, opts ...client.CallOption) (*Glossary, error) {
+	req := c.c.NewRequest(c.name, "TranslationService.UpdateGlossary", in)
+	out := new(Glossary)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationService) DeleteGlossary(ctx context.Context, in *GlossaryID, opts ...client.CallOption) (*GlossaryDeleted, error) {
+	req := c.c.NewRequest(c.name, "TranslationService.DeleteGlossary", in)
+	out := new(GlossaryDeleted)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationService) FetchGlossary(ctx context.Context, in *GlossaryID, opts ...client.CallOption) (*Glossary, error) {
+	req := c.c.NewRequest(c.name, "TranslationService.FetchGlossary", in)
+	out := new(Glossary)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationService) FetchGlossaries(ctx context.Context, in *GlossaryQuery, opts ...client.CallOption) (*Glossaries, error) {
+	req := c.c.NewRequest(c.name, "TranslationService.FetchGlossaries", in)
+	out := new(Glossaries)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationService) SavePrompt(ctx context.Context, in *PromptTemplate, opts ...client.CallOption) (*PromptTemplate, error) {
+	req := c.c.NewRequest(c.name, "TranslationService.SavePrompt", in)
+	out := new(PromptTemplate)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *translationService) FetchPrompts(ctx context.Context, in *PromptQuery, opts ...client.CallOption) (*PromptTemplates, error) {
+	req := c.c.NewRequest(c.name, "TranslationService.FetchPrompts", in)
+	out := new(PromptTemplates)
+	err := c.c.Call(ctx, req, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for TranslationService service
 
 type TranslationServiceHandler interface {
 	Translate(context.Context, *Translation, *TranslationID) error
 	GetStatus(context.Context, *TranslationID, *TranslatedText) error
+	WatchStatus(context.Context, *TranslationID, TranslationService_WatchStatusStream) error
+	Cancel(context.Context, *TranslationID, *TranslationCancel) error
+	FetchMemory(context.Context, *MemoryQuery, *MemoryEntries) error
+	InvalidateMemory(context.Context, *MemoryQuery, *MemoryInvalidated) error
+	ExportMemory(context.Context, *MemoryQuery, TranslationService_ExportMemoryStream) error
+	ExportTM(context.Context, *TMExport, TranslationService_ExportTMStream) error
+	ImportTM(context.Context, TranslationService_ImportTMStream) error
+	CreateGlossary(context.Context, *Glossary, *Glossary) error
+	UpdateGlossary(context.Context, *Glossary, *Glossary) error
+	DeleteGlossary(context.Context, *GlossaryID, *GlossaryDeleted) error
+	FetchGlossary(context.Context, *GlossaryID, *Glossary) error
+	FetchGlossaries(context.Context, *GlossaryQuery, *Glossaries) error
+	SavePrompt(context.Context, *PromptTemplate, *PromptTemplate) error
+	FetchPrompts(context.Context, *PromptQuery, *PromptTemplates) error
 }
 
 func RegisterTranslationServiceHandler(s server.Server, hdlr TranslationServiceHandler, opts ...server.HandlerOption) error {
 	type translationService interface {
 		Translate(ctx context.Context, in *Translation, out *TranslationID) error
 		GetStatus(ctx context.Context, in *TranslationID, out *TranslatedText) error
+		WatchStatus(ctx context.Context, stream server.Stream) error
+		Cancel(ctx context.Context, in *TranslationID, out *TranslationCancel) error
+		FetchMemory(ctx context.Context, in *MemoryQuery, out *MemoryEntries) error
+		InvalidateMemory(ctx context.Context, in *MemoryQuery, out *MemoryInvalidated) error
+		ExportMemory(ctx context.Context, stream server.Stream) error
+		ExportTM(ctx context.Context, stream server.Stream) error
+		ImportTM(ctx context.Context, stream server.Stream) error
+		CreateGlossary(ctx context.Context, in *Glossary, out *Glossary) error
+		UpdateGlossary(ctx context.Context, in *Glossary, out *Glossary) error
+		DeleteGlossary(ctx context.Context, in *GlossaryID, out *GlossaryDeleted) error
+		FetchGlossary(ctx context.Context, in *GlossaryID, out *Glossary) error
+		FetchGlossaries(ctx context.Context, in *GlossaryQuery, out *Glossaries) error
+		SavePrompt(ctx context.Context, in *PromptTemplate, out *PromptTemplate) error
+		FetchPrompts(ctx context.Context, in *PromptQuery, out *PromptTemplates) error
 	}
 	type TranslationService struct {
 		translationService
@@ -102,3 +462,208 @@ func (h *translationServiceHandler) Translate(ctx context.Context, in *Translati
 func (h *translationServiceHandler) GetStatus(ctx context.Context, in *TranslationID, out *TranslatedText) error {
 	return h.TranslationServiceHandler.GetStatus(ctx, in, out)
 }
+
+func (h *translationServiceHandler) WatchStatus(ctx context.Context, stream server.Stream) error {
+	m := new(TranslationID)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.TranslationServiceHandler.WatchStatus(ctx, m, &translationServiceWatchStatusStream{stream})
+}
+
+type TranslationService_WatchStatusStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*TranslationProgress) error
+}
+
+type translationServiceWatchStatusStream struct {
+	stream server.Stream
+}
+
+func (x *translationServiceWatchStatusStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *translationServiceWatchStatusStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *translationServiceWatchStatusStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *translationServiceWatchStatusStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *translationServiceWatchStatusStream) Send(m *TranslationProgress) error {
+	return x.stream.Send(m)
+}
+
+func (h *translationServiceHandler) Cancel(ctx context.Context, in *TranslationID, out *TranslationCancel) error {
+	return h.TranslationServiceHandler.Cancel(ctx, in, out)
+}
+
+func (h *translationServiceHandler) FetchMemory(ctx context.Context, in *MemoryQuery, out *MemoryEntries) error {
+	return h.TranslationServiceHandler.FetchMemory(ctx, in, out)
+}
+
+func (h *translationServiceHandler) InvalidateMemory(ctx context.Context, in *MemoryQuery, out *MemoryInvalidated) error {
+	return h.TranslationServiceHandler.InvalidateMemory(ctx, in, out)
+}
+
+func (h *translationServiceHandler) ExportMemory(ctx context.Context, stream server.Stream) error {
+	m := new(MemoryQuery)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.TranslationServiceHandler.ExportMemory(ctx, m, &translationServiceExportMemoryStream{stream})
+}
+
+type TranslationService_ExportMemoryStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*MemoryEntry) error
+}
+
+type translationServiceExportMemoryStream struct {
+	stream server.Stream
+}
+
+func (x *translationServiceExportMemoryStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *translationServiceExportMemoryStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *translationServiceExportMemoryStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *translationServiceExportMemoryStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *translationServiceExportMemoryStream) Send(m *MemoryEntry) error {
+	return x.stream.Send(m)
+}
+
+func (h *translationServiceHandler) ExportTM(ctx context.Context, stream server.Stream) error {
+	m := new(TMExport)
+	if err := stream.Recv(m); err != nil {
+		return err
+	}
+	return h.TranslationServiceHandler.ExportTM(ctx, m, &translationServiceExportTMStream{stream})
+}
+
+type TranslationService_ExportTMStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*TMXChunk) error
+}
+
+type translationServiceExportTMStream struct {
+	stream server.Stream
+}
+
+func (x *translationServiceExportTMStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *translationServiceExportTMStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *translationServiceExportTMStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *translationServiceExportTMStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *translationServiceExportTMStream) Send(m *TMXChunk) error {
+	return x.stream.Send(m)
+}
+
+func (h *translationServiceHandler) ImportTM(ctx context.Context, stream server.Stream) error {
+	return h.TranslationServiceHandler.ImportTM(ctx, &translationServiceImportTMStream{stream})
+}
+
+type TranslationService_ImportTMStream interface {
+	Context() context.Context
+	SendMsg(interface{}) error
+	RecvMsg(interface{}) error
+	Close() error
+	Send(*TMImportProgress) error
+	Recv() (*TMXChunk, error)
+}
+
+type translationServiceImportTMStream struct {
+	stream server.Stream
+}
+
+func (x *translationServiceImportTMStream) Close() error {
+	return x.stream.Close()
+}
+
+func (x *translationServiceImportTMStream) Context() context.Context {
+	return x.stream.Context()
+}
+
+func (x *translationServiceImportTMStream) SendMsg(m interface{}) error {
+	return x.stream.Send(m)
+}
+
+func (x *translationServiceImportTMStream) RecvMsg(m interface{}) error {
+	return x.stream.Recv(m)
+}
+
+func (x *translationServiceImportTMStream) Send(m *TMImportProgress) error {
+	return x.stream.Send(m)
+}
+
+func (x *translationServiceImportTMStream) Recv() (*TMXChunk, error) {
+	m := new(TMXChunk)
+	if err := x.stream.Recv(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (h *translationServiceHandler) CreateGlossary(ctx context.Context, in *Glossary, out *Glossary) error {
+	return h.TranslationServiceHandler.CreateGlossary(ctx, in, out)
+}
+
+func (h *translationServiceHandler) UpdateGlossary(ctx context.Context, in *Glossary, out *Glossary) error {
+	return h.TranslationServiceHandler.UpdateGlossary(ctx, in, out)
+}
+
+func (h *translationServiceHandler) DeleteGlossary(ctx context.Context, in *GlossaryID, out *GlossaryDeleted) error {
+	return h.TranslationServiceHandler.DeleteGlossary(ctx, in, out)
+}
+
+func (h *translationServiceHandler) FetchGlossary(ctx context.Context, in *GlossaryID, out *Glossary) error {
+	return h.TranslationServiceHandler.FetchGlossary(ctx, in, out)
+}
+
+func (h *translationServiceHandler) FetchGlossaries(ctx context.Context, in *GlossaryQuery, out *Glossaries) error {
+	return h.TranslationServiceHandler.FetchGlossaries(ctx, in, out)
+}
+
+func (h *translationServiceHandler) SavePrompt(ctx context.Context, in *PromptTemplate, out *PromptTemplate) error {
+	return h.TranslationServiceHandler.SavePrompt(ctx, in, out)
+}
+
+func (h *translationServiceHandler) FetchPrompts(ctx context.Context, in *PromptQuery, out *PromptTemplates) error {
+	return h.TranslationServiceHandler.FetchPrompts(ctx, in, out)
+}
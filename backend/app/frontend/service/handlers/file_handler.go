@@ -1,17 +1,18 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"mime/multipart"
-	"net/http"
 	fs "paper-translation/api/file/service/v1"
 	"paper-translation/pkg/errutil"
+	"paper-translation/pkg/storage"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
-	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 	"go.mongodb.org/mongo-driver/bson"
@@ -40,12 +41,12 @@ type ReqUploadChunk struct {
 // FileHandler 是处理文件相关操作的处理器。
 type FileHandler struct {
 	fileService fs.FileService
-	oss         *oss.Client
+	store       storage.ObjectStore
 }
 
 // NewFileHandler 创建一个新的 FileHandler 实例。
-func NewFileHandler(fileService fs.FileService, oss *oss.Client) *FileHandler {
-	return &FileHandler{fileService: fileService, oss: oss}
+func NewFileHandler(fileService fs.FileService, store storage.ObjectStore) *FileHandler {
+	return &FileHandler{fileService: fileService, store: store}
 }
 
 // StartUploadFile 处理开始上传文件的请求。
@@ -87,15 +88,10 @@ func (f *FileHandler) UploadChunk(ctx *gin.Context) {
 		return
 	}
 
-	bkt, err := f.oss.Bucket(Bucket)
-	if err != nil {
-		errutil.ResponseError(ctx, errutil.UnknownError, err)
-		return
-	}
-
 	key := fmt.Sprintf("chunks/%s/%d", req.Hash, req.ChunkIndex)
 	// 将文件块上传到对象存储。
-	err = bkt.PutObject(key, file)
+	err = f.store.Put(ctx, Bucket, key, file)
+	_ = file.Close()
 	if err != nil {
 		errutil.ResponseError(ctx, errutil.UnknownError, err)
 		return
@@ -113,17 +109,12 @@ func (f *FileHandler) UploadChunk(ctx *gin.Context) {
 
 	// 如果文件已完全上传，将分块合并成完整文件。
 	if fileInfo.Status == fs.FileStatus_Uploaded {
-		result, err := bkt.ListObjectsV2(oss.Prefix(fmt.Sprintf("chunks/%s/", req.Hash)))
+		keys, err := f.store.List(ctx, Bucket, fmt.Sprintf("chunks/%s/", req.Hash))
 		if err != nil {
 			errutil.ResponseError(ctx, errutil.UnknownError, err)
 			return
 		}
 
-		var keys = make([]string, 0)
-		for _, object := range result.Objects {
-			keys = append(keys, object.Key)
-		}
-
 		// 对分块文件按索引排序，以确保正确顺序合并。
 		sort.SliceStable(keys, func(i, j int) bool {
 			ix, _ := strconv.ParseInt(strings.Split(keys[i], "/")[2], 10, 64)
@@ -131,29 +122,19 @@ func (f *FileHandler) UploadChunk(ctx *gin.Context) {
 			return ix < jx
 		})
 
-		var appendPosition = int64(0)
-		for _, objectKey := range keys {
-			log.Printf("append file: %s", objectKey)
-			obj, err := bkt.GetObject(objectKey)
-			if err != nil {
-				errutil.ResponseError(ctx, errutil.UnknownError, err)
-				return
-			}
-			// 合并分块文件。
-			nextPosition, err := bkt.AppendObject(*fileInfo.FilePath, obj, appendPosition)
-			if err != nil {
-				errutil.ResponseError(ctx, errutil.UnknownError, err)
-				return
-			}
-			appendPosition = nextPosition
-			obj.Close()
+		// 合并分块文件。
+		log.Printf("compose file %s from: %v", *fileInfo.FilePath, keys)
+		err = f.store.Compose(ctx, Bucket, *fileInfo.FilePath, keys)
+		if err != nil {
+			errutil.ResponseError(ctx, errutil.UnknownError, err)
+			return
 		}
 
 		// 启动文件备份协程。
-		go func() {
+		go func(filePath string) {
 			// 文件备份
-			_, _ = bkt.CopyObject(*fileInfo.FilePath, fmt.Sprintf("buckup/%s", *fileInfo.FilePath))
-		}()
+			_ = f.store.Copy(context.Background(), Bucket, filePath, fmt.Sprintf("buckup/%s", filePath))
+		}(*fileInfo.FilePath)
 	}
 }
 
@@ -180,15 +161,10 @@ func (f *FileHandler) GetFileURL(ctx *gin.Context) {
 		return
 	}
 
-	bkt, err := f.oss.Bucket(Bucket)
-	if err != nil {
-		errutil.ResponseError(ctx, errutil.UnknownError, err)
-		return
-	}
-
 	// 生成文件的可访问 URL 并返回给客户端。
-	url, err := bkt.SignURL(*query.FilePath, http.MethodGet, 10000)
+	url, err := f.store.SignURL(ctx, *query.Bucket, *query.FilePath, time.Second*10000)
 	if err != nil {
+		errutil.ResponseError(ctx, errutil.UnknownError, err)
 		return
 	}
 	ctx.JSON(200, bson.M{
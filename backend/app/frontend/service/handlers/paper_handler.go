@@ -4,15 +4,34 @@ import (
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	merrors "go-micro.dev/v4/errors"
+	"io"
+	"net/http"
 	"os"
 	v1 "paper-translation/api/paper/service/v1"
 	"paper-translation/pkg/errutil"
 )
 
+// upgrader 跨域由 cors 中间件统一放开，这里不再校验 Origin
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
 type ReqCreatePaper struct {
-	FileHash       string `json:"fileHash"`
-	EmailTo        string `json:"emailTo"`
-	TargetLanguage string `json:"targetLanguage"`
+	FileHash        string   `json:"fileHash"`
+	EmailTo         string   `json:"emailTo"`
+	TargetLanguage  string   `json:"targetLanguage"`  // BCP-47 标签或常见语言名称，如 en、English、英语
+	SourceLanguage  string   `json:"sourceLanguage"`  // 原文语言，可选，为空时自动检测
+	TargetLanguages []string `json:"targetLanguages"` // 多个目标语言，可选，每种语言一篇子论文
+	GlossaryID      string   `json:"glossaryId"`      // 术语表ID，可选
+	Title           string   `json:"title"`           // 论文标题，可选
+	PromptName      string   `json:"promptName"`      // 提示词模板名称，可选
+	PromptVersion   string   `json:"promptVersion"`   // 提示词模板版本，可选
+}
+
+type ReqRerunStage struct {
+	Stage string `json:"stage"`
 }
 
 type PaperHandler struct {
@@ -31,12 +50,18 @@ func (t *PaperHandler) CreatePaper(ctx *gin.Context) {
 		return
 	}
 	resp, err := t.paperService.Create(ctx, &v1.CreatePaper{
-		PaperFileHash:  req.FileHash,
-		EmailTo:        req.EmailTo,
-		TargetLanguage: req.TargetLanguage,
+		PaperFileHash:   req.FileHash,
+		EmailTo:         req.EmailTo,
+		TargetLanguage:  req.TargetLanguage,
+		TargetLanguages: req.TargetLanguages,
+		SourceLanguage:  req.SourceLanguage,
+		GlossaryId:      req.GlossaryID,
+		Title:           req.Title,
+		PromptName:      req.PromptName,
+		PromptVersion:   req.PromptVersion,
 	})
 	if err != nil {
-		errutil.ResponseError(ctx, errutil.UnknownError, err)
+		errutil.ResponseError(ctx, paperError(err), err)
 		return
 	}
 	ctx.JSON(200, gin.H{
@@ -51,14 +76,55 @@ func (t *PaperHandler) GetPaper(ctx *gin.Context) {
 		return
 	}
 	ctx.JSON(200, gin.H{
-		"paperID":    paper.Id,
-		"status":     paper.Status,
-		"createAt":   paper.CreateAt,
-		"resultText": paper.ResultText,
-		"fileHash":   paper.FileHash,
+		"paperID":         paper.Id,
+		"status":          paper.Status,
+		"createAt":        paper.CreateAt,
+		"resultText":      paper.ResultText,
+		"fileHash":        paper.FileHash,
+		"targetLanguage":  paper.TargetLanguage,
+		"targetLanguages": paper.TargetLanguages,
+		"sourceLanguage":  paper.SourceLanguage,
+		"glossaryId":      paper.GlossaryId,
+		"title":           paper.Title,
+		"promptName":      paper.PromptName,
+		"promptVersion":   paper.PromptVersion,
+		"failure":         paperFailure(paper.Failure),
+		"failedPages":     paper.FailedPages,
+		"parentId":        paper.ParentId,
+		"children":        paperChildren(paper.Children),
 	})
 }
 
+// paperChildren 转换多目标语言论文每种语言的子论文，每篇子论文有各自的状态和翻译结果
+func paperChildren(children []*v1.Paper) []gin.H {
+	var resp = make([]gin.H, 0, len(children))
+	for _, child := range children {
+		resp = append(resp, gin.H{
+			"paperID":        child.Id,
+			"status":         child.Status,
+			"targetLanguage": child.TargetLanguage,
+			"resultText":     child.ResultText,
+			"glossaryId":     child.GlossaryId,
+			"failure":        paperFailure(child.Failure),
+		})
+	}
+	return resp
+}
+
+// paperFailure 转换失败记录，没有失败时返回 nil
+func paperFailure(failure *v1.Failure) gin.H {
+	if failure == nil {
+		return nil
+	}
+	return gin.H{
+		"stage":    failure.Stage,
+		"code":     failure.Code,
+		"message":  failure.Message,
+		"failedAt": failure.FailedAt,
+		"attempt":  failure.Attempt,
+	}
+}
+
 func (t *PaperHandler) GetPapers(ctx *gin.Context) {
 	fetchs, err := t.paperService.Fetchs(ctx, &v1.ReqFetchs{})
 	if err != nil {
@@ -85,6 +151,63 @@ func (t *PaperHandler) DeletePaper(ctx *gin.Context) {
 	}
 }
 
+// RetryPaper 从失败或取消时所在的阶段重新处理论文
+func (t *PaperHandler) RetryPaper(ctx *gin.Context) {
+	paper, err := t.paperService.Retry(ctx, &v1.PaperID{Id: ctx.Param("id")})
+	if err != nil {
+		errutil.ResponseError(ctx, paperError(err), err)
+		return
+	}
+	ctx.JSON(200, gin.H{
+		"paperID": paper.Id,
+		"status":  paper.Status,
+	})
+}
+
+// CancelPaper 取消正在处理的论文
+func (t *PaperHandler) CancelPaper(ctx *gin.Context) {
+	paper, err := t.paperService.Cancel(ctx, &v1.PaperID{Id: ctx.Param("id")})
+	if err != nil {
+		errutil.ResponseError(ctx, paperError(err), err)
+		return
+	}
+	ctx.JSON(200, gin.H{
+		"paperID": paper.Id,
+		"status":  paper.Status,
+	})
+}
+
+// RerunPaper 从指定阶段重新处理已经结束的论文
+func (t *PaperHandler) RerunPaper(ctx *gin.Context) {
+	var req ReqRerunStage
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		errutil.ResponseError(ctx, errutil.RequestParamError, err)
+		return
+	}
+	paper, err := t.paperService.RerunStage(ctx, &v1.ReqRerunStage{Id: ctx.Param("id"), Stage: req.Stage})
+	if err != nil {
+		errutil.ResponseError(ctx, paperError(err), err)
+		return
+	}
+	ctx.JSON(200, gin.H{
+		"paperID": paper.Id,
+		"status":  paper.Status,
+	})
+}
+
+// paperError 把论文服务返回的状态码转换为响应错误
+func paperError(err error) *errutil.Error {
+	switch merrors.FromError(err).Code {
+	case http.StatusBadRequest:
+		return errutil.RequestParamError
+	case http.StatusConflict:
+		return errutil.PaperStateError
+	default:
+		return errutil.UnknownError
+	}
+}
+
 func (t *PaperHandler) DownloadPaperResult(ctx *gin.Context) {
 	paper, err := t.paperService.Fetch(ctx, &v1.PaperID{Id: ctx.Param("id")})
 	if err != nil {
@@ -101,3 +224,94 @@ func (t *PaperHandler) DownloadPaperResult(ctx *gin.Context) {
 	}
 	ctx.FileAttachment(localFile, "paper.txt")
 }
+
+// PaperEvents 推送论文处理进度，默认使用 Server-Sent Events，请求带 WebSocket 升级头时改用 WebSocket。
+// 依次推送当前状态、阶段变化、OCR每页进度、翻译每段译文，处理结束时推送结果后关闭。
+func (t *PaperHandler) PaperEvents(ctx *gin.Context) {
+	stream, err := t.paperService.Watch(ctx, &v1.PaperID{Id: ctx.Param("id")})
+	if err != nil {
+		errutil.ResponseError(ctx, paperError(err), err)
+		return
+	}
+	defer stream.Close()
+
+	// 第一条消息是当前状态，先读出来以便论文不存在时还能返回普通的错误响应
+	first, err := stream.Recv()
+	if err != nil {
+		errutil.ResponseError(ctx, paperError(err), err)
+		return
+	}
+
+	if websocket.IsWebSocketUpgrade(ctx.Request) {
+		t.websocketEvents(ctx, stream, first)
+		return
+	}
+
+	// 客户端断开时关闭进度流，结束下面阻塞的 Recv
+	finished := make(chan struct{})
+	defer close(finished)
+	go func() {
+		select {
+		case <-ctx.Request.Context().Done():
+			_ = stream.Close()
+		case <-finished:
+		}
+	}()
+
+	var next = first
+	ctx.Stream(func(w io.Writer) bool {
+		if next == nil {
+			next, err = stream.Recv()
+			if err != nil {
+				return false
+			}
+		}
+		ctx.SSEvent(next.Type.String(), paperEvent(next))
+		next = nil
+		return true
+	})
+}
+
+// websocketEvents 通过 WebSocket 推送论文事件，每条消息是一个 JSON 对象
+func (t *PaperHandler) websocketEvents(ctx *gin.Context, stream v1.PaperService_WatchService, first *v1.PaperEvent) {
+	conn, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	// 客户端断开时关闭进度流，结束下面阻塞的 Recv
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				_ = stream.Close()
+				return
+			}
+		}
+	}()
+
+	for e := first; ; {
+		err = conn.WriteJSON(paperEvent(e))
+		if err != nil {
+			return
+		}
+		e, err = stream.Recv()
+		if err != nil {
+			_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+			return
+		}
+	}
+}
+
+func paperEvent(e *v1.PaperEvent) gin.H {
+	return gin.H{
+		"type":        e.Type.String(),
+		"status":      e.Status,
+		"index":       e.Index,
+		"done":        e.Done,
+		"total":       e.Total,
+		"text":        e.Text,
+		"error":       e.Error,
+		"failedPages": e.FailedPages,
+	}
+}
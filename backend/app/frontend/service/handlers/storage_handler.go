@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"paper-translation/pkg/errutil"
+	"paper-translation/pkg/storage"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StorageHandler 校验签名下载链接并转发对象内容，用于本地存储等没有公网签名能力的驱动。
+type StorageHandler struct {
+	store  storage.ObjectStore
+	signer *storage.URLSigner
+}
+
+// NewStorageHandler 创建一个新的 StorageHandler 实例。
+func NewStorageHandler(store storage.ObjectStore, signer *storage.URLSigner) *StorageHandler {
+	return &StorageHandler{store: store, signer: signer}
+}
+
+// Download 处理 GET /v1/storage/:bucket/*key?expires=..&signature=.. 请求。
+func (t *StorageHandler) Download(ctx *gin.Context) {
+	bucket := ctx.Param("bucket")
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+
+	err := t.signer.Verify(bucket, key, ctx.Query("expires"), ctx.Query("signature"))
+	if errors.Is(err, storage.ErrURLExpired) {
+		errutil.ResponseError(ctx, errutil.URLExpiredError)
+		return
+	}
+	if err != nil {
+		errutil.ResponseError(ctx, errutil.SignatureInvalidError, err)
+		return
+	}
+
+	reader, err := t.store.Get(ctx, bucket, key)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		errutil.ResponseError(ctx, errutil.FileNotExistError, err)
+		return
+	}
+	if err != nil {
+		errutil.ResponseError(ctx, errutil.UnknownError, err)
+		return
+	}
+	defer reader.Close()
+
+	// 本地文件支持 Range 请求，其他驱动直接流式转发。
+	if seeker, ok := reader.(io.ReadSeeker); ok {
+		http.ServeContent(ctx.Writer, ctx.Request, path.Base(key), time.Time{}, seeker)
+		return
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	ctx.DataFromReader(http.StatusOK, -1, contentType, reader, nil)
+}
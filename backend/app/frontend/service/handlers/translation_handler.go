@@ -0,0 +1,337 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	v1 "paper-translation/api/translation/service/v1"
+	"paper-translation/pkg/errutil"
+
+	"github.com/gin-gonic/gin"
+	merrors "go-micro.dev/v4/errors"
+)
+
+// tmxChunkSize 上传 TMX 文件时每个分片的大小
+const tmxChunkSize = 32 << 10
+
+// ReqExportTM 导出翻译记忆的查询参数，为空的字段不作为条件
+type ReqExportTM struct {
+	TargetLanguage string `form:"targetLanguage"` // 目标语言
+	Provider       string `form:"provider"`       // 大模型提供方
+	PromptVersion  string `form:"promptVersion"`  // 提示词版本
+	SourceLanguage string `form:"sourceLanguage"` // 原文的语言标签，写入 TMX 文件
+}
+
+// ReqGlossaryTerm 术语
+type ReqGlossaryTerm struct {
+	Source        string `json:"source"`        // 原文术语
+	Target        string `json:"target"`        // 规定的译法
+	CaseSensitive bool   `json:"caseSensitive"` // 原文匹配是否区分大小写
+}
+
+// ReqGlossary 创建或更新术语表的请求
+type ReqGlossary struct {
+	Name           string            `json:"name"`
+	SourceLanguage string            `json:"sourceLanguage"`
+	TargetLanguage string            `json:"targetLanguage"`
+	Owner          string            `json:"owner"` // 所属用户，为空时所有用户可用
+	Terms          []ReqGlossaryTerm `json:"terms"`
+}
+
+// ReqFetchGlossaries 查询术语表的参数，为空的字段不作为条件
+type ReqFetchGlossaries struct {
+	SourceLanguage string `form:"sourceLanguage"`
+	TargetLanguage string `form:"targetLanguage"`
+	Owner          string `form:"owner"`
+}
+
+// ReqPrompt 保存提示词模板的请求
+type ReqPrompt struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Text    string `json:"text"` // Go text/template 模板
+}
+
+// TranslationHandler 处理翻译记忆、术语表和提示词模板相关的请求
+type TranslationHandler struct {
+	translationService v1.TranslationService
+}
+
+func NewTranslationHandler(translationService v1.TranslationService) *TranslationHandler {
+	return &TranslationHandler{translationService: translationService}
+}
+
+// ExportTM 以 TMX 文件下载翻译记忆，边接收边写出
+func (t *TranslationHandler) ExportTM(ctx *gin.Context) {
+	var req ReqExportTM
+	err := ctx.ShouldBindQuery(&req)
+	if err != nil {
+		errutil.ResponseError(ctx, errutil.RequestParamError, err)
+		return
+	}
+	stream, err := t.translationService.ExportTM(ctx, &v1.TMExport{
+		Query: &v1.MemoryQuery{
+			TargetLanguage: req.TargetLanguage,
+			Provider:       req.Provider,
+			PromptVersion:  req.PromptVersion,
+		},
+		SourceLanguage: req.SourceLanguage,
+	})
+	if err != nil {
+		errutil.ResponseError(ctx, errutil.UnknownError, err)
+		return
+	}
+	defer stream.Close()
+
+	// 第一个分片到达后再写响应头，导出失败时还能返回普通的错误响应
+	chunk, err := stream.Recv()
+	if err != nil {
+		errutil.ResponseError(ctx, errutil.UnknownError, err)
+		return
+	}
+	ctx.Header("Content-Type", "application/x-tmx+xml")
+	ctx.Header("Content-Disposition", `attachment; filename="translation-memory.tmx"`)
+	ctx.Stream(func(w io.Writer) bool {
+		if chunk == nil {
+			chunk, err = stream.Recv()
+			if err != nil {
+				return false
+			}
+		}
+		_, err = w.Write(chunk.Data)
+		chunk = nil
+		return err == nil
+	})
+}
+
+// ImportTM 上传 TMX 文件导入翻译记忆，返回导入和跳过的翻译单元数
+func (t *TranslationHandler) ImportTM(ctx *gin.Context) {
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		errutil.ResponseError(ctx, errutil.RequestParamError, err)
+		return
+	}
+	file, err := header.Open()
+	if err != nil {
+		errutil.ResponseError(ctx, errutil.UnknownError, err)
+		return
+	}
+	defer file.Close()
+
+	stream, err := t.translationService.ImportTM(ctx)
+	if err != nil {
+		errutil.ResponseError(ctx, errutil.UnknownError, err)
+		return
+	}
+	defer stream.Close()
+
+	buf := make([]byte, tmxChunkSize)
+	sourceLanguage := ctx.PostForm("sourceLanguage")
+	for {
+		n, err := io.ReadFull(file, buf)
+		last := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+		if err != nil && !last {
+			errutil.ResponseError(ctx, errutil.UnknownError, err)
+			return
+		}
+		err = stream.Send(&v1.TMXChunk{Data: buf[:n], Last: last, SourceLanguage: sourceLanguage})
+		if err != nil {
+			errutil.ResponseError(ctx, errutil.UnknownError, err)
+			return
+		}
+		if last {
+			break
+		}
+		// 原文语言只需要在第一个分片中传递
+		sourceLanguage = ""
+	}
+
+	for {
+		progress, err := stream.Recv()
+		if err != nil {
+			errutil.ResponseError(ctx, errutil.UnknownError, err)
+			return
+		}
+		if progress.Finished {
+			ctx.JSON(200, gin.H{
+				"imported": progress.Imported,
+				"skipped":  progress.Skipped,
+			})
+			return
+		}
+	}
+}
+
+// CreateGlossary 创建术语表
+func (t *TranslationHandler) CreateGlossary(ctx *gin.Context) {
+	var req ReqGlossary
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		errutil.ResponseError(ctx, errutil.RequestParamError, err)
+		return
+	}
+	g, err := t.translationService.CreateGlossary(ctx, convertReqGlossary("", &req))
+	if err != nil {
+		errutil.ResponseError(ctx, glossaryError(err), err)
+		return
+	}
+	ctx.JSON(200, glossaryResponse(g))
+}
+
+// UpdateGlossary 更新术语表
+func (t *TranslationHandler) UpdateGlossary(ctx *gin.Context) {
+	var req ReqGlossary
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		errutil.ResponseError(ctx, errutil.RequestParamError, err)
+		return
+	}
+	g, err := t.translationService.UpdateGlossary(ctx, convertReqGlossary(ctx.Param("id"), &req))
+	if err != nil {
+		errutil.ResponseError(ctx, glossaryError(err), err)
+		return
+	}
+	ctx.JSON(200, glossaryResponse(g))
+}
+
+// DeleteGlossary 删除术语表
+func (t *TranslationHandler) DeleteGlossary(ctx *gin.Context) {
+	_, err := t.translationService.DeleteGlossary(ctx, &v1.GlossaryID{Id: ctx.Param("id")})
+	if err != nil {
+		errutil.ResponseError(ctx, glossaryError(err), err)
+		return
+	}
+}
+
+// GetGlossary 获取术语表和其中的术语
+func (t *TranslationHandler) GetGlossary(ctx *gin.Context) {
+	g, err := t.translationService.FetchGlossary(ctx, &v1.GlossaryID{Id: ctx.Param("id")})
+	if err != nil {
+		errutil.ResponseError(ctx, glossaryError(err), err)
+		return
+	}
+	ctx.JSON(200, glossaryResponse(g))
+}
+
+// GetGlossaries 查询术语表，不返回术语
+func (t *TranslationHandler) GetGlossaries(ctx *gin.Context) {
+	var req ReqFetchGlossaries
+	err := ctx.ShouldBindQuery(&req)
+	if err != nil {
+		errutil.ResponseError(ctx, errutil.RequestParamError, err)
+		return
+	}
+	glossaries, err := t.translationService.FetchGlossaries(ctx, &v1.GlossaryQuery{
+		SourceLanguage: req.SourceLanguage,
+		TargetLanguage: req.TargetLanguage,
+		Owner:          req.Owner,
+	})
+	if err != nil {
+		errutil.ResponseError(ctx, errutil.UnknownError, err)
+		return
+	}
+	var resp = make([]gin.H, 0)
+	for _, g := range glossaries.Glossaries {
+		resp = append(resp, glossaryResponse(g))
+	}
+	ctx.JSON(200, resp)
+}
+
+func convertReqGlossary(id string, req *ReqGlossary) *v1.Glossary {
+	g := &v1.Glossary{
+		Id:             id,
+		Name:           req.Name,
+		SourceLanguage: req.SourceLanguage,
+		TargetLanguage: req.TargetLanguage,
+		Owner:          req.Owner,
+	}
+	for _, term := range req.Terms {
+		g.Terms = append(g.Terms, &v1.GlossaryTerm{Source: term.Source, Target: term.Target, CaseSensitive: term.CaseSensitive})
+	}
+	return g
+}
+
+func glossaryResponse(g *v1.Glossary) gin.H {
+	var terms = make([]gin.H, 0)
+	for _, term := range g.Terms {
+		terms = append(terms, gin.H{
+			"source":        term.Source,
+			"target":        term.Target,
+			"caseSensitive": term.CaseSensitive,
+		})
+	}
+	return gin.H{
+		"glossaryId":     g.Id,
+		"name":           g.Name,
+		"sourceLanguage": g.SourceLanguage,
+		"targetLanguage": g.TargetLanguage,
+		"owner":          g.Owner,
+		"terms":          terms,
+		"createAt":       g.CreateAt,
+		"updateAt":       g.UpdateAt,
+	}
+}
+
+// glossaryError 把翻译服务返回的状态码转换为响应错误
+func glossaryError(err error) *errutil.Error {
+	switch merrors.FromError(err).Code {
+	case http.StatusBadRequest:
+		return errutil.RequestParamError
+	case http.StatusNotFound:
+		return errutil.GlossaryNotExistError
+	default:
+		return errutil.UnknownError
+	}
+}
+
+// SavePrompt 保存新版本的提示词模板
+func (t *TranslationHandler) SavePrompt(ctx *gin.Context) {
+	var req ReqPrompt
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		errutil.ResponseError(ctx, errutil.RequestParamError, err)
+		return
+	}
+	tmpl, err := t.translationService.SavePrompt(ctx, &v1.PromptTemplate{Name: req.Name, Version: req.Version, Text: req.Text})
+	if err != nil {
+		errutil.ResponseError(ctx, promptError(err), err)
+		return
+	}
+	ctx.JSON(200, promptResponse(tmpl))
+}
+
+// GetPrompts 查询提示词模板，可以按名称筛选
+func (t *TranslationHandler) GetPrompts(ctx *gin.Context) {
+	templates, err := t.translationService.FetchPrompts(ctx, &v1.PromptQuery{Name: ctx.Query("name")})
+	if err != nil {
+		errutil.ResponseError(ctx, errutil.UnknownError, err)
+		return
+	}
+	var resp = make([]gin.H, 0)
+	for _, tmpl := range templates.Templates {
+		resp = append(resp, promptResponse(tmpl))
+	}
+	ctx.JSON(200, resp)
+}
+
+func promptResponse(tmpl *v1.PromptTemplate) gin.H {
+	return gin.H{
+		"name":     tmpl.Name,
+		"version":  tmpl.Version,
+		"text":     tmpl.Text,
+		"builtin":  tmpl.Builtin,
+		"createAt": tmpl.CreateAt,
+	}
+}
+
+// promptError 把翻译服务返回的状态码转换为响应错误
+func promptError(err error) *errutil.Error {
+	switch merrors.FromError(err).Code {
+	case http.StatusBadRequest:
+		return errutil.RequestParamError
+	case http.StatusConflict:
+		return errutil.PromptVersionExistError
+	default:
+		return errutil.UnknownError
+	}
+}
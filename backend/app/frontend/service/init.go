@@ -3,10 +3,11 @@ package main
 import (
 	fs "paper-translation/api/file/service/v1"
 	v1 "paper-translation/api/paper/service/v1"
+	ts "paper-translation/api/translation/service/v1"
 	"paper-translation/app/frontend/service/handlers"
 	"paper-translation/pkg/service"
+	"paper-translation/pkg/storage"
 
-	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"go-micro.dev/v4/client"
@@ -62,24 +63,43 @@ func NewPaperService(registry registry.Registry) v1.PaperService {
 	return v1.NewPaperService(service.PaperServiceName, cli) // 创建并返回论文服务实例
 }
 
+// NewTranslationService 创建并返回一个新的翻译服务实例。
+//
+// 参数:
+// - registry registry.Registry: 微服务注册表。
+//
+// 返回值:
+// - ts.TranslationService: 创建的翻译服务实例。
+func NewTranslationService(registry registry.Registry) ts.TranslationService {
+	cli := client.NewClient(
+		client.Registry(registry), // 使用微服务注册表创建客户端
+	)
+	return ts.NewTranslationService(service.TranslationServiceName, cli) // 创建并返回翻译服务实例
+}
+
 // NewRoute 创建并返回一个新的 Gin 引擎路由。
 //
 // 参数:
 // - fileService fs.FileService: 文件服务实例。
 // - paperService v1.PaperService: 论文服务实例。
-// - oss *oss.Client: 阿里云 OSS 客户端。
+// - translationService ts.TranslationService: 翻译服务实例。
+// - store storage.ObjectStore: 对象存储。
+// - signer *storage.URLSigner: 下载链接签名器。
 //
 // 返回值:
 // - *gin.Engine: 创建的 Gin 引擎路由。
-func NewRoute(fileService fs.FileService, paperService v1.PaperService, oss *oss.Client) *gin.Engine {
-	r := gin.Default()                                       // 创建默认的 Gin 引擎
-	r.Use(cors.Default())                                    // 使用默认的 CORS 中间件
-	fileHandler := handlers.NewFileHandler(fileService, oss) // 创建文件处理器
-	files := r.Group("/v1/files")                            // 创建文件路由组
-	files.POST("/start", fileHandler.StartUploadFile)        // 处理文件上传请求
-	files.POST("/chunk", fileHandler.UploadChunk)            // 处理文件块上传请求
-	files.GET("/:hash", fileHandler.QueryFile)               // 处理文件查询请求
-	files.GET("/:hash/public_url", fileHandler.GetFileURL)   // 处理获取文件公共链接请求
+func NewRoute(fileService fs.FileService, paperService v1.PaperService, translationService ts.TranslationService, store storage.ObjectStore, signer *storage.URLSigner) *gin.Engine {
+	r := gin.Default()                                         // 创建默认的 Gin 引擎
+	r.Use(cors.Default())                                      // 使用默认的 CORS 中间件
+	fileHandler := handlers.NewFileHandler(fileService, store) // 创建文件处理器
+	files := r.Group("/v1/files")                              // 创建文件路由组
+	files.POST("/start", fileHandler.StartUploadFile)          // 处理文件上传请求
+	files.POST("/chunk", fileHandler.UploadChunk)              // 处理文件块上传请求
+	files.GET("/:hash", fileHandler.QueryFile)                 // 处理文件查询请求
+	files.GET("/:hash/public_url", fileHandler.GetFileURL)     // 处理获取文件公共链接请求
+
+	storageHandler := handlers.NewStorageHandler(store, signer)          // 创建存储下载处理器
+	r.GET(storage.DownloadPath+"/:bucket/*key", storageHandler.Download) // 处理签名链接下载请求
 
 	paperHandler := handlers.NewPaperHandler(paperService)            // 创建论文处理器
 	papers := r.Group("/v1/papers")                                   // 创建论文路由组
@@ -88,5 +108,25 @@ func NewRoute(fileService fs.FileService, paperService v1.PaperService, oss *oss
 	papers.GET("/:id", paperHandler.GetPaper)                         // 处理获取单个论文请求
 	papers.DELETE("/:id", paperHandler.DeletePaper)                   // 处理删除论文请求
 	papers.GET("/:id/download_txt", paperHandler.DownloadPaperResult) // 处理下载论文文本结果请求
-	return r                                                          // 返回创建的 Gin 引擎路由
+	papers.GET("/:id/events", paperHandler.PaperEvents)               // 处理订阅论文处理进度请求
+	papers.POST("/:id/retry", paperHandler.RetryPaper)                // 处理重试论文请求
+	papers.POST("/:id/cancel", paperHandler.CancelPaper)              // 处理取消论文请求
+	papers.POST("/:id/rerun", paperHandler.RerunPaper)                // 处理从指定阶段重新处理论文请求
+
+	translationHandler := handlers.NewTranslationHandler(translationService) // 创建翻译处理器
+	memory := r.Group("/v1/translation/memory")                              // 创建翻译记忆路由组
+	memory.GET("/tmx", translationHandler.ExportTM)                          // 处理导出 TMX 文件请求
+	memory.POST("/tmx", translationHandler.ImportTM)                         // 处理导入 TMX 文件请求
+
+	glossaries := r.Group("/v1/glossaries")                      // 创建术语表路由组
+	glossaries.POST("/", translationHandler.CreateGlossary)      // 处理创建术语表请求
+	glossaries.GET("/", translationHandler.GetGlossaries)        // 处理查询术语表请求
+	glossaries.GET("/:id", translationHandler.GetGlossary)       // 处理获取术语表请求
+	glossaries.PUT("/:id", translationHandler.UpdateGlossary)    // 处理更新术语表请求
+	glossaries.DELETE("/:id", translationHandler.DeleteGlossary) // 处理删除术语表请求
+
+	prompts := r.Group("/v1/prompts")                // 创建提示词模板路由组
+	prompts.POST("/", translationHandler.SavePrompt) // 处理保存提示词模板请求
+	prompts.GET("/", translationHandler.GetPrompts)  // 处理查询提示词模板请求
+	return r                                         // 返回创建的 Gin 引擎路由
 }
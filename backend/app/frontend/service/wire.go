@@ -6,16 +6,18 @@ package main
 import (
 	"github.com/google/wire"
 	"go-micro.dev/v4/web"
-	"paper-translation/pkg/oss"
 	"paper-translation/pkg/service"
+	"paper-translation/pkg/storage"
 )
 
 func InitApp() web.Service {
 	panic(wire.Build(
 		service.ProviderSet,
-		oss.NewAliYunOSS,
+		storage.NewObjectStore,
+		storage.NewURLSigner,
 		NewFileService,
 		NewPaperService,
+		NewTranslationService,
 		NewRoute,
 		NewService,
 	))
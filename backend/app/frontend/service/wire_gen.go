@@ -8,8 +8,8 @@ package main
 
 import (
 	"go-micro.dev/v4/web"
-	"paper-translation/pkg/oss"
 	"paper-translation/pkg/service"
+	"paper-translation/pkg/storage"
 )
 
 // Injectors from wire.go:
@@ -19,8 +19,10 @@ func InitApp() web.Service {
 	config := service.NewConfig()
 	fileService := NewFileService(registry)
 	paperService := NewPaperService(registry)
-	client := oss.NewAliYunOSS(config)
-	engine := NewRoute(fileService, paperService, client)
+	translationService := NewTranslationService(registry)
+	objectStore := storage.NewObjectStore(config)
+	urlSigner := storage.NewURLSigner(config)
+	engine := NewRoute(fileService, paperService, translationService, objectStore, urlSigner)
 	webService := NewService(registry, config, engine)
 	return webService
 }
@@ -2,8 +2,10 @@ package main
 
 import (
 	v1 "paper-translation/api/ocr/service/v1"
+	"paper-translation/app/ocr/service/ocr"
 	"paper-translation/pkg/errutil"
 	"paper-translation/pkg/service"
+	"time"
 
 	"go-micro.dev/v4"
 	"go-micro.dev/v4/config"
@@ -30,3 +32,14 @@ func NewService(registry registry.Registry, config config.Config, handler v1.OCR
 	svc.Init()                                                 // 初始化服务
 	return svc                                                 // 返回创建的 OCR 微服务实例
 }
+
+// NewOCROptions 从配置中读取OCR任务的执行参数
+func NewOCROptions(config config.Config) ocr.Options {
+	return ocr.Options{
+		Parallelism:    config.Get("ocr", "parallelism").Int(4),
+		Concurrency:    config.Get("ocr", "concurrency").Int(8),
+		MaxAttempts:    config.Get("ocr", "max_attempts").Int(3),
+		RetryInterval:  config.Get("ocr", "retry_interval").Duration(time.Second * 2),
+		AcquireTimeout: config.Get("ocr", "acquire_timeout").Duration(time.Second * 60),
+	}
+}
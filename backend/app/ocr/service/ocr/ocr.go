@@ -1,9 +1,42 @@
 package ocr
 
+import "time"
+
+// 页面文本的来源
+const (
+	SourceTextLayer = "text_layer" // PDF 自带的文本层
+	SourceOCR       = "ocr"        // 转换为图像后OCR识别
+)
+
 type OCR struct {
-	ID        string `bson:"ID"`
-	Bucket    string `bson:"Bucket"`
-	ObjectKey string `bson:"ObjectKey"`
-	FileType  string `bson:"FileType"`
-	OcredText string `bson:"OcredText"`
+	ID        string       `bson:"ID"`
+	Bucket    string       `bson:"Bucket"`
+	ObjectKey string       `bson:"ObjectKey"`
+	FileType  string       `bson:"FileType"`
+	FileHash  string       `bson:"FileHash,omitempty"` // 文件内容哈希，有值时按哈希缓存识别结果
+	Language  string       `bson:"Language,omitempty"` // 识别时使用的文档语言，重新识别失败的页面时沿用
+	OcredText string       `bson:"OcredText"`
+	Pages     []PageSource `bson:"Pages,omitempty"` // 每一页文本的来源
+	// 识别失败的页码，这些页面在 OcredText 中没有文本，详情见 ocr_pages 集合
+	FailedPages []int32   `bson:"FailedPages,omitempty"`
+	CreateAt    time.Time `bson:"CreateAt"` // 首次保存的时间，同一文件有多个结果时使用最新的
+}
+
+// PageSource 一页文本的来源
+type PageSource struct {
+	Page   int32  `bson:"Page"` // 页码，从1开始
+	Source string `bson:"Source"`
+}
+
+// OCRPage 一页的识别结果，每识别完一页保存一次，最终文本按页码顺序拼接
+type OCRPage struct {
+	OCRID      string  `bson:"OCRID"`           // 所属的识别结果，即 OCR 任务ID
+	Page       int32   `bson:"Page"`            // 页码，从1开始
+	Text       string  `bson:"Text"`            // 这一页的文本
+	Source     string  `bson:"Source"`          // 文本来源
+	Engine     string  `bson:"Engine"`          // 识别引擎，使用文本层时为空
+	Confidence float64 `bson:"Confidence"`      // 平均置信度，0 到 1，文本层为 1
+	Duration   int64   `bson:"Duration"`        // 识别耗时，单位毫秒
+	Attempts   int32   `bson:"Attempts"`        // 识别的尝试次数，文本层为 0
+	Error      string  `bson:"Error,omitempty"` // 渲染或识别失败的原因
 }
@@ -0,0 +1,56 @@
+package ocr
+
+import (
+	"context"
+	"log"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type OCRPageRepository interface {
+	Save(page *OCRPage) error
+	List(ocrID string) ([]*OCRPage, error)
+	Delete(ocrID string) error
+}
+
+type MongoOCRPageRepository struct {
+	C *mongo.Collection
+}
+
+func NewMongoOCRPageRepository(db *mongo.Database) *MongoOCRPageRepository {
+	c := db.Collection("ocr_pages")
+	_, err := c.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "OCRID", Value: 1}, {Key: "Page", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Printf("create ocr_pages indexes err: %+v", err)
+	}
+	return &MongoOCRPageRepository{C: c}
+}
+
+// Save 保存一页的识别结果，同一页重新识别时覆盖
+func (t *MongoOCRPageRepository) Save(page *OCRPage) error {
+	_, err := t.C.ReplaceOne(context.TODO(), bson.M{
+		"OCRID": page.OCRID,
+		"Page":  page.Page,
+	}, page, options.Replace().SetUpsert(true))
+	return err
+}
+
+// List 按页码顺序返回识别结果的所有页面
+func (t *MongoOCRPageRepository) List(ocrID string) ([]*OCRPage, error) {
+	cursor, err := t.C.Find(context.TODO(), bson.M{"OCRID": ocrID}, options.Find().SetSort(bson.D{{Key: "Page", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	var pages []*OCRPage
+	return pages, cursor.All(context.TODO(), &pages)
+}
+
+func (t *MongoOCRPageRepository) Delete(ocrID string) error {
+	_, err := t.C.DeleteMany(context.TODO(), bson.M{"OCRID": ocrID})
+	return err
+}
@@ -2,13 +2,21 @@ package ocr
 
 import (
 	"context"
+	"errors"
+	"log"
+
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// indexNotFound 删除不存在的索引时 MongoDB 返回的错误码
+const indexNotFound = 27
+
 type OCRRepository interface {
-	Create(ocr *OCR) error
-	Get(bucket string, objectKey string, fileType string) (*OCR, error)
+	Save(ocr *OCR) error
+	Get(fileHash string, bucket string, objectKey string, fileType string) (*OCR, error)
+	GetByID(id string) (*OCR, error)
 }
 
 type MongoOCRRepository struct {
@@ -16,18 +24,51 @@ type MongoOCRRepository struct {
 }
 
 func NewMongoOCRRepository(db *mongo.Database) *MongoOCRRepository {
-	return &MongoOCRRepository{C: db.Collection("ocrs")}
-}
-
-func (t *MongoOCRRepository) Create(ocr *OCR) error {
-	_, err := t.C.InsertOne(context.TODO(), ocr)
-	return err
+	c := db.Collection("ocrs")
+	// 同一文件可以有多个识别结果，删除旧版本建立的 FileHash 唯一索引
+	_, err := c.Indexes().DropOne(context.TODO(), "FileHash_1")
+	var cmdErr mongo.CommandError
+	if err != nil && !(errors.As(err, &cmdErr) && cmdErr.Code == indexNotFound) {
+		log.Printf("drop ocrs index FileHash_1 err: %+v", err)
+	}
+	// 之前的识别结果没有 FileHash，只对有哈希的文档建立索引
+	_, err = c.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "FileHash", Value: 1}, {Key: "CreateAt", Value: -1}}, Options: options.Index().
+			SetPartialFilterExpression(bson.M{"FileHash": bson.M{"$exists": true}})},
+		{Keys: bson.D{{Key: "Bucket", Value: 1}, {Key: "ObjectKey", Value: 1}, {Key: "FileType", Value: 1}, {Key: "CreateAt", Value: -1}}},
+		{Keys: bson.D{{Key: "ID", Value: 1}}, Options: options.Index().SetUnique(true)},
+	})
+	if err != nil {
+		log.Printf("create ocrs indexes err: %+v", err)
+	}
+	return &MongoOCRRepository{C: c}
 }
 
-func (t *MongoOCRRepository) Get(bucket string, objectKey string, fileType string) (o *OCR, err error) {
-	return o, t.C.FindOne(context.TODO(), bson.M{
+// cacheKey 识别结果的缓存键，有文件内容哈希时按哈希，同一文件重复上传也能命中；否则按文件在存储中的位置
+func cacheKey(fileHash string, bucket string, objectKey string, fileType string) bson.M {
+	if fileHash != "" {
+		return bson.M{"FileHash": fileHash}
+	}
+	return bson.M{
 		"Bucket":    bucket,
 		"ObjectKey": objectKey,
 		"FileType":  fileType,
-	}).Decode(&o)
+	}
+}
+
+// Save 按 ID 保存识别结果，重新识别失败的页面时更新同一结果；
+// 重新识别文件会生成新的结果，之前的结果仍然保留，引用它的任务可以继续读取
+func (t *MongoOCRRepository) Save(ocr *OCR) error {
+	_, err := t.C.ReplaceOne(context.TODO(), bson.M{"ID": ocr.ID}, ocr, options.Replace().SetUpsert(true))
+	return err
+}
+
+// Get 查找文件最新的识别结果，fileHash 为空时按 bucket、objectKey、fileType 查找
+func (t *MongoOCRRepository) Get(fileHash string, bucket string, objectKey string, fileType string) (o *OCR, err error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "CreateAt", Value: -1}})
+	return o, t.C.FindOne(context.TODO(), cacheKey(fileHash, bucket, objectKey, fileType), opts).Decode(&o)
+}
+
+func (t *MongoOCRRepository) GetByID(id string) (o *OCR, err error) {
+	return o, t.C.FindOne(context.TODO(), bson.M{"ID": id}).Decode(&o)
 }
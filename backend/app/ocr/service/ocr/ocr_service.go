@@ -1,32 +1,83 @@
 package ocr
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log"
-	"net/http"
 	"os"
 	v1 "paper-translation/api/ocr/service/v1"
+	"paper-translation/pkg/errutil"
+	"paper-translation/pkg/event"
+	ocrengine "paper-translation/pkg/ocr"
 	"paper-translation/pkg/pdf"
+	"paper-translation/pkg/service"
+	"paper-translation/pkg/signal"
+	"paper-translation/pkg/storage"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 
-	ocr "github.com/alibabacloud-go/ocr-api-20210707/client"
-	"github.com/aliyun/aliyun-oss-go-sdk/oss"
 	"github.com/google/uuid"
+	"go-micro.dev/v4/broker"
+	merrors "go-micro.dev/v4/errors"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
+// watchResendInterval WatchStatus 没有新事件时重新推送当前状态的间隔
+const watchResendInterval = time.Second * 30
+
+// Options OCR任务的执行参数
+type Options struct {
+	Parallelism    int           // 每个任务同时识别的页数，同时受引擎信号量限制
+	Concurrency    int           // 同一引擎在所有OCR服务实例间共享的最大并发数
+	MaxAttempts    int           // 每页的最大尝试次数
+	RetryInterval  time.Duration // 第一次重试前的等待时间，之后每次翻倍
+	AcquireTimeout time.Duration // 等待引擎信号量的超时时间，超时计为一次失败
+}
+
 // OCRStatus 存储OCR任务的状态
 type OCRStatus struct {
-	Text     string
-	Finished bool
+	Text       string
+	Finished   bool
+	PagesDone  int32        // 已识别的页数
+	PagesTotal int32        // 总页数
+	Error      string       // 失败原因
+	Pages      []PageSource // 每一页文本的来源，完成时有值
+	// 识别失败的页码，完成时有值
+	FailedPages []int32
+	// 使用缓存时为缓存结果的任务ID，每一页的结果按这个ID保存，为空时就是任务本身的ID
+	OCRID string
+}
+
+// Progress 转换为进度消息，结果文本只在完成时携带
+func (t OCRStatus) Progress() *v1.OCRProgress {
+	progress := &v1.OCRProgress{
+		Finished:   t.Finished,
+		PagesDone:  t.PagesDone,
+		PagesTotal: t.PagesTotal,
+		Error:      t.Error,
+	}
+	if t.Finished {
+		progress.Text = t.Text
+		progress.Pages = convertPages(t.Pages)
+		progress.FailedPages = t.FailedPages
+		progress.OcrId = t.OCRID
+	}
+	return progress
+}
+
+// convertPages 转换为页面来源消息
+func convertPages(pages []PageSource) []*v1.PageSource {
+	result := make([]*v1.PageSource, 0, len(pages))
+	for _, page := range pages {
+		result = append(result, &v1.PageSource{Page: page.Page, Source: page.Source})
+	}
+	return result
 }
 
 // UnmarshalBinary 从二进制数据中反序列化OCRStatus
@@ -41,30 +92,45 @@ func (t OCRStatus) MarshalBinary() (data []byte, err error) {
 
 // OCRService 包含OCR服务的实现
 type OCRService struct {
-	ocrRepo     OCRRepository // OCR任务的存储库
-	ocr         *ocr.Client   // 阿里云OCR客户端
-	oss         *oss.Client   // 阿里云OSS客户端
-	redisClient *redis.Client // Redis客户端，用于存储OCR任务状态
+	ocrRepo       OCRRepository        // OCR任务的存储库
+	pageRepo      OCRPageRepository    // 每一页识别结果的存储库
+	engine        ocrengine.OCREngine  // OCR识别引擎
+	renderer      pdf.Renderer         // 把需要识别的页面渲染为图像
+	signalFactory signal.SignalFactory // 创建各实例共享的引擎信号量
+	store         storage.ObjectStore  // 对象存储
+	redisClient   *redis.Client        // Redis客户端，用于存储OCR任务状态
+	broker        broker.Broker        // 发布任务进度和结束事件
+	hub           *event.Hub           // 把任务事件分发给 WatchStatus
+	canceller     *event.Canceller     // 取消本实例正在执行的任务
+	options       Options
 }
 
 // NewOCRService 创建一个新的OCRService实例
-func NewOCRService(ocrRepo OCRRepository, ocr *ocr.Client, oss *oss.Client, redisClient *redis.Client) *OCRService {
-	return &OCRService{ocrRepo: ocrRepo, ocr: ocr, oss: oss, redisClient: redisClient}
+func NewOCRService(ocrRepo OCRRepository, pageRepo OCRPageRepository, engine ocrengine.OCREngine, renderer pdf.Renderer, signalFactory signal.SignalFactory, store storage.ObjectStore, redisClient *redis.Client, broker broker.Broker, options Options) *OCRService {
+	// 任务可能在其他实例上执行，进度通过 broker 汇总到订阅的实例
+	hub, err := event.NewHub(broker, event.TopicOCRProgress, event.TopicOCRCompleted, event.TopicOCRFailed)
+	errutil.PanicIfErr(err)
+	canceller, err := event.NewCanceller(broker, event.TopicOCRCancel)
+	errutil.PanicIfErr(err)
+	return &OCRService{ocrRepo: ocrRepo, pageRepo: pageRepo, engine: engine, renderer: renderer, signalFactory: signalFactory, store: store, redisClient: redisClient, broker: broker, hub: hub, canceller: canceller, options: options}
 }
 
 // OCR 启动OCR任务，处理文档的OCR识别
 func (t *OCRService) OCR(ctx context.Context, param *v1.OCRParam, resp *v1.OCRTaskID) error {
-	// 检查是否已经存在OCR结果
-	ocx, err := t.ocrRepo.Get(param.Bucket, param.ObjectKey, param.FileType)
-	if err == nil {
-		resp.TaskId = uuid.NewString()
-		t.redisClient.Set(ctx, resp.TaskId, OCRStatus{Text: ocx.OcredText, Finished: true}, time.Hour)
-		return nil
-	}
+	// 检查是否已经存在OCR结果，要求重新识别时跳过
+	if !param.SkipCache {
+		ocx, err := t.ocrRepo.Get(param.FileHash, param.Bucket, param.ObjectKey, param.FileType)
+		if err == nil {
+			resp.TaskId = uuid.NewString()
+			t.redisClient.Set(ctx, resp.TaskId, OCRStatus{Text: ocx.OcredText, Finished: true, Pages: ocx.Pages, FailedPages: ocx.FailedPages, OCRID: ocx.ID}, time.Hour)
+			t.publish(resp.TaskId, nil)
+			return nil
+		}
 
-	// 如果存在其他错误（不是没有找到文档），则返回错误
-	if !errors.Is(err, mongo.ErrNoDocuments) {
-		return err
+		// 如果存在其他错误（不是没有找到文档），则返回错误
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return err
+		}
 	}
 
 	// 创建新的OCR任务
@@ -72,13 +138,85 @@ func (t *OCRService) OCR(ctx context.Context, param *v1.OCRParam, resp *v1.OCRTa
 	// 每次进行一个新的 OCR  大任务， 都要往 redis 里面存一下，记录一下这个开始的任务，
 	//存到 Redis 里 key 是 taskID， value 是一个对象，字段  text 是将文件序列化后变成字符串存进去，status 就是这个 taskID 的执行状态
 	t.redisClient.Set(ctx, resp.TaskId, OCRStatus{Text: "", Finished: false}, time.Hour)
+	ocx := &OCR{
+		ID:        resp.TaskId,
+		Bucket:    param.Bucket,
+		ObjectKey: param.ObjectKey,
+		FileType:  param.FileType,
+		FileHash:  param.FileHash,
+		Language:  param.Language,
+	}
+	t.start(ocx.ID, ocx.ID, func(ctx context.Context) error {
+		return t.StartPipeline(ctx, ocx)
+	})
+	return nil
+}
+
+// RetryPages 重新识别OCR结果中失败的页面，成功的页面覆盖原来的结果后重新拼接文本
+func (t *OCRService) RetryPages(ctx context.Context, req *v1.OCRTaskID, resp *v1.OCRTaskID) error {
+	ocrID, err := t.ocrID(ctx, req.TaskId)
+	if err != nil {
+		return err
+	}
+	ocx, err := t.ocrRepo.GetByID(ocrID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return merrors.NotFound(service.OCRServiceName, "ocr result of task %s not found", req.TaskId)
+	}
+	if err != nil {
+		return err
+	}
+	if len(ocx.FailedPages) == 0 {
+		return merrors.BadRequest(service.OCRServiceName, "ocr task %s has no failed pages", req.TaskId)
+	}
+
+	resp.TaskId = uuid.NewString()
+	t.redisClient.Set(ctx, resp.TaskId, OCRStatus{OCRID: ocx.ID}, time.Hour)
+	taskID := resp.TaskId
+	t.start(taskID, ocx.ID, func(ctx context.Context) error {
+		return t.RetryPipeline(ctx, taskID, ocx)
+	})
+	return nil
+}
+
+// start 在后台执行任务，ocrID 为任务结果所属的识别结果
+func (t *OCRService) start(taskID, ocrID string, pipeline func(ctx context.Context) error) {
+	// 任务在请求返回后继续执行，不能使用请求的 context，通过 Cancel 取消
+	ctx, done := t.canceller.Start(taskID)
 	go func() {
-		err = t.StartPipeline(context.TODO(), resp.TaskId, param.Bucket, param.ObjectKey)
+		defer done()
+		err := pipeline(ctx)
 		if err != nil {
 			log.Printf("exec ocr pipeline failed err: %+v", err)
+			// 标记为已结束但没有结果，和识别结果为空的情况保持一致
+			t.redisClient.Set(context.TODO(), taskID, OCRStatus{Text: "", Finished: true, Error: err.Error(), OCRID: ocrID}, time.Hour)
 		}
+		t.publish(taskID, err)
 	}()
-	return nil
+}
+
+// Cancel 取消OCR任务，已经识别的页不会保存为缓存结果
+func (t *OCRService) Cancel(ctx context.Context, req *v1.OCRTaskID, resp *v1.OCRCancel) error {
+	return t.canceller.Cancel(req.TaskId)
+}
+
+// publish 发布OCR任务结束事件
+func (t *OCRService) publish(taskID string, cause error) {
+	topic, e := event.TopicOCRCompleted, &event.TaskEvent{TaskID: taskID}
+	if cause != nil {
+		topic, e.Error = event.TopicOCRFailed, cause.Error()
+	}
+	err := event.Publish(t.broker, topic, e)
+	if err != nil {
+		log.Printf("publish %s event err: %+v", topic, err)
+	}
+}
+
+// publishProgress 发布一页识别完成的进度事件
+func (t *OCRService) publishProgress(taskID string, page, done, total int32) {
+	err := event.Publish(t.broker, event.TopicOCRProgress, &event.TaskEvent{TaskID: taskID, Index: page, Done: done, Total: total})
+	if err != nil {
+		log.Printf("publish %s event err: %+v", event.TopicOCRProgress, err)
+	}
 }
 
 // GetStatus 获取OCR任务的状态
@@ -91,136 +229,420 @@ func (t *OCRService) GetStatus(ctx context.Context, req *v1.OCRTaskID, resp *v1.
 	}
 	resp.Text = status.Text
 	resp.Finished = status.Finished
+	resp.PagesDone = status.PagesDone
+	resp.PagesTotal = status.PagesTotal
+	resp.Pages = convertPages(status.Pages)
+	resp.FailedPages = status.FailedPages
+	if status.Finished {
+		resp.OcrId = status.OCRID
+	}
 	return nil
 }
 
-// OCRLocalImage 对本地图像执行OCR识别
-func (t *OCRService) OCRLocalImage(bucket, filePath string) (string, error) {
-	// 获取OSS存储桶
-	bkt, err := t.oss.Bucket(bucket)
+// GetResult 获取保存的识别结果。参数可以是识别结果ID，也可以是任务ID，任务状态过期后使用缓存的任务只能按识别结果ID查询
+func (t *OCRService) GetResult(ctx context.Context, req *v1.OCRTaskID, resp *v1.OCRText) error {
+	ocrID, err := t.ocrID(ctx, req.TaskId)
 	if err != nil {
-		return "", err
+		return err
+	}
+	ocx, err := t.ocrRepo.GetByID(ocrID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return merrors.NotFound(service.OCRServiceName, "ocr result %s not found", req.TaskId)
 	}
-
-	// 打开本地图像文件
-	f, err := os.Open(filePath)
 	if err != nil {
-		return "", err
+		return err
 	}
+	total := int32(len(ocx.Pages))
+	resp.Finished = true
+	resp.Text = ocx.OcredText
+	resp.PagesDone = total
+	resp.PagesTotal = total
+	resp.Pages = convertPages(ocx.Pages)
+	resp.FailedPages = ocx.FailedPages
+	resp.OcrId = ocx.ID
+	return nil
+}
 
-	// 生成随机的对象键，将图像上传到OSS，因为 OCR 接口只能传 url 进去
-	objectKey := fmt.Sprintf("images/%s.jpg", uuid.NewString())
-	err = bkt.PutObject(objectKey, f)
-	if err != nil {
+// ocrID 返回任务结果所属的识别结果ID，使用缓存或重新识别失败页面的任务属于之前的识别结果。
+// 任务状态过期后按任务ID查询
+func (t *OCRService) ocrID(ctx context.Context, taskID string) (string, error) {
+	var status OCRStatus
+	err := t.redisClient.Get(ctx, taskID).Scan(&status)
+	if err != nil && !errors.Is(err, redis.Nil) {
 		return "", err
 	}
+	if status.OCRID != "" {
+		return status.OCRID, nil
+	}
+	return taskID, nil
+}
 
-	// 生成带签名的URL以下载图像
-	fileURL, err := bkt.SignURL(objectKey, http.MethodGet, 120)
+// GetPages 获取OCR任务每一页的识别结果。任务状态过期后仍然可以按任务ID查询已保存的页面
+func (t *OCRService) GetPages(ctx context.Context, req *v1.OCRTaskID, resp *v1.OCRPages) error {
+	ocrID, err := t.ocrID(ctx, req.TaskId)
 	if err != nil {
-		return "", err
+		return err
 	}
-
-	log.Printf("start ocr for image %s", fileURL)
-
-	// 创建OCR请求并执行OCR
-	req := &ocr.RecognizeEnglishRequest{Url: &fileURL}
-	resp, err := t.ocr.RecognizeEnglish(req)
+	pages, err := t.pageRepo.List(ocrID)
 	if err != nil {
-		return "", err
+		return err
 	}
+	if len(pages) == 0 {
+		return merrors.NotFound(service.OCRServiceName, "pages of ocr task %s not found", req.TaskId)
+	}
+	for _, page := range pages {
+		resp.Pages = append(resp.Pages, &v1.OCRPage{
+			Page:       page.Page,
+			Text:       page.Text,
+			Source:     page.Source,
+			Engine:     page.Engine,
+			Confidence: page.Confidence,
+			DurationMs: page.Duration,
+			Error:      page.Error,
+			Attempts:   page.Attempts,
+		})
+	}
+	return nil
+}
 
-	// 解析OCR响应数据
-	var data map[string]any
-	err = json.Unmarshal([]byte(*resp.Body.Data), &data)
+// WatchStatus 推送OCR任务进度
+// 先推送一次当前状态，之后每识别完一页推送一次，任务结束时推送结果并关闭
+func (t *OCRService) WatchStatus(ctx context.Context, req *v1.OCRTaskID, stream v1.OCRService_WatchStatusStream) error {
+	defer stream.Close()
+
+	// 先开始等待再读取状态，避免错过两者之间的事件
+	events, stop := t.hub.Watch(req.TaskId)
+	defer stop()
+
+	var status OCRStatus
+	err := t.redisClient.Get(ctx, req.TaskId).Scan(&status)
 	if err != nil {
-		return "", err
+		return err
+	}
+	err = stream.Send(status.Progress())
+	if err != nil || status.Finished {
+		return err
 	}
 
-	return data["content"].(string), nil
-}
+	// 定期重新推送当前状态，既能发现客户端断开，也能兜底丢失的事件
+	ticker := time.NewTicker(watchResendInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e := <-events:
+			if e.Topic == event.TopicOCRProgress {
+				err = stream.Send(&v1.OCRProgress{Page: e.Index, PagesDone: e.Done, PagesTotal: e.Total})
+				if err != nil {
+					return err
+				}
+				continue
+			}
+		case <-ticker.C:
+		}
 
-// ConvertLocalImages 将本地PDF文件转换为图像
-// 因为接口不支持直接输入多页 PDF ，所以用命令把 PDF 拆分成图片，一个个喂
-func (t *OCRService) ConvertLocalImages(bucket, filePath string) ([]string, func(), error) {
-	// 获取OSS存储桶
-	bkt, err := t.oss.Bucket(bucket)
-	if err != nil {
-		return nil, nil, err
+		err = t.redisClient.Get(ctx, req.TaskId).Scan(&status)
+		if err != nil {
+			return err
+		}
+		err = stream.Send(status.Progress())
+		if err != nil || status.Finished {
+			return err
+		}
 	}
+}
 
+// DownloadFile 把对象存储中的PDF文件下载到本地临时文件，返回文件路径和清理函数
+func (t *OCRService) DownloadFile(ctx context.Context, bucket, filePath string) (string, func(), error) {
 	log.Printf("start ocr for object: %s", filePath)
 
-	// 获取OSS对象
-	object, err := bkt.GetObject(filePath)
+	// 获取存储对象
+	object, err := t.store.Get(ctx, bucket, filePath)
 	if err != nil {
 		log.Printf("get object %s/%s err: %+v", bucket, filePath, err)
-		return nil, nil, err
+		return "", nil, err
 	}
 	defer object.Close()
 
-	// 到这里我们已经从 oss 里面拿到了需要处理的 PDF
+	// 到这里我们已经从对象存储里面拿到了需要处理的 PDF
 
 	// 生成本地临时PDF文件并将对象内容复制到该文件，我们在对生产环境的任何文件进行更改的时候，都要复制一下去操作副本
 	localFilePath := fmt.Sprintf("%s/%s.pdf", os.TempDir(), uuid.NewString())
 	file, err := os.OpenFile(localFilePath, os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
-		return nil, nil, err
+		return "", nil, err
 	}
-	_, _ = io.Copy(file, object)
+	_, err = io.Copy(file, object)
 	_ = file.Close()
-	defer func() {
+	clean := func() {
 		_ = os.RemoveAll(localFilePath)
-	}()
-
-	// 然后将PDF文件转换为图像
-	return pdf.ConvertPdfToImages(localFilePath)
+	}
+	if err != nil {
+		clean()
+		return "", nil, err
+	}
+	return localFilePath, clean, nil
 }
 
-// StartPipeline 启动OCR处理管道，包括图像转换和OCR识别
-// 是总的流水线函数，对一个 PDF 做 OCR
+// StartPipeline 启动OCR处理管道，包括提取文本层、页面渲染和OCR识别
+// 是总的流水线函数，对一个 PDF 做 OCR。大多数论文自带文本层，只有没有可用文本的页面（如扫描件）才渲染为图像识别。
+// ocx 为要生成的识别结果，ID 就是任务ID
+func (t *OCRService) StartPipeline(ctx context.Context, ocx *OCR) error {
+	taskID := ocx.ID
+	localFilePath, clean, err := t.DownloadFile(ctx, ocx.Bucket, ocx.ObjectKey)
+	if err != nil {
+		return err
+	}
+	defer clean()
+
+	// 先按页提取PDF自带的文本层，文本不可用的页面记录下来交给OCR
+	layer, err := pdf.ExtractText(localFilePath)
+	if err != nil {
+		log.Printf("extract text layer of %s err: %+v", ocx.ObjectKey, err)
+		return err
+	}
+
+	// 每一页的结果识别完就保存，最后按页码顺序从存储中拼接文本
+	pages := make([]PageSource, len(layer))
+	var ocrPages []int
+	for i, text := range layer {
+		pages[i] = PageSource{Page: int32(i + 1), Source: SourceTextLayer}
+		if pdf.Usable(text) {
+			err = t.pageRepo.Save(&OCRPage{OCRID: taskID, Page: int32(i + 1), Text: text, Source: SourceTextLayer, Confidence: 1})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		pages[i].Source = SourceOCR
+		ocrPages = append(ocrPages, i+1)
+	}
+
+	var total = int32(len(pages))
+	var done int32
+	t.redisClient.Set(ctx, taskID, OCRStatus{PagesTotal: total}, time.Hour)
+	// 使用文本层的页面直接完成
+	for _, page := range pages {
+		if page.Source == SourceTextLayer {
+			done++
+			t.redisClient.Set(ctx, taskID, OCRStatus{PagesDone: done, PagesTotal: total}, time.Hour)
+			t.publishProgress(taskID, page.Page, done, total)
+		}
+	}
+
+	err = t.recognize(ctx, taskID, taskID, localFilePath, ocrPages, ocx.Language, done, total)
+	if ctx.Err() != nil {
+		// 任务被取消，部分结果不能作为缓存
+		_ = t.pageRepo.Delete(taskID)
+		return context.Cause(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	ocx.Pages = pages
+	return t.finish(ctx, taskID, ocx)
+}
 
-func (t *OCRService) StartPipeline(ctx context.Context, taskID, bucket, filePath string) error {
-	// 将本地PDF文件转换为图像
-	images, clean, err := t.ConvertLocalImages(bucket, filePath)
+// RetryPipeline 重新识别 ocx 中失败的页面，进度按整个文件计算，之前成功的页面算作已完成
+func (t *OCRService) RetryPipeline(ctx context.Context, taskID string, ocx *OCR) error {
+	localFilePath, clean, err := t.DownloadFile(ctx, ocx.Bucket, ocx.ObjectKey)
 	if err != nil {
 		return err
 	}
 	defer clean()
 
-	log.Printf("convert images is %+v", images)
+	pages := make([]int, len(ocx.FailedPages))
+	for i, page := range ocx.FailedPages {
+		pages[i] = int(page)
+	}
+	total := int32(len(ocx.Pages))
+	done := total - int32(len(pages))
+	t.redisClient.Set(ctx, taskID, OCRStatus{PagesDone: done, PagesTotal: total, OCRID: ocx.ID}, time.Hour)
+
+	// 取消时已经重新识别成功的页面保留，下次重试时不会再识别
+	err = t.recognize(ctx, taskID, ocx.ID, localFilePath, pages, ocx.Language, done, total)
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+	if err != nil {
+		return err
+	}
+	return t.finish(ctx, taskID, ocx)
+}
+
+// recognize 渲染并识别 pages 中的页面，每一页的结果保存在 ocrID 下。
+// 每个任务最多同时识别 Parallelism 页，所有任务共用引擎的信号量，done、total 为开始前的进度
+func (t *OCRService) recognize(ctx context.Context, taskID, ocrID, localFilePath string, pages []int, language string, done, total int32) error {
+	if len(pages) == 0 {
+		return nil
+	}
+	// 需要识别的页面按页码顺序逐页渲染，渲染好一页就交给空闲的协程识别，不必等待整个文件
+	rendered, err := t.renderer.Render(ctx, localFilePath, pdf.RenderOptions{Pages: pages})
+	if err != nil {
+		return err
+	}
+	semaphore := t.signalFactory.Semaphore("ocr:"+t.engine.Name(), t.options.Concurrency)
+
+	var mu sync.Mutex // 保证进度按完成顺序递增，同时保护 saveErr
+	var saveErr error
+	finish := func(page *OCRPage) {
+		page.OCRID = ocrID
+		err := t.pageRepo.Save(page)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			log.Printf("save page %d of ocr %s err: %+v", page.Page, ocrID, err)
+			saveErr = err
+		}
+		done++
+		t.redisClient.Set(ctx, taskID, OCRStatus{PagesDone: done, PagesTotal: total, OCRID: ocrID}, time.Hour)
+		t.publishProgress(taskID, page.Page, done, total)
+	}
+
 	var wg sync.WaitGroup
-	var texts = make([]string, len(images)) //这里先记录一下顺序，免得并发执行后 OCR 的文本顺序混乱
-	for index, imagePath := range images {
+	for i := 0; i < max(1, min(t.options.Parallelism, len(pages))); i++ {
 		wg.Add(1)
-		go func(index int, imagePath string) { //并发执行图片的 OCR，调接口同时进行
+		go func() {
 			defer wg.Done()
-			// 对每个图像执行OCR识别
-			text, err := t.OCRLocalImage(bucket, imagePath)
+			// 取消后渲染器会关闭通道
+			for page := range rendered {
+				if page.Err != nil {
+					log.Printf("render page %d of %s err: %+v", page.Page, localFilePath, page.Err)
+					finish(&OCRPage{Page: int32(page.Page), Source: SourceOCR, Engine: t.engine.Name(), Error: page.Err.Error()})
+					continue
+				}
+				record := t.recognizePage(ctx, semaphore, page, language)
+				if ctx.Err() != nil {
+					// 取消导致的失败不记录
+					continue
+				}
+				finish(record)
+			}
+		}()
+	}
+	wg.Wait()
+	return saveErr
+}
+
+// recognizePage 获取一个引擎信号量额度后识别一页，失败后按指数退避重试，耗时为最后一次识别的耗时
+func (t *OCRService) recognizePage(ctx context.Context, semaphore signal.Semaphore, page pdf.RenderedPage, language string) *OCRPage {
+	record := &OCRPage{Page: int32(page.Page), Source: SourceOCR, Engine: t.engine.Name()}
+	interval := t.options.RetryInterval
+	for {
+		record.Attempts++
+		release, err := t.acquire(ctx, semaphore)
+		if err == nil {
+			var result *ocrengine.Result
+			start := time.Now()
+			result, err = t.engine.Recognize(ctx, page.Image, language)
+			record.Duration = time.Since(start).Milliseconds()
+			release()
+			if err == nil {
+				record.Text, record.Confidence = result.Text, result.Confidence
+				return record
+			}
+		}
+		if int(record.Attempts) >= t.options.MaxAttempts || ctx.Err() != nil {
+			log.Printf("ocr page %d err: %+v", page.Page, err)
+			record.Error = err.Error()
+			return record
+		}
+		log.Printf("ocr page %d attempt %d err: %+v", page.Page, record.Attempts, err)
+		select {
+		case <-time.After(interval):
+			interval *= 2
+		case <-ctx.Done():
+			record.Error = context.Cause(ctx).Error()
+			return record
+		}
+	}
+}
+
+// acquire 获取一个引擎信号量额度，返回释放额度的函数。先立即尝试一次，没有空闲额度时每 500ms 重试
+func (t *OCRService) acquire(ctx context.Context, semaphore signal.Semaphore) (func(), error) {
+	acquired, err := semaphore.Acquire()
+	if err != nil {
+		return nil, err
+	}
+	ticker := time.NewTicker(time.Millisecond * 500)
+	defer ticker.Stop()
+	timer := time.NewTimer(t.options.AcquireTimeout)
+	defer timer.Stop()
+	for !acquired {
+		select {
+		case <-ticker.C:
+			acquired, err = semaphore.Acquire()
 			if err != nil {
-				log.Printf("ocr err: %+v", err)
-				return
+				return nil, err
 			}
-			texts[index] = text
-		}(index, imagePath)
+		case <-timer.C:
+			return nil, errors.New("wait to ocr timeout")
+		case <-ctx.Done():
+			return nil, context.Cause(ctx)
+		}
 	}
-	wg.Wait() //等待并发任务全部结束
+	return func() {
+		err := semaphore.Release()
+		if err != nil {
+			log.Printf("release semaphore err: %v", err)
+		}
+	}, nil
+}
 
-	// 将OCR识别的文本合并成一个文本，按照刚才记录的顺序
-	var buf bytes.Buffer
-	for i := range texts {
-		buf.WriteString(texts[i])
+// finish 按页码顺序拼接 ocx 已保存的页面，把任务标记为已完成并保存识别结果
+func (t *OCRService) finish(ctx context.Context, taskID string, ocx *OCR) error {
+	text, failed, err := t.assemble(ocx.ID)
+	if err != nil {
+		return err
 	}
+	if len(failed) > 0 {
+		log.Printf("ocr %s: %d of %d pages failed: %v", ocx.ID, len(failed), len(ocx.Pages), failed)
+		if text == "" {
+			return fmt.Errorf("all %d pages failed", len(failed))
+		}
+	}
+	ocx.OcredText, ocx.FailedPages = text, failed
 
-	// 将OCR任务的状态标记为已完成，并存储OCR结果到 Redis
-	t.redisClient.Set(ctx, taskID, OCRStatus{Text: buf.String(), Finished: true}, time.Hour)
-	if buf.String() != "" {
-		_ = t.ocrRepo.Create(&OCR{
-			ID:        taskID,
-			Bucket:    bucket,
-			ObjectKey: filePath,
-			OcredText: buf.String(),
-		})
+	if text != "" {
+		// 先保存识别结果，保存失败时任务失败，不会出现已完成但没有保存的结果
+		err = t.save(ocx)
+		if err != nil {
+			return err
+		}
 	}
+
+	// 将OCR任务的状态标记为已完成，并存储OCR结果到 Redis
+	total := int32(len(ocx.Pages))
+	t.redisClient.Set(ctx, taskID, OCRStatus{Text: text, Finished: true, PagesDone: total, PagesTotal: total, Pages: ocx.Pages, FailedPages: failed, OCRID: ocx.ID}, time.Hour)
 	return nil
 }
+
+// save 保存识别结果，之后的缓存命中使用这个结果；之前的结果和页面保留，已引用它们的任务不受影响
+func (t *OCRService) save(ocx *OCR) error {
+	if ocx.CreateAt.IsZero() {
+		ocx.CreateAt = time.Now()
+	}
+	return t.ocrRepo.Save(ocx)
+}
+
+// assemble 按页码顺序拼接已保存的页面，每页文本以换行结尾，返回文本和失败的页码
+func (t *OCRService) assemble(ocrID string) (string, []int32, error) {
+	pages, err := t.pageRepo.List(ocrID)
+	if err != nil {
+		return "", nil, err
+	}
+	var buf strings.Builder
+	var failed []int32
+	for _, page := range pages {
+		if page.Error != "" {
+			failed = append(failed, page.Page)
+			continue
+		}
+		buf.WriteString(page.Text)
+		if page.Text != "" && !strings.HasSuffix(page.Text, "\n") {
+			buf.WriteString("\n")
+		}
+	}
+	return buf.String(), failed, nil
+}
@@ -0,0 +1,193 @@
+package ocr_test
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"fmt"
+	v1 "paper-translation/api/ocr/service/v1"
+	"paper-translation/app/ocr/service/ocr"
+	"paper-translation/pkg/storage"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go-micro.dev/v4/broker"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// memoryOCRRepo 在内存中按 ID 保存识别结果，Get 返回最新的结果
+type memoryOCRRepo struct {
+	mu      sync.Mutex
+	results []*ocr.OCR
+}
+
+func (r *memoryOCRRepo) Save(o *ocr.OCR) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	saved := *o
+	for i, result := range r.results {
+		if result.ID == o.ID {
+			r.results[i] = &saved
+			return nil
+		}
+	}
+	r.results = append(r.results, &saved)
+	return nil
+}
+
+func (r *memoryOCRRepo) Get(fileHash string, bucket string, objectKey string, fileType string) (*ocr.OCR, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var newest *ocr.OCR
+	for _, result := range r.results {
+		match := result.FileHash == fileHash
+		if fileHash == "" {
+			match = result.Bucket == bucket && result.ObjectKey == objectKey && result.FileType == fileType
+		}
+		if match && (newest == nil || result.CreateAt.After(newest.CreateAt)) {
+			newest = result
+		}
+	}
+	if newest == nil {
+		return nil, mongo.ErrNoDocuments
+	}
+	return newest, nil
+}
+
+func (r *memoryOCRRepo) GetByID(id string) (*ocr.OCR, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, result := range r.results {
+		if result.ID == id {
+			return result, nil
+		}
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+// memoryPageRepo 在内存中保存每一页的识别结果
+type memoryPageRepo struct {
+	mu    sync.Mutex
+	pages map[string][]*ocr.OCRPage
+}
+
+func (r *memoryPageRepo) Save(page *ocr.OCRPage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pages[page.OCRID] = append(r.pages[page.OCRID], page)
+	return nil
+}
+
+func (r *memoryPageRepo) List(ocrID string) ([]*ocr.OCRPage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.pages[ocrID], nil
+}
+
+func (r *memoryPageRepo) Delete(ocrID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.pages, ocrID)
+	return nil
+}
+
+// redisHook 在内存中执行 SET 和 GET，其他命令直接忽略，不连接 Redis
+type redisHook struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (h *redisHook) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h *redisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error { return nil }
+}
+
+func (h *redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		args := cmd.Args()
+		switch cmd.Name() {
+		case "set":
+			value, _ := args[2].(encoding.BinaryMarshaler).MarshalBinary()
+			h.values[args[1].(string)] = string(value)
+		case "get":
+			value, ok := h.values[args[1].(string)]
+			if !ok {
+				cmd.SetErr(redis.Nil)
+				return redis.Nil
+			}
+			cmd.(*redis.StringCmd).SetVal(value)
+		}
+		return nil
+	}
+}
+
+// textPDF 一页带文本层的文档，文本足够长，不需要 OCR 引擎
+func textPDF(text string) []byte {
+	content := fmt.Sprintf("BT /F1 12 Tf 72 720 Td (%s) Tj ET", text)
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+	}
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return b.Bytes()
+}
+
+/**
+ * TestReOCRKeepsResult 测试两篇论文共用同一文件哈希，其中一篇强制重新识别后，
+ * 另一篇引用的旧结果和页面仍然可以读取，缓存命中最新的结果。
+ */
+func TestReOCRKeepsResult(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewLocalObjectStore(t.TempDir(), nil)
+	first := "The first recognition of this paper keeps its own text."
+	second := "The second recognition of this paper replaces the cache entry."
+	assert.Nil(t, store.Put(ctx, "papers", "a.pdf", bytes.NewReader(textPDF(first))))
+	assert.Nil(t, store.Put(ctx, "papers", "b.pdf", bytes.NewReader(textPDF(second))))
+
+	b := broker.NewMemoryBroker()
+	assert.Nil(t, b.Connect())
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1"})
+	redisClient.AddHook(&redisHook{values: map[string]string{}})
+	ocrRepo := &memoryOCRRepo{}
+	pageRepo := &memoryPageRepo{pages: map[string][]*ocr.OCRPage{}}
+	s := ocr.NewOCRService(ocrRepo, pageRepo, nil, nil, nil, store, redisClient, b, ocr.Options{})
+
+	// 第一篇论文识别后，第二篇论文使用同一哈希强制重新识别
+	assert.Nil(t, s.StartPipeline(ctx, &ocr.OCR{ID: "old", Bucket: "papers", ObjectKey: "a.pdf", FileType: "pdf", FileHash: "hash"}))
+	time.Sleep(time.Millisecond)
+	assert.Nil(t, s.StartPipeline(ctx, &ocr.OCR{ID: "new", Bucket: "papers", ObjectKey: "b.pdf", FileType: "pdf", FileHash: "hash"}))
+
+	var result v1.OCRText
+	assert.Nil(t, s.GetResult(ctx, &v1.OCRTaskID{TaskId: "old"}, &result))
+	assert.Equal(t, "old", result.OcrId)
+	assert.Contains(t, result.Text, first)
+	var pages v1.OCRPages
+	assert.Nil(t, s.GetPages(ctx, &v1.OCRTaskID{TaskId: "old"}, &pages))
+	assert.Len(t, pages.Pages, 1)
+
+	cached, err := ocrRepo.Get("hash", "", "", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "new", cached.ID)
+	assert.Contains(t, cached.OcredText, second)
+}
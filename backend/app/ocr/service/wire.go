@@ -9,9 +9,12 @@ import (
 	v1 "paper-translation/api/ocr/service/v1"
 	"paper-translation/app/ocr/service/ocr"
 	"paper-translation/pkg/ds"
+	"paper-translation/pkg/event"
 	aliYunOCR "paper-translation/pkg/ocr"
-	"paper-translation/pkg/oss"
+	"paper-translation/pkg/pdf"
 	"paper-translation/pkg/service"
+	"paper-translation/pkg/signal"
+	"paper-translation/pkg/storage"
 )
 
 func InitApp() micro.Service {
@@ -20,10 +23,15 @@ func InitApp() micro.Service {
 		ds.NewMongoClient,
 		ds.NewMongoDatabase,
 		ds.NewRedisClient,
-		aliYunOCR.NewAliYunOCR,
-		oss.NewAliYunOSS,
+		event.NewBroker,
+		aliYunOCR.NewOCREngine,
+		pdf.NewRenderer,
+		signal.NewSignalFactory,
+		storage.NewObjectStore,
 		ocr.NewMongoOCRRepository, wire.Bind(new(ocr.OCRRepository), new(*ocr.MongoOCRRepository)),
+		ocr.NewMongoOCRPageRepository, wire.Bind(new(ocr.OCRPageRepository), new(*ocr.MongoOCRPageRepository)),
 		ocr.NewOCRService, wire.Bind(new(v1.OCRServiceHandler), new(*ocr.OCRService)),
+		NewOCROptions,
 		NewService,
 	))
 }
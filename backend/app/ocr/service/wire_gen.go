@@ -10,9 +10,12 @@ import (
 	"go-micro.dev/v4"
 	"paper-translation/app/ocr/service/ocr"
 	"paper-translation/pkg/ds"
+	"paper-translation/pkg/event"
 	ocr2 "paper-translation/pkg/ocr"
-	"paper-translation/pkg/oss"
+	"paper-translation/pkg/pdf"
 	"paper-translation/pkg/service"
+	"paper-translation/pkg/signal"
+	"paper-translation/pkg/storage"
 )
 
 // Injectors from wire.go:
@@ -23,10 +26,15 @@ func InitApp() micro.Service {
 	client := ds.NewMongoClient(config)
 	database := ds.NewMongoDatabase(config, client)
 	mongoOCRRepository := ocr.NewMongoOCRRepository(database)
-	clientClient := ocr2.NewAliYunOCR(config)
-	ossClient := oss.NewAliYunOSS(config)
+	mongoOCRPageRepository := ocr.NewMongoOCRPageRepository(database)
+	ocrEngine := ocr2.NewOCREngine(config)
+	renderer := pdf.NewRenderer(config)
+	signalFactory := signal.NewSignalFactory(config)
+	objectStore := storage.NewObjectStore(config)
 	redisClient := ds.NewRedisClient(config)
-	ocrService := ocr.NewOCRService(mongoOCRRepository, clientClient, ossClient, redisClient)
+	broker := event.NewBroker(config)
+	options := NewOCROptions(config)
+	ocrService := ocr.NewOCRService(mongoOCRRepository, mongoOCRPageRepository, ocrEngine, renderer, signalFactory, objectStore, redisClient, broker, options)
 	microService := NewService(registry, config, ocrService)
 	return microService
 }
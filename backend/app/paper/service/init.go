@@ -7,6 +7,7 @@ import (
 	os "paper-translation/api/ocr/service/v1"
 	v1 "paper-translation/api/paper/service/v1"
 	ts "paper-translation/api/translation/service/v1"
+	"paper-translation/app/paper/service/paper"
 	"paper-translation/pkg/errutil"
 	"paper-translation/pkg/service"
 	"time"
@@ -16,11 +17,13 @@ import (
 	"go-micro.dev/v4/registry"
 )
 
-func NewService(registry registry.Registry, config config.Config, handler v1.PaperServiceHandler) micro.Service {
+func NewService(registry registry.Registry, config config.Config, handler v1.PaperServiceHandler, worker *paper.PipelineWorker) micro.Service {
 	svc := micro.NewService(
 		micro.Name(service.PaperServiceName),
 		micro.Address(config.Get("server", "addr").String(":4000")),
 		micro.Registry(registry),
+		micro.AfterStart(worker.Start),
+		micro.BeforeStop(worker.Stop),
 	)
 	err := v1.RegisterPaperServiceHandler(svc.Server(), handler)
 	errutil.PanicIfErr(err)
@@ -28,6 +31,16 @@ func NewService(registry registry.Registry, config config.Config, handler v1.Pap
 	return svc
 }
 
+func NewWorkerOptions(config config.Config) paper.WorkerOptions {
+	return paper.WorkerOptions{
+		Concurrency:  config.Get("pipeline", "concurrency").Int(4),
+		Lease:        config.Get("pipeline", "lease").Duration(time.Second * 30),
+		MaxAttempts:  int32(config.Get("pipeline", "max_attempts").Int(3)),
+		StageTimeout: config.Get("pipeline", "stage_timeout").Duration(time.Hour),
+		PollInterval: time.Second,
+	}
+}
+
 func NewFileService(registry registry.Registry) fs.FileService {
 	cli := client.NewClient(
 		client.Registry(registry),
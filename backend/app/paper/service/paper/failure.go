@@ -0,0 +1,78 @@
+package paper
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	merrors "go-micro.dev/v4/errors"
+)
+
+// 失败错误码
+const (
+	FailureTimeout     = "timeout"      // 阶段超时，可以重试
+	FailureUnavailable = "unavailable"  // 下游服务不可用，可以重试
+	FailureTaskFailed  = "task_failed"  // 下游任务执行失败，例如OCR引擎或大模型报错
+	FailureEmptyResult = "empty_result" // 下游任务没有识别或翻译出任何内容，重试通常也无济于事
+	FailureInternal    = "internal"     // 其他错误
+)
+
+// ErrEmptyResult 下游任务结束但没有结果
+var ErrEmptyResult = errors.New("empty result")
+
+// TaskError 下游任务报告的失败原因
+type TaskError struct {
+	Message string
+}
+
+func (t *TaskError) Error() string {
+	return t.Message
+}
+
+// Failure 论文处理的失败记录
+type Failure struct {
+	Stage    string    `bson:"Stage"`    // 失败的阶段
+	Code     string    `bson:"Code"`     // 错误码
+	Message  string    `bson:"Message"`  // 错误信息
+	FailedAt time.Time `bson:"FailedAt"` // 失败时间
+	Attempt  int32     `bson:"Attempt"`  // 该阶段第几次尝试失败
+}
+
+// NewFailure 根据错误创建失败记录
+func NewFailure(stage string, attempt int32, err error) *Failure {
+	return &Failure{
+		Stage:    stage,
+		Code:     failureCode(err),
+		Message:  err.Error(),
+		FailedAt: time.Now(),
+		Attempt:  attempt,
+	}
+}
+
+// failureCode 把错误归类为失败错误码
+func failureCode(err error) string {
+	var taskErr *TaskError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return FailureTimeout
+	case errors.Is(err, ErrEmptyResult):
+		return FailureEmptyResult
+	case errors.As(err, &taskErr):
+		return FailureTaskFailed
+	}
+
+	// 调用下游服务的错误
+	var microErr *merrors.Error
+	if errors.As(err, &microErr) {
+		switch {
+		case microErr.Code == http.StatusRequestTimeout:
+			return FailureTimeout
+		case microErr.Id == "go.micro.client":
+			return FailureUnavailable
+		default:
+			return FailureTaskFailed
+		}
+	}
+	return FailureInternal
+}
@@ -0,0 +1,208 @@
+package paper
+
+import (
+	"errors"
+	v1 "paper-translation/api/paper/service/v1"
+	"paper-translation/pkg/event"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// FanOut 父论文OCR完成后为每篇子论文创建从翻译阶段开始的任务。
+// 子论文已有任务时说明父论文重新执行了OCR阶段，已经结束的子论文使用新的识别结果重新翻译。
+func (t *PaperService) FanOut(paper *Paper, ocrID string) error {
+	children, err := t.repo.GetChildren(paper.ID)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		// 子论文使用父论文的识别结果，OCR失败的页码一并记录
+		err = t.repo.SetFailedPages(child.ID, paper.FailedPages)
+		if err != nil {
+			return err
+		}
+		job := NewJob(child.ID)
+		job.Stage, job.OCRID = StageTranslation, ocrID
+		err = t.jobRepo.Create(job)
+		if mongo.IsDuplicateKeyError(err) {
+			err = t.restart(child, []string{JobFinished, JobFailed, JobCancelled}, map[string]any{"Stage": StageTranslation, "OCRID": ocrID})
+			if errors.Is(err, ErrJobState) {
+				// 子论文正在处理，例如分发到一半时实例退出后重新分发
+				err = nil
+			}
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		err = t.SetStatus(child.ID, v1.Paper_translation)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SyncParent 子论文的状态变化后汇总父论文的状态。
+// 有子论文在处理时父论文处于翻译阶段；全部结束后有失败为失败，有取消为取消，否则为完成。
+func (t *PaperService) SyncParent(id string) error {
+	parent, err := t.repo.Get(id)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// 父论文已被删除
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	children, err := t.repo.GetChildren(id)
+	if err != nil {
+		return err
+	}
+
+	status := v1.Paper_finished
+	var failure *Failure
+	for _, child := range children {
+		switch v1.Paper_Status(child.Status) {
+		case v1.Paper_finished:
+		case v1.Paper_failed:
+			if failure == nil && child.Failure != nil {
+				f := *child.Failure
+				f.Message = child.TargetLanguage + ": " + f.Message
+				failure = &f
+			}
+			status = v1.Paper_failed
+		case v1.Paper_cancelled:
+			if status != v1.Paper_failed {
+				status = v1.Paper_cancelled
+			}
+		default:
+			// 子论文还在处理，只把已经结束的父论文恢复为翻译阶段，OCR阶段的状态由父论文的任务维护
+			if paperEnded(parent) {
+				err = t.repo.SetFailure(id, nil)
+				if err != nil {
+					return err
+				}
+				return t.SetStatus(id, v1.Paper_translation)
+			}
+			return nil
+		}
+	}
+
+	if v1.Paper_Status(parent.Status) == status {
+		return nil
+	}
+	err = t.repo.SetFailure(id, failure)
+	if err != nil {
+		return err
+	}
+	err = t.SetStatus(id, status)
+	if err != nil {
+		return err
+	}
+	switch status {
+	case v1.Paper_finished:
+		t.publish(event.TopicPaperFinished, &event.TaskEvent{TaskID: id})
+	case v1.Paper_failed:
+		var reason string
+		if failure != nil {
+			reason = failure.Message
+		}
+		t.publish(event.TopicPaperFailed, &event.TaskEvent{TaskID: id, Error: reason})
+	case v1.Paper_cancelled:
+		t.publish(event.TopicPaperCancelled, &event.TaskEvent{TaskID: id})
+	}
+	return nil
+}
+
+// paperEnded 论文是否已经结束
+func paperEnded(paper *Paper) bool {
+	switch v1.Paper_Status(paper.Status) {
+	case v1.Paper_finished, v1.Paper_failed, v1.Paper_cancelled:
+		return true
+	}
+	return false
+}
+
+// SyncFailed 论文失败后同步父子论文的状态：子论文失败时汇总父论文的状态，父论文在OCR阶段失败时等待OCR的子论文一并失败
+func (t *PaperService) SyncFailed(id string) error {
+	paper, err := t.repo.Get(id)
+	if err != nil {
+		return err
+	}
+	if paper.ParentID != "" {
+		return t.SyncParent(paper.ParentID)
+	}
+	if len(paper.TargetLanguages) == 0 {
+		return nil
+	}
+	children, err := t.repo.GetChildren(id)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		if v1.Paper_Status(child.Status) != v1.Paper_ocr {
+			continue
+		}
+		err = t.repo.SetFailure(child.ID, paper.Failure)
+		if err != nil {
+			return err
+		}
+		err = t.SetStatus(child.ID, v1.Paper_failed)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resetChildren 父论文从OCR阶段重新处理时，把不在处理中的子论文重置为等待OCR
+func (t *PaperService) resetChildren(id string) error {
+	children, err := t.repo.GetChildren(id)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		job, err := t.jobRepo.Get(child.ID)
+		if err == nil && (job.Status == JobPending || job.Status == JobRunning) {
+			continue
+		}
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return err
+		}
+		err = t.repo.SetFailure(child.ID, nil)
+		if err != nil {
+			return err
+		}
+		err = t.SetStatus(child.ID, v1.Paper_ocr)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restartChildren 依次重新处理父论文的子论文，跳过状态不允许的子论文，没有子论文可以重新处理时返回 ErrJobState
+func (t *PaperService) restartChildren(id string, restart func(child *Paper) error) error {
+	children, err := t.repo.GetChildren(id)
+	if err != nil {
+		return err
+	}
+	restarted := false
+	for _, child := range children {
+		err = restart(child)
+		if errors.Is(err, ErrJobState) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		restarted = true
+	}
+	if !restarted {
+		return ErrJobState
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package paper
+
+import "time"
+
+// 流水线阶段
+const (
+	StageOCR         = "ocr"         // OCR识别
+	StageTranslation = "translation" // 翻译
+	StageNotify      = "notify"      // 发送邮件通知
+	StageFanOut      = "fanout"      // 多目标语言的论文为每篇子论文创建翻译任务
+)
+
+// 任务状态
+const (
+	JobPending   = "pending"   // 等待被领取
+	JobRunning   = "running"   // 已被某个实例领取，租约有效期内由该实例执行
+	JobFinished  = "finished"  // 全部阶段完成
+	JobFailed    = "failed"    // 重试次数用尽
+	JobCancelled = "cancelled" // 已被用户取消
+)
+
+// Job 是论文处理流水线的持久化任务，ID 与论文ID相同。
+// 实例通过租约领取任务并定期心跳续约，实例重启或宕机后租约过期，任务会被其他实例从当前阶段继续执行。
+type Job struct {
+	ID            string    `bson:"ID"`
+	Stage         string    `bson:"Stage"`         // 当前阶段
+	Status        string    `bson:"Status"`        // 任务状态
+	Attempts      int32     `bson:"Attempts"`      // 当前阶段已失败的次数
+	LeaseOwner    string    `bson:"LeaseOwner"`    // 持有租约的实例
+	LeaseExpireAt time.Time `bson:"LeaseExpireAt"` // 租约过期时间
+	HeartbeatAt   time.Time `bson:"HeartbeatAt"`   // 最近一次心跳时间
+	NextRunAt     time.Time `bson:"NextRunAt"`     // 最早可以被领取的时间，用于失败后退避
+	TaskID        string    `bson:"TaskID"`        // 当前阶段在下游服务中的任务ID
+	OCRID         string    `bson:"OCRID"`         // OCR阶段识别结果的ID，识别文本保存在OCR服务中，翻译时按ID读取
+	LastError     string    `bson:"LastError"`     // 最近一次失败的原因
+	SkipOCRCache  bool      `bson:"SkipOCRCache"`  // OCR阶段忽略已缓存的识别结果
+	CreateAt      time.Time `bson:"CreateAt"`
+	UpdateAt      time.Time `bson:"UpdateAt"`
+}
+
+// NewJob 创建一个从OCR阶段开始、可以立即被领取的任务。
+func NewJob(id string) *Job {
+	now := time.Now()
+	return &Job{
+		ID:        id,
+		Stage:     StageOCR,
+		Status:    JobPending,
+		NextRunAt: now,
+		CreateAt:  now,
+		UpdateAt:  now,
+	}
+}
@@ -0,0 +1,123 @@
+package paper
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// ErrLeaseLost 表示当前实例已经不再持有任务租约。
+var ErrLeaseLost = errors.New("job lease lost")
+
+// ErrJobState 表示任务当前的状态不允许该操作。
+var ErrJobState = errors.New("job state conflict")
+
+type JobRepository interface {
+	Create(job *Job) error
+	Get(id string) (*Job, error)
+	Claim(owner string, lease time.Duration) (*Job, error)
+	Heartbeat(id, owner string, lease time.Duration) error
+	Update(id, owner string, set map[string]any) error
+	Transition(id string, from []string, set map[string]any) (*Job, error)
+	Delete(id string) error
+}
+
+type MongoJobRepository struct {
+	C *mongo.Collection
+}
+
+func NewMongoJobRepository(db *mongo.Database) *MongoJobRepository {
+	c := db.Collection("paper_jobs")
+	_, err := c.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "ID", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "Status", Value: 1}, {Key: "NextRunAt", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("create paper_jobs indexes err: %+v", err)
+	}
+	return &MongoJobRepository{C: c}
+}
+
+func (t *MongoJobRepository) Create(job *Job) error {
+	_, err := t.C.InsertOne(context.TODO(), job)
+	return err
+}
+
+func (t *MongoJobRepository) Get(id string) (j *Job, err error) {
+	return j, t.C.FindOne(context.TODO(), bson.M{"ID": id}).Decode(&j)
+}
+
+// Claim 原子地领取一个可执行的任务：等待中且已到执行时间，或者执行中但租约已过期。
+// 没有可领取的任务时返回 mongo.ErrNoDocuments。
+func (t *MongoJobRepository) Claim(owner string, lease time.Duration) (j *Job, err error) {
+	now := time.Now()
+	filter := bson.M{
+		"$or": bson.A{
+			bson.M{"Status": JobPending, "NextRunAt": bson.M{"$lte": now}},
+			bson.M{"Status": JobRunning, "LeaseExpireAt": bson.M{"$lt": now}},
+		},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"Status":        JobRunning,
+			"LeaseOwner":    owner,
+			"LeaseExpireAt": now.Add(lease),
+			"HeartbeatAt":   now,
+			"UpdateAt":      now,
+		},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.M{"NextRunAt": 1}).
+		SetReturnDocument(options.After)
+	return j, t.C.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&j)
+}
+
+// Heartbeat 续约，租约已被其他实例抢走或任务已被删除时返回 ErrLeaseLost。
+func (t *MongoJobRepository) Heartbeat(id, owner string, lease time.Duration) error {
+	now := time.Now()
+	return t.Update(id, owner, map[string]any{
+		"LeaseExpireAt": now.Add(lease),
+		"HeartbeatAt":   now,
+	})
+}
+
+// Update 在持有租约的前提下更新任务。
+func (t *MongoJobRepository) Update(id, owner string, set map[string]any) error {
+	set["UpdateAt"] = time.Now()
+	result, err := t.C.UpdateOne(context.TODO(), bson.M{
+		"ID":         id,
+		"Status":     JobRunning,
+		"LeaseOwner": owner,
+	}, bson.M{"$set": set})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+// Transition 仅当任务处于 from 中的某个状态时更新任务并返回更新后的任务，否则返回 ErrJobState。
+// 用于重试、取消等不持有租约的操作。
+func (t *MongoJobRepository) Transition(id string, from []string, set map[string]any) (j *Job, err error) {
+	set["UpdateAt"] = time.Now()
+	err = t.C.FindOneAndUpdate(context.TODO(), bson.M{
+		"ID":     id,
+		"Status": bson.M{"$in": from},
+	}, bson.M{"$set": set}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&j)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, ErrJobState
+	}
+	return j, err
+}
+
+func (t *MongoJobRepository) Delete(id string) error {
+	_, err := t.C.DeleteOne(context.TODO(), bson.M{"ID": id})
+	return err
+}
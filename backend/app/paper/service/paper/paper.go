@@ -9,5 +9,15 @@ type Paper struct {
 	Status         int32     `bson:"Status"`
 	EmailTo        string    `bson:"EmailTo"`
 	ResultText     string    `bson:"ResultText"`
-	TargetLanguage string    `bson:"TargetLanguage"`
+	TargetLanguage string    `bson:"TargetLanguage"`           // 规范的 BCP-47 标签
+	SourceLanguage string    `bson:"SourceLanguage,omitempty"` // 创建时没有指定的在OCR完成后自动检测
+	GlossaryID     string    `bson:"GlossaryID,omitempty"`     // 翻译使用的术语表
+	Title          string    `bson:"Title,omitempty"`
+	PromptName     string    `bson:"PromptName,omitempty"`    // 翻译使用的提示词模板，为空时使用默认模板
+	PromptVersion  string    `bson:"PromptVersion,omitempty"` // 为空时使用模板的最新版本
+	Failure        *Failure  `bson:"Failure,omitempty"`       // 最近一次失败记录，处理成功后清空
+	FailedPages    []int32   `bson:"FailedPages,omitempty"`   // OCR识别失败的页码，重新OCR后更新
+	// 多目标语言的论文OCR只执行一次，识别结果分发给每种语言的子论文分别翻译，父论文的状态由子论文汇总
+	TargetLanguages []string `bson:"TargetLanguages,omitempty"` // 父论文的全部目标语言
+	ParentID        string   `bson:"ParentID,omitempty"`        // 子论文所属的父论文
 }
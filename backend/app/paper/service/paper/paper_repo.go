@@ -12,9 +12,14 @@ type PaperRepository interface {
 	Create(paper *Paper) error
 	Get(id string) (*Paper, error)
 	UpdateText(id string, text string) error
+	SetSourceLanguage(id string, language string) error
 	SetStatus(id string, status int32) error
+	SetFailure(id string, failure *Failure) error
+	SetFailedPages(id string, pages []int32) error
 	Delete(id string) error
 	GetPapers() ([]*Paper, error)
+	GetByStatus(status ...int32) ([]*Paper, error)
+	GetChildren(parentID string) ([]*Paper, error)
 }
 
 type MongoPaperRepository struct {
@@ -43,6 +48,16 @@ func (t *MongoPaperRepository) UpdateText(id string, text string) error {
 	return err
 }
 
+// SetSourceLanguage 设置论文的原文语言，多目标语言的论文同时设置子论文
+func (t *MongoPaperRepository) SetSourceLanguage(id string, language string) error {
+	_, err := t.C.UpdateMany(context.TODO(), bson.M{"$or": bson.A{bson.M{"ID": id}, bson.M{"ParentID": id}}}, bson.M{
+		"$set": bson.M{
+			"SourceLanguage": language,
+		},
+	})
+	return err
+}
+
 func (t *MongoPaperRepository) SetStatus(id string, status int32) error {
 	_, err := t.C.UpdateOne(context.TODO(), bson.M{"ID": id}, bson.M{
 		"$set": bson.M{
@@ -52,13 +67,50 @@ func (t *MongoPaperRepository) SetStatus(id string, status int32) error {
 	return err
 }
 
+func (t *MongoPaperRepository) SetFailure(id string, failure *Failure) error {
+	update := bson.M{"$set": bson.M{"Failure": failure}}
+	if failure == nil {
+		update = bson.M{"$unset": bson.M{"Failure": ""}}
+	}
+	_, err := t.C.UpdateOne(context.TODO(), bson.M{"ID": id}, update)
+	return err
+}
+
+// SetFailedPages 保存OCR识别失败的页码，没有失败的页面时清空
+func (t *MongoPaperRepository) SetFailedPages(id string, pages []int32) error {
+	update := bson.M{"$set": bson.M{"FailedPages": pages}}
+	if len(pages) == 0 {
+		update = bson.M{"$unset": bson.M{"FailedPages": ""}}
+	}
+	_, err := t.C.UpdateOne(context.TODO(), bson.M{"ID": id}, update)
+	return err
+}
+
 func (t *MongoPaperRepository) Delete(id string) error {
 	_, err := t.C.DeleteOne(context.TODO(), bson.M{"ID": id})
 	return err
 }
 
+// GetPapers 返回除子论文外的全部论文，子论文随父论文返回
 func (t *MongoPaperRepository) GetPapers() (ps []*Paper, err error) {
-	cur, err := t.C.Find(context.TODO(), bson.M{}, options.Find().SetSort(bson.M{"CreateAt": -1}))
+	cur, err := t.C.Find(context.TODO(), bson.M{"ParentID": bson.M{"$exists": false}}, options.Find().SetSort(bson.M{"CreateAt": -1}))
+	if err != nil {
+		return nil, err
+	}
+	return ps, cur.All(context.TODO(), &ps)
+}
+
+func (t *MongoPaperRepository) GetByStatus(status ...int32) (ps []*Paper, err error) {
+	cur, err := t.C.Find(context.TODO(), bson.M{"Status": bson.M{"$in": status}})
+	if err != nil {
+		return nil, err
+	}
+	return ps, cur.All(context.TODO(), &ps)
+}
+
+// GetChildren 按创建顺序，即目标语言的顺序返回父论文的子论文
+func (t *MongoPaperRepository) GetChildren(parentID string) (ps []*Paper, err error) {
+	cur, err := t.C.Find(context.TODO(), bson.M{"ParentID": parentID}, options.Find().SetSort(bson.M{"_id": 1}))
 	if err != nil {
 		return nil, err
 	}
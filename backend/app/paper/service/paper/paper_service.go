@@ -3,39 +3,68 @@ package paper
 import (
 	"context"
 	"errors"
+	"fmt"
+	"go-micro.dev/v4/broker"
 	"go-micro.dev/v4/client"
+	merrors "go-micro.dev/v4/errors"
 	"log"
+	"net/http"
 	es "paper-translation/api/email/service/v1"
 	fs "paper-translation/api/file/service/v1"
 	os "paper-translation/api/ocr/service/v1"
 	v1 "paper-translation/api/paper/service/v1"
 	ts "paper-translation/api/translation/service/v1"
+	"paper-translation/pkg/errutil"
+	"paper-translation/pkg/event"
+	"paper-translation/pkg/lang"
+	"paper-translation/pkg/service"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type PaperService struct {
 	repo             PaperRepository
+	jobRepo          JobRepository
 	fileService      fs.FileService
 	ocrService       os.OCRService
 	translateService ts.TranslationService
 	emailService     es.EmailService
+	broker           broker.Broker // 发布论文处理事件
+	hub              *event.Hub    // 把论文处理事件分发给 Watch
 }
 
 func NewPaperService(
 	repo PaperRepository,
+	jobRepo JobRepository,
 	fileService fs.FileService,
 	ocrService os.OCRService,
 	translateService ts.TranslationService,
 	emailService es.EmailService,
+	broker broker.Broker,
 ) *PaperService {
+	// 论文可能由其他实例处理，进度通过 broker 汇总到订阅的实例
+	hub, err := event.NewHub(broker,
+		event.TopicPaperStage,
+		event.TopicPaperOCRProgress,
+		event.TopicPaperTranslationProgress,
+		event.TopicPaperFinished,
+		event.TopicPaperFailed,
+		event.TopicPaperCancelled,
+	)
+	errutil.PanicIfErr(err)
 	return &PaperService{
 		repo:             repo,
+		jobRepo:          jobRepo,
 		fileService:      fileService,
 		ocrService:       ocrService,
 		translateService: translateService,
 		emailService:     emailService,
+		broker:           broker,
+		hub:              hub,
 	}
 }
 
@@ -50,31 +79,121 @@ func (t *PaperService) Create(ctx context.Context, req *v1.CreatePaper, resp *v1
 		return errors.New("file is not uploaded")
 	}
 
+	languages, err := targetLanguages(req)
+	if err != nil {
+		return err
+	}
+	var sourceLanguage string
+	if req.SourceLanguage != "" {
+		sourceLanguage, err = lang.Normalize(req.SourceLanguage)
+		if err != nil {
+			return merrors.BadRequest(service.PaperServiceName, "invalid source language: %v", err)
+		}
+	}
+	glossaryLanguage, err := t.glossaryLanguage(ctx, req.GlossaryId)
+	if err != nil {
+		return err
+	}
+	if glossaryLanguage != "" && !slices.ContainsFunc(languages, func(language string) bool { return lang.Match(language, glossaryLanguage) }) {
+		return merrors.BadRequest(service.PaperServiceName, "glossary %s target language is %s, not %s", req.GlossaryId, glossaryLanguage, strings.Join(languages, ","))
+	}
+
 	paper := Paper{
 		ID:             uuid.NewString(),
 		FileHash:       req.PaperFileHash,
 		CreateAt:       time.Now(),
-		Status:         0,
+		Status:         int32(v1.Paper_ocr),
 		EmailTo:        req.EmailTo,
-		TargetLanguage: req.TargetLanguage,
+		SourceLanguage: sourceLanguage,
+		GlossaryID:     req.GlossaryId,
+		Title:          req.Title,
+		PromptName:     req.PromptName,
+		PromptVersion:  req.PromptVersion,
+	}
+	if len(languages) > 1 {
+		paper.TargetLanguages = languages
+	} else {
+		paper.TargetLanguage = languages[0]
 	}
 
-	go func() {
-		err = t.StartPipeline(context.TODO(), paper.ID, *fileInfo.Bucket, *fileInfo.FilePath, req.TargetLanguage, req.EmailTo)
+	err = t.repo.Create(&paper)
+	if err != nil {
+		return err
+	}
+	// 子论文在父论文的任务之前创建，OCR完成后由父论文的任务分发识别结果
+	for _, language := range paper.TargetLanguages {
+		child := paper
+		child.ID = uuid.NewString()
+		child.TargetLanguage = language
+		child.TargetLanguages = nil
+		child.ParentID = paper.ID
+		if !lang.Match(language, glossaryLanguage) {
+			// 术语表只用于目标语言一致的子论文
+			child.GlossaryID = ""
+		}
+		err = t.repo.Create(&child)
 		if err != nil {
-			log.Printf("exec paper pipeline failed err: %+v", err)
+			return err
 		}
-	}()
-	t.ConvertPaper(&paper, resp)
-	return t.repo.Create(&paper)
+	}
+	// 流水线由 PipelineWorker 领取执行
+	err = t.jobRepo.Create(NewJob(paper.ID))
+	if err != nil {
+		return err
+	}
+	return t.Fetch(ctx, &v1.PaperID{Id: paper.ID}, resp)
+}
+
+// targetLanguages 合并请求中的单个目标语言和多个目标语言，规范化为 BCP-47 标签后去掉重复值并保持请求中的顺序
+func targetLanguages(req *v1.CreatePaper) ([]string, error) {
+	var languages []string
+	for _, language := range append([]string{req.TargetLanguage}, req.TargetLanguages...) {
+		if language == "" {
+			continue
+		}
+		tag, err := lang.Normalize(language)
+		if err != nil {
+			return nil, merrors.BadRequest(service.PaperServiceName, "invalid target language: %v", err)
+		}
+		if !slices.Contains(languages, tag) {
+			languages = append(languages, tag)
+		}
+	}
+	if len(languages) == 0 {
+		return nil, merrors.BadRequest(service.PaperServiceName, "target language is required")
+	}
+	return languages, nil
 }
 
-func (t *PaperService) OCR(ctx context.Context, bucket, filePath string) (string, error) {
+// glossaryLanguage 创建论文时检查术语表并返回术语表的目标语言，避免到翻译阶段才失败
+func (t *PaperService) glossaryLanguage(ctx context.Context, id string) (string, error) {
+	if id == "" {
+		return "", nil
+	}
+	glossary, err := t.translateService.FetchGlossary(ctx, &ts.GlossaryID{Id: id})
+	if err != nil {
+		if merrors.FromError(err).Code == http.StatusNotFound {
+			return "", merrors.BadRequest(service.PaperServiceName, "glossary %s not found", id)
+		}
+		return "", err
+	}
+	return glossary.TargetLanguage, nil
+}
+
+// SubmitOCR 提交论文的OCR任务，返回OCR服务的任务ID。skipCache 为 true 时忽略已缓存的识别结果
+func (t *PaperService) SubmitOCR(ctx context.Context, paper *Paper, skipCache bool) (string, error) {
+	fileInfo, err := t.fileService.Query(ctx, &fs.QueryFile{Hash: paper.FileHash})
+	if err != nil {
+		return "", err
+	}
+
 	ocrID, err := t.ocrService.OCR(
 		ctx,
 		&os.OCRParam{
-			Bucket:    bucket,
-			ObjectKey: filePath,
+			Bucket:    *fileInfo.Bucket,
+			ObjectKey: *fileInfo.FilePath,
+			FileHash:  paper.FileHash,
+			SkipCache: skipCache,
 		},
 		client.WithDialTimeout(time.Second*300),
 		client.WithRequestTimeout(time.Second*300),
@@ -83,26 +202,66 @@ func (t *PaperService) OCR(ctx context.Context, bucket, filePath string) (string
 		log.Printf("do ocr err: %+v", err)
 		return "", err
 	}
+	return ocrID.TaskId, nil
+}
 
-	for {
-		status, err := t.ocrService.GetStatus(ctx, ocrID)
-		if err != nil {
-			return "", err
-		}
-		if status.Finished {
-			if status.Text == "" {
-				return "", errors.New("ocr failed")
-			}
-			return status.Text, nil
+// WaitOCR 通过 WatchStatus 流等待OCR任务结束，并把每页的进度转发为论文事件，返回识别结果ID
+func (t *PaperService) WaitOCR(ctx context.Context, id, taskID string) (string, error) {
+	var ocrID string
+	var failedPages []int32
+	progress, err := watchTask(ctx, func() (progressStream[*os.OCRProgress], error) {
+		return t.ocrService.WatchStatus(ctx, &os.OCRTaskID{TaskId: taskID})
+	}, func(p *os.OCRProgress) *TaskProgress {
+		if p.Finished {
+			ocrID, failedPages = p.OcrId, p.FailedPages
 		}
-		time.Sleep(time.Second)
+		return &TaskProgress{Finished: p.Finished, Text: p.Text, Index: p.Page, Done: p.PagesDone, Total: p.PagesTotal, Error: p.Error}
+	}, func(p *TaskProgress) {
+		t.publish(event.TopicPaperOCRProgress, &event.TaskEvent{TaskID: id, Index: p.Index, Done: p.Done, Total: p.Total})
+	})
+	if err != nil {
+		return "", err
+	}
+	_, err = progress.Result("ocr failed")
+	if err != nil {
+		return "", err
+	}
+	if ocrID == "" {
+		return "", fmt.Errorf("ocr task %s finished without result id", taskID)
 	}
+	// 部分页面失败时仍然翻译其余页面，失败的页码记录在论文上，详细原因可以通过 OCR 服务的 GetPages 查看
+	err = t.repo.SetFailedPages(id, failedPages)
+	if err != nil {
+		return "", err
+	}
+	return ocrID, nil
 }
 
-func (t *PaperService) Translate(ctx context.Context, text, targetLanguage string) (string, error) {
+// OCRText 按识别结果ID从OCR服务读取识别文本
+func (t *PaperService) OCRText(ctx context.Context, ocrID string) (string, error) {
+	result, err := t.ocrService.GetResult(ctx, &os.OCRTaskID{TaskId: ocrID})
+	if err != nil {
+		return "", err
+	}
+	if result.Text == "" {
+		return "", fmt.Errorf("ocr result %s: %w", ocrID, ErrEmptyResult)
+	}
+	return result.Text, nil
+}
+
+// SubmitTranslation 提交翻译任务，返回翻译服务的任务ID
+func (t *PaperService) SubmitTranslation(ctx context.Context, paper *Paper, text string) (string, error) {
 	translateID, err := t.translateService.Translate(
 		ctx,
-		&ts.Translation{Text: text, TargetLanguage: targetLanguage},
+		&ts.Translation{
+			Text:           text,
+			SourceLanguage: paper.SourceLanguage,
+			TargetLanguage: paper.TargetLanguage,
+			GlossaryId:     paper.GlossaryID,
+			Title:          paper.Title,
+			PromptName:     paper.PromptName,
+			PromptVersion:  paper.PromptVersion,
+		},
 		client.WithDialTimeout(time.Second*300),
 		client.WithRequestTimeout(time.Second*300),
 	)
@@ -110,68 +269,391 @@ func (t *PaperService) Translate(ctx context.Context, text, targetLanguage strin
 		log.Printf("do translate text err: %+v", err)
 		return "", err
 	}
+	return translateID.TaskId, nil
+}
+
+// WaitTranslation 通过 WatchStatus 流等待翻译任务结束，并把每段的译文转发为论文事件
+func (t *PaperService) WaitTranslation(ctx context.Context, id, taskID string) (string, error) {
+	progress, err := watchTask(ctx, func() (progressStream[*ts.TranslationProgress], error) {
+		return t.translateService.WatchStatus(ctx, &ts.TranslationID{TaskId: taskID})
+	}, func(p *ts.TranslationProgress) *TaskProgress {
+		return &TaskProgress{Finished: p.Finished || p.Failed, Text: p.Text, Index: p.Segment, Done: p.SegmentsDone, Total: p.SegmentsTotal, Error: p.Error}
+	}, func(p *TaskProgress) {
+		t.publish(event.TopicPaperTranslationProgress, &event.TaskEvent{TaskID: id, Index: p.Index, Done: p.Done, Total: p.Total, Text: p.Text})
+	})
+	if err != nil {
+		return "", err
+	}
+	return progress.Result("translate failed")
+}
+
+// CancelTask 取消阶段在下游服务中的任务，失败只记录日志
+func (t *PaperService) CancelTask(stage, taskID string) {
+	if taskID == "" {
+		return
+	}
+	var err error
+	switch stage {
+	case StageOCR:
+		_, err = t.ocrService.Cancel(context.TODO(), &os.OCRTaskID{TaskId: taskID})
+	case StageTranslation:
+		_, err = t.translateService.Cancel(context.TODO(), &ts.TranslationID{TaskId: taskID})
+	}
+	if err != nil {
+		log.Printf("cancel %s task %s err: %+v", stage, taskID, err)
+	}
+}
+
+// SetStatus 更新论文状态并发布阶段事件
+func (t *PaperService) SetStatus(id string, status v1.Paper_Status) error {
+	err := t.repo.SetStatus(id, int32(status))
+	if err != nil {
+		return err
+	}
+	t.publish(event.TopicPaperStage, &event.TaskEvent{TaskID: id, Status: int32(status)})
+	return nil
+}
+
+// publish 发布论文处理事件，发布失败只影响进度推送，不影响流水线
+func (t *PaperService) publish(topic string, e *event.TaskEvent) {
+	err := event.Publish(t.broker, topic, e)
+	if err != nil {
+		log.Printf("publish %s event err: %+v", topic, err)
+	}
+}
+
+// Watch 推送论文处理进度
+// 先推送当前状态，之后推送阶段变化、OCR每页进度、翻译每段译文，论文处理结束时推送结果并关闭
+func (t *PaperService) Watch(ctx context.Context, id *v1.PaperID, stream v1.PaperService_WatchStream) error {
+	defer stream.Close()
+
+	// 先开始等待再读取状态，避免错过两者之间的事件
+	events, stop := t.hub.Watch(id.Id)
+	defer stop()
+
+	paper, err := t.repo.Get(id.Id)
+	if err != nil {
+		return err
+	}
+	ended, err := t.sendStatus(stream, paper)
+	if err != nil || ended {
+		return err
+	}
 
+	// 定期重新推送当前状态，既能发现客户端断开，也能兜底丢失的事件
+	ticker := time.NewTicker(watchResendInterval)
+	defer ticker.Stop()
 	for {
-		status, err := t.translateService.GetStatus(ctx, translateID)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e := <-events:
+			var resp *v1.PaperEvent
+			switch e.Topic {
+			case event.TopicPaperStage:
+				resp = &v1.PaperEvent{Type: v1.PaperEvent_stage, Status: v1.Paper_Status(e.Status)}
+			case event.TopicPaperOCRProgress:
+				resp = &v1.PaperEvent{Type: v1.PaperEvent_ocr_progress, Status: v1.Paper_ocr, Index: e.Index, Done: e.Done, Total: e.Total}
+			case event.TopicPaperTranslationProgress:
+				resp = &v1.PaperEvent{Type: v1.PaperEvent_translation_progress, Status: v1.Paper_translation, Index: e.Index, Done: e.Done, Total: e.Total, Text: e.Text}
+			}
+			if resp != nil {
+				err = stream.Send(resp)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+		case <-ticker.C:
+		}
+
+		paper, err = t.repo.Get(id.Id)
 		if err != nil {
-			return "", err
+			return err
+		}
+		ended, err = t.sendStatus(stream, paper)
+		if err != nil || ended {
+			return err
 		}
+	}
+}
 
-		if status.Finished {
-			if status.Text == "" {
-				return "", errors.New("translate failed")
-			}
-			return status.Text, nil
+// sendStatus 推送论文当前状态，论文处理结束时推送结果并返回 true
+func (t *PaperService) sendStatus(stream v1.PaperService_WatchStream, paper *Paper) (bool, error) {
+	status := v1.Paper_Status(paper.Status)
+	resp := &v1.PaperEvent{Type: v1.PaperEvent_result, Status: status, FailedPages: paper.FailedPages}
+	switch status {
+	case v1.Paper_finished:
+		resp.Text = paper.ResultText
+	case v1.Paper_failed:
+		if paper.Failure != nil {
+			resp.Error = paper.Failure.Message
 		}
-		time.Sleep(time.Second)
+	case v1.Paper_cancelled:
+	default:
+		resp.Type = v1.PaperEvent_stage
+		return false, stream.Send(resp)
 	}
+	return true, stream.Send(resp)
 }
 
-func (t *PaperService) StartPipeline(ctx context.Context, id, bucket, filePath, targetLanguage, emailTo string) (err error) {
+// Notify 把翻译结果发送到论文的邮箱
+func (t *PaperService) Notify(ctx context.Context, paper *Paper) error {
+	if paper.EmailTo == "" {
+		return nil
+	}
+	subject := "你的paper翻译完成"
+	if paper.ParentID != "" {
+		// 多目标语言的论文每种语言单独发送一封邮件
+		subject += "（" + paper.TargetLanguage + "）"
+	}
+	_, err := t.emailService.SendEmail(ctx, &es.SendEmailParam{
+		EmailTo:  paper.EmailTo,
+		Subject:  subject,
+		Template: "{{.Text}}",
+		Vars: map[string]string{
+			"Text": paper.ResultText,
+		},
+	})
+	return err
+}
+
+// Fetch 获取单篇论文，多目标语言的论文同时返回每种语言的子论文
+func (t *PaperService) Fetch(ctx context.Context, id *v1.PaperID, resp *v1.Paper) error {
+	paper, err := t.repo.Get(id.Id)
+	if err != nil {
+		return err
+	}
+	t.ConvertPaper(paper, resp)
+	if len(paper.TargetLanguages) == 0 {
+		return nil
+	}
+	children, err := t.repo.GetChildren(paper.ID)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		c := &v1.Paper{}
+		t.ConvertPaper(child, c)
+		resp.Children = append(resp.Children, c)
+	}
+	return nil
+}
 
-	defer func() {
+// Delete 删除论文，多目标语言的论文一并删除子论文
+func (t *PaperService) Delete(ctx context.Context, id *v1.PaperID, re *v1.DeletePaper) error {
+	paper, err := t.repo.Get(id.Id)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+	children, err := t.repo.GetChildren(id.Id)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		err = t.deletePaper(child.ID)
 		if err != nil {
-			_ = t.repo.SetStatus(id, int32(v1.Paper_failed))
-		} else {
-			_ = t.repo.SetStatus(id, int32(v1.Paper_finished))
+			return err
 		}
-	}()
+	}
+	err = t.deletePaper(id.Id)
+	if err != nil {
+		return err
+	}
+	if paper != nil && paper.ParentID != "" {
+		return t.SyncParent(paper.ParentID)
+	}
+	return nil
+}
 
-	text, err := t.OCR(ctx, bucket, filePath)
+// deletePaper 删除论文和它的任务
+func (t *PaperService) deletePaper(id string) error {
+	err := t.repo.Delete(id)
 	if err != nil {
 		return err
 	}
+	// 论文还在处理时一并取消，不再占用下游服务
+	job, err := t.jobRepo.Transition(id, []string{JobPending, JobRunning}, map[string]any{"Status": JobCancelled, "LeaseOwner": ""})
+	if err == nil {
+		t.publish(event.TopicPaperCancelled, &event.TaskEvent{TaskID: id})
+		t.CancelTask(job.Stage, job.TaskID)
+	}
+	return t.jobRepo.Delete(id)
+}
 
-	_ = t.repo.SetStatus(id, int32(v1.Paper_translation))
-	translate, err := t.Translate(ctx, text, targetLanguage)
+// Retry 从失败或取消时所在的阶段重新处理论文，OCR阶段会复用已缓存的识别结果。
+// 多目标语言的论文OCR阶段已经完成时重试失败或取消的子论文
+func (t *PaperService) Retry(ctx context.Context, id *v1.PaperID, resp *v1.Paper) error {
+	paper, err := t.repo.Get(id.Id)
 	if err != nil {
 		return err
 	}
+	from := []string{JobFailed, JobCancelled}
+	err = t.restart(paper, from, map[string]any{})
+	if errors.Is(err, ErrJobState) && len(paper.TargetLanguages) > 0 {
+		err = t.restartChildren(paper.ID, func(child *Paper) error {
+			return t.restart(child, from, map[string]any{})
+		})
+	}
+	return t.restarted(id.Id, err, resp)
+}
 
-	if emailTo != "" {
-		_, _ = t.emailService.SendEmail(ctx, &es.SendEmailParam{
-			EmailTo:  emailTo,
-			Subject:  "你的paper翻译完成",
-			Template: "{{.Text}}",
-			Vars: map[string]string{
-				"Text": translate,
-			},
+// RerunStage 从指定阶段重新处理已经结束的论文，从OCR阶段开始时会重新识别。
+// 多目标语言的论文从翻译或通知阶段开始时由每篇子论文各自重新处理
+func (t *PaperService) RerunStage(ctx context.Context, req *v1.ReqRerunStage, resp *v1.Paper) error {
+	paper, err := t.repo.Get(req.Id)
+	if err != nil {
+		return err
+	}
+	if len(paper.TargetLanguages) > 0 && req.Stage != StageOCR {
+		err = t.restartChildren(paper.ID, func(child *Paper) error {
+			return t.rerunStage(child, req.Stage)
 		})
+	} else {
+		err = t.rerunStage(paper, req.Stage)
 	}
-	return t.repo.UpdateText(id, translate)
+	return t.restarted(req.Id, err, resp)
 }
 
-func (t *PaperService) Fetch(ctx context.Context, id *v1.PaperID, resp *v1.Paper) error {
-	paper, err := t.repo.Get(id.Id)
+// rerunStage 检查阶段需要的输入后从该阶段重新处理论文
+func (t *PaperService) rerunStage(paper *Paper, stage string) error {
+	job, err := t.jobRepo.Get(paper.ID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// 等待父论文OCR的子论文还没有任务
+		return ErrJobState
+	}
 	if err != nil {
 		return err
 	}
-	t.ConvertPaper(paper, resp)
+	set := map[string]any{"Stage": stage}
+	switch stage {
+	case StageOCR:
+		set["SkipOCRCache"] = true
+	case StageTranslation:
+		if job.OCRID == "" {
+			return merrors.BadRequest(service.PaperServiceName, "paper %s has no ocr text", paper.ID)
+		}
+	case StageNotify:
+		if paper.ResultText == "" {
+			return merrors.BadRequest(service.PaperServiceName, "paper %s has no translated text", paper.ID)
+		}
+	default:
+		return merrors.BadRequest(service.PaperServiceName, "unknown paper stage %q", stage)
+	}
+	return t.restart(paper, []string{JobFinished, JobFailed, JobCancelled}, set)
+}
+
+// restart 把处于 from 状态的任务重置为等待执行，set 为需要额外修改的字段，任务不处于 from 状态时返回 ErrJobState
+func (t *PaperService) restart(paper *Paper, from []string, set map[string]any) error {
+	set["Status"] = JobPending
+	set["Attempts"] = 0
+	set["LeaseOwner"] = ""
+	set["NextRunAt"] = time.Now()
+	set["TaskID"] = ""
+	set["LastError"] = ""
+	job, err := t.jobRepo.Transition(paper.ID, from, set)
+	if err != nil {
+		return err
+	}
+
+	err = t.repo.SetFailure(paper.ID, nil)
+	if err != nil {
+		return err
+	}
+	status := v1.Paper_translation
+	if job.Stage == StageOCR {
+		status = v1.Paper_ocr
+	}
+	err = t.SetStatus(paper.ID, status)
+	if err != nil {
+		return err
+	}
+	if len(paper.TargetLanguages) > 0 {
+		return t.resetChildren(paper.ID)
+	}
+	if paper.ParentID != "" {
+		return t.SyncParent(paper.ParentID)
+	}
 	return nil
 }
 
-func (t *PaperService) Delete(ctx context.Context, id *v1.PaperID, re *v1.DeletePaper) error {
-	return t.repo.Delete(id.Id)
+// restarted 把重新处理的结果转换为响应
+func (t *PaperService) restarted(id string, err error, resp *v1.Paper) error {
+	if errors.Is(err, ErrJobState) {
+		return merrors.Conflict(service.PaperServiceName, "paper %s can not be restarted", id)
+	}
+	if err != nil {
+		return err
+	}
+	return t.Fetch(context.TODO(), &v1.PaperID{Id: id}, resp)
+}
+
+// Cancel 取消等待或正在处理的论文，并取消下游正在执行的OCR或翻译任务。
+// 多目标语言的论文一并取消所有子论文
+func (t *PaperService) Cancel(ctx context.Context, id *v1.PaperID, resp *v1.Paper) error {
+	paper, err := t.repo.Get(id.Id)
+	if err != nil {
+		return err
+	}
+	cancelled, err := t.cancel(paper)
+	if err != nil {
+		return err
+	}
+	if len(paper.TargetLanguages) > 0 {
+		children, err := t.repo.GetChildren(paper.ID)
+		if err != nil {
+			return err
+		}
+		for _, child := range children {
+			ok, err := t.cancel(child)
+			if err != nil {
+				return err
+			}
+			cancelled = cancelled || ok
+		}
+		if cancelled {
+			err = t.SyncParent(paper.ID)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if !cancelled {
+		return merrors.Conflict(service.PaperServiceName, "paper %s can not be cancelled", id.Id)
+	}
+	if paper.ParentID != "" {
+		err = t.SyncParent(paper.ParentID)
+		if err != nil {
+			return err
+		}
+	}
+	return t.Fetch(ctx, id, resp)
+}
+
+// cancel 取消论文等待或正在执行的任务，论文不在处理中时返回 false
+func (t *PaperService) cancel(paper *Paper) (bool, error) {
+	job, err := t.jobRepo.Transition(paper.ID, []string{JobPending, JobRunning}, map[string]any{"Status": JobCancelled, "LeaseOwner": ""})
+	if errors.Is(err, ErrJobState) {
+		// 等待父论文OCR的子论文还没有任务，直接取消
+		if paper.ParentID == "" || v1.Paper_Status(paper.Status) != v1.Paper_ocr {
+			return false, nil
+		}
+		job, err = nil, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	err = t.SetStatus(paper.ID, v1.Paper_cancelled)
+	if err != nil {
+		return false, err
+	}
+	if job != nil {
+		// 执行任务的实例收到事件后中断当前阶段
+		t.publish(event.TopicPaperCancelled, &event.TaskEvent{TaskID: paper.ID})
+		t.CancelTask(job.Stage, job.TaskID)
+	}
+	return true, nil
 }
 
 func (t *PaperService) Fetchs(ctx context.Context, req *v1.ReqFetchs, resp *v1.RespFetchs) error {
@@ -183,11 +665,13 @@ func (t *PaperService) Fetchs(ctx context.Context, req *v1.ReqFetchs, resp *v1.R
 	resp.Papers = func() (res []*v1.Paper) {
 		for i := 0; i < len(papers); i++ {
 			res = append(res, &v1.Paper{
-				Id:             papers[i].ID,
-				FileHash:       papers[i].FileHash,
-				CreateAt:       papers[i].CreateAt.Unix(),
-				Status:         v1.Paper_Status(papers[i].Status),
-				TargetLanguage: papers[i].TargetLanguage,
+				Id:              papers[i].ID,
+				FileHash:        papers[i].FileHash,
+				CreateAt:        papers[i].CreateAt.Unix(),
+				Status:          v1.Paper_Status(papers[i].Status),
+				TargetLanguage:  papers[i].TargetLanguage,
+				Failure:         ConvertFailure(papers[i].Failure),
+				TargetLanguages: papers[i].TargetLanguages,
 			})
 		}
 		return res
@@ -197,9 +681,31 @@ func (t *PaperService) Fetchs(ctx context.Context, req *v1.ReqFetchs, resp *v1.R
 
 func (t *PaperService) ConvertPaper(paper *Paper, resp *v1.Paper) {
 	resp.Id = paper.ID
-	resp.Status = v1.Paper_ocr
+	resp.Status = v1.Paper_Status(paper.Status)
 	resp.FileHash = paper.FileHash
 	resp.CreateAt = paper.CreateAt.Unix()
 	resp.TargetLanguage = paper.TargetLanguage
 	resp.ResultText = paper.ResultText
+	resp.Failure = ConvertFailure(paper.Failure)
+	resp.GlossaryId = paper.GlossaryID
+	resp.Title = paper.Title
+	resp.PromptName = paper.PromptName
+	resp.PromptVersion = paper.PromptVersion
+	resp.TargetLanguages = paper.TargetLanguages
+	resp.SourceLanguage = paper.SourceLanguage
+	resp.ParentId = paper.ParentID
+	resp.FailedPages = paper.FailedPages
+}
+
+func ConvertFailure(failure *Failure) *v1.Failure {
+	if failure == nil {
+		return nil
+	}
+	return &v1.Failure{
+		Stage:    failure.Stage,
+		Code:     failure.Code,
+		Message:  failure.Message,
+		FailedAt: failure.FailedAt.Unix(),
+		Attempt:  failure.Attempt,
+	}
 }
@@ -0,0 +1,79 @@
+package paper
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	watchResendInterval = time.Second * 30 // Watch 没有新事件时重新推送当前状态的间隔
+	watchRetries        = 3                // 连续多少次订阅进度流都没有收到消息后放弃
+	watchRetryInterval  = time.Second * 5  // 进度流中断后重新订阅的间隔
+)
+
+// TaskProgress 下游OCR或翻译任务的进度
+type TaskProgress struct {
+	Finished bool   // 任务是否结束
+	Text     string // 结束时为结果，翻译进度中为本段译文
+	Index    int32  // 本次完成的页码或段序号，为0时表示当前状态
+	Done     int32  // 已完成的页数或段数
+	Total    int32  // 总页数或总段数
+	Error    string // 失败原因
+}
+
+// Result 返回结束时的结果，失败或结果为空时返回错误
+func (t *TaskProgress) Result(failed string) (string, error) {
+	if t.Error != "" {
+		return "", &TaskError{Message: t.Error}
+	}
+	if t.Text == "" {
+		return "", fmt.Errorf("%s: %w", failed, ErrEmptyResult)
+	}
+	return t.Text, nil
+}
+
+// progressStream 下游服务 WatchStatus 返回的进度流
+type progressStream[T any] interface {
+	Recv() (T, error)
+	Close() error
+}
+
+// watchTask 读取进度流直到任务结束，返回最后一条进度。
+// 流意外中断（例如下游实例重启）时重新订阅，连续 watchRetries 次没有收到任何消息则放弃，由流水线按失败重试当前阶段。
+func watchTask[T any](ctx context.Context, open func() (progressStream[T], error), convert func(T) *TaskProgress, onProgress func(*TaskProgress)) (*TaskProgress, error) {
+	var failures int
+	for {
+		stream, err := open()
+		if err == nil {
+			var msg T
+			for {
+				msg, err = stream.Recv()
+				if err != nil {
+					break
+				}
+				failures = 0
+				progress := convert(msg)
+				if progress.Finished {
+					_ = stream.Close()
+					return progress, nil
+				}
+				onProgress(progress)
+			}
+			_ = stream.Close()
+		}
+
+		failures++
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if failures >= watchRetries {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(watchRetryInterval):
+		}
+	}
+}
@@ -0,0 +1,368 @@
+package paper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	v1 "paper-translation/api/paper/service/v1"
+	"paper-translation/pkg/event"
+	"paper-translation/pkg/lang"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrPaperCancelled 论文被取消时作为正在执行的阶段的取消原因。
+var ErrPaperCancelled = errors.New("paper cancelled")
+
+// WorkerOptions 流水线执行器的参数
+type WorkerOptions struct {
+	Concurrency  int           // 同时执行的任务数
+	Lease        time.Duration // 租约时长，每三分之一租约时长心跳一次
+	MaxAttempts  int32         // 每个阶段的最大尝试次数
+	StageTimeout time.Duration // 单个阶段的超时时间，超时后计为一次失败
+	PollInterval time.Duration // 没有可领取的任务时的轮询间隔
+}
+
+// PipelineWorker 从 paper_jobs 中领取任务，并按 OCR -> 翻译 -> 通知 的顺序推进论文流水线。
+// 多目标语言的论文OCR完成后进入分发阶段，每篇子论文的任务从翻译阶段开始。
+// 每个阶段完成后都会把结果写回任务，实例重启后从未完成的阶段继续执行。
+type PipelineWorker struct {
+	service *PaperService
+	repo    PaperRepository
+	jobRepo JobRepository
+	options WorkerOptions
+	owner   string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewPipelineWorker(service *PaperService, repo PaperRepository, jobRepo JobRepository, options WorkerOptions) *PipelineWorker {
+	hostname, _ := os.Hostname()
+	ctx, cancel := context.WithCancel(context.Background())
+	return &PipelineWorker{
+		service: service,
+		repo:    repo,
+		jobRepo: jobRepo,
+		options: options,
+		owner:   fmt.Sprintf("%s-%s", hostname, uuid.NewString()[:8]),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Start 为遗留的未完成论文补建任务，然后启动执行协程。
+func (t *PipelineWorker) Start() error {
+	err := t.resume()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < t.options.Concurrency; i++ {
+		t.wg.Add(1)
+		go t.loop()
+	}
+	log.Printf("paper pipeline worker %s started", t.owner)
+	return nil
+}
+
+// Stop 中断正在执行的任务并释放租约，让其他实例可以立即接手。
+func (t *PipelineWorker) Stop() error {
+	t.cancel()
+	t.wg.Wait()
+	return nil
+}
+
+// resume 为没有任务记录的未完成论文补建任务，从OCR阶段重新驱动。
+// 这些论文来自引入任务表之前的版本，或者创建时任务写入失败。子论文的任务由父论文分发，这里跳过。
+func (t *PipelineWorker) resume() error {
+	papers, err := t.repo.GetByStatus(int32(v1.Paper_ocr), int32(v1.Paper_translation))
+	if err != nil {
+		return err
+	}
+	for _, paper := range papers {
+		if paper.ParentID != "" {
+			continue
+		}
+		_, err = t.jobRepo.Get(paper.ID)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			return err
+		}
+		err = t.jobRepo.Create(NewJob(paper.ID))
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+		log.Printf("re-drive paper %s", paper.ID)
+	}
+	return nil
+}
+
+func (t *PipelineWorker) loop() {
+	defer t.wg.Done()
+	for t.ctx.Err() == nil {
+		job, err := t.jobRepo.Claim(t.owner, t.options.Lease)
+		if err == nil {
+			t.run(job)
+			continue
+		}
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			log.Printf("claim paper job err: %+v", err)
+		}
+		select {
+		case <-t.ctx.Done():
+		case <-time.After(t.options.PollInterval):
+		}
+	}
+}
+
+// run 执行已领取的任务直到完成、失败或失去租约。
+func (t *PipelineWorker) run(job *Job) {
+	ctx, cancel := context.WithCancelCause(t.ctx)
+	defer cancel(nil)
+	go t.heartbeat(ctx, cancel, job.ID)
+
+	log.Printf("run paper job %s at stage %s, attempts %d", job.ID, job.Stage, job.Attempts)
+	for job.Status == JobRunning {
+		err := t.runStage(ctx, job)
+		if err == nil {
+			continue
+		}
+		switch {
+		case errors.Is(context.Cause(ctx), ErrPaperCancelled):
+			// 下游任务可能在取消前刚提交，任务ID还没有写回，这里再取消一次
+			log.Printf("paper job %s cancelled", job.ID)
+			t.service.CancelTask(job.Stage, job.TaskID)
+		case errors.Is(err, ErrLeaseLost) || errors.Is(context.Cause(ctx), ErrLeaseLost):
+			log.Printf("paper job %s lease lost", job.ID)
+		case t.ctx.Err() != nil:
+			// 实例退出，释放租约但不计入失败次数
+			job.Status, job.LeaseOwner, job.NextRunAt = JobPending, "", time.Now()
+			if err = t.save(job); err != nil {
+				log.Printf("release paper job %s err: %+v", job.ID, err)
+			}
+		default:
+			t.fail(job, err)
+		}
+		return
+	}
+}
+
+// runStage 执行任务的当前阶段，成功后把结果和下一阶段写回任务。
+func (t *PipelineWorker) runStage(ctx context.Context, job *Job) error {
+	ctx, cancel := context.WithTimeout(ctx, t.options.StageTimeout)
+	defer cancel()
+
+	paper, err := t.repo.Get(job.ID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// 论文已被删除
+		job.Status = JobFinished
+		return t.jobRepo.Delete(job.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	switch job.Stage {
+	case StageOCR:
+		err = t.service.SetStatus(job.ID, v1.Paper_ocr)
+		if err != nil {
+			return err
+		}
+		ocrID, err := t.waitTask(ctx, job, func() (string, error) {
+			return t.service.SubmitOCR(ctx, paper, job.SkipOCRCache)
+		}, t.service.WaitOCR)
+		if err != nil {
+			return err
+		}
+		if paper.SourceLanguage == "" {
+			// 没有指定原文语言时根据识别结果检测，无法检测时留空由大模型自行判断
+			text, err := t.service.OCRText(ctx, ocrID)
+			if err != nil {
+				return err
+			}
+			if language := lang.Detect(text); language != "" {
+				err = t.repo.SetSourceLanguage(job.ID, language)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		next := StageTranslation
+		if len(paper.TargetLanguages) > 0 {
+			next = StageFanOut
+		}
+		job.Stage, job.OCRID, job.TaskID, job.Attempts, job.SkipOCRCache = next, ocrID, "", 0, false
+
+	case StageFanOut:
+		// 先进入翻译阶段再分发，子论文结束后汇总的状态不会被覆盖
+		err = t.repo.SetFailure(job.ID, nil)
+		if err != nil {
+			return err
+		}
+		err = t.service.SetStatus(job.ID, v1.Paper_translation)
+		if err != nil {
+			return err
+		}
+		err = t.service.FanOut(paper, job.OCRID)
+		if err != nil {
+			return err
+		}
+		job.Status = JobFinished
+
+	case StageTranslation:
+		err = t.service.SetStatus(job.ID, v1.Paper_translation)
+		if err != nil {
+			return err
+		}
+		// 识别文本可能很大，不保存在任务中，每次翻译时从OCR服务读取
+		text, err := t.service.OCRText(ctx, job.OCRID)
+		if err != nil {
+			return err
+		}
+		if !lang.IsTarget(paper.SourceLanguage, paper.TargetLanguage) {
+			source := text
+			text, err = t.waitTask(ctx, job, func() (string, error) {
+				return t.service.SubmitTranslation(ctx, paper, source)
+			}, t.service.WaitTranslation)
+			if err != nil {
+				return err
+			}
+		} else {
+			// 原文已经是目标语言，识别结果直接作为译文
+			log.Printf("paper %s source language is %s, skip translation", job.ID, paper.SourceLanguage)
+		}
+		err = t.repo.UpdateText(job.ID, text)
+		if err != nil {
+			return err
+		}
+		job.Stage, job.TaskID, job.Attempts = StageNotify, "", 0
+
+	case StageNotify:
+		err = t.service.Notify(ctx, paper)
+		if err != nil {
+			log.Printf("send paper %s email err: %+v", job.ID, err)
+		}
+		err = t.repo.SetFailure(job.ID, nil)
+		if err != nil {
+			return err
+		}
+		err = t.service.SetStatus(job.ID, v1.Paper_finished)
+		if err != nil {
+			return err
+		}
+		t.service.publish(event.TopicPaperFinished, &event.TaskEvent{TaskID: job.ID})
+		if paper.ParentID != "" {
+			if err = t.service.SyncParent(paper.ParentID); err != nil {
+				log.Printf("sync paper %s parent err: %+v", job.ID, err)
+			}
+		}
+		job.Status = JobFinished
+
+	default:
+		return fmt.Errorf("unknown paper job stage %q", job.Stage)
+	}
+	return t.save(job)
+}
+
+// waitTask 提交下游任务并等待结果。任务ID会先写回任务表，实例重启后直接等待同一个下游任务。
+func (t *PipelineWorker) waitTask(ctx context.Context, job *Job, submit func() (string, error), wait func(ctx context.Context, id, taskID string) (string, error)) (string, error) {
+	if job.TaskID == "" {
+		taskID, err := submit()
+		if err != nil {
+			return "", err
+		}
+		job.TaskID = taskID
+		err = t.save(job)
+		if err != nil {
+			return "", err
+		}
+	}
+	return wait(ctx, job.ID, job.TaskID)
+}
+
+// fail 记录一次失败，未超过最大尝试次数时退避后重试当前阶段，否则将论文标记为失败。
+func (t *PipelineWorker) fail(job *Job, cause error) {
+	log.Printf("paper job %s stage %s failed err: %+v", job.ID, job.Stage, cause)
+
+	job.Attempts++
+	job.LastError = cause.Error()
+	if err := t.repo.SetFailure(job.ID, NewFailure(job.Stage, job.Attempts, cause)); err != nil {
+		log.Printf("save paper %s failure err: %+v", job.ID, err)
+	}
+	job.TaskID = "" // 下游任务可能已经丢失，重试时重新提交
+	job.LeaseOwner = ""
+	if job.Attempts < t.options.MaxAttempts {
+		job.Status = JobPending
+		job.NextRunAt = time.Now().Add(time.Duration(1<<job.Attempts) * 5 * time.Second)
+	} else {
+		job.Status = JobFailed
+	}
+	if err := t.save(job); err != nil {
+		log.Printf("save paper job %s err: %+v", job.ID, err)
+	}
+	if job.Status == JobFailed {
+		// 失败记录已经保存，Watch 收到事件后从论文中读取
+		if err := t.service.SetStatus(job.ID, v1.Paper_failed); err != nil {
+			log.Printf("set paper %s failed status err: %+v", job.ID, err)
+		}
+		t.service.publish(event.TopicPaperFailed, &event.TaskEvent{TaskID: job.ID, Error: job.LastError})
+		if err := t.service.SyncFailed(job.ID); err != nil {
+			log.Printf("sync paper %s failure err: %+v", job.ID, err)
+		}
+	}
+}
+
+// save 把任务的可变字段写回，要求当前实例仍持有租约。
+func (t *PipelineWorker) save(job *Job) error {
+	return t.jobRepo.Update(job.ID, t.owner, map[string]any{
+		"Stage":        job.Stage,
+		"Status":       job.Status,
+		"Attempts":     job.Attempts,
+		"LeaseOwner":   job.LeaseOwner,
+		"NextRunAt":    job.NextRunAt,
+		"TaskID":       job.TaskID,
+		"OCRID":        job.OCRID,
+		"LastError":    job.LastError,
+		"SkipOCRCache": job.SkipOCRCache,
+	})
+}
+
+// heartbeat 定期续约，论文被取消或租约丢失时取消正在执行的阶段。
+func (t *PipelineWorker) heartbeat(ctx context.Context, cancel context.CancelCauseFunc, id string) {
+	events, stop := t.service.hub.Watch(id)
+	defer stop()
+	ticker := time.NewTicker(t.options.Lease / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-events:
+			if e.Topic == event.TopicPaperCancelled {
+				cancel(ErrPaperCancelled)
+				return
+			}
+		case <-ticker.C:
+			err := t.jobRepo.Heartbeat(id, t.owner, t.options.Lease)
+			if errors.Is(err, ErrLeaseLost) {
+				// 取消事件可能丢失，续约失败时再确认一次任务是否已被取消
+				if job, _ := t.jobRepo.Get(id); job != nil && job.Status == JobCancelled {
+					err = ErrPaperCancelled
+				}
+				cancel(err)
+				return
+			}
+			if err != nil {
+				log.Printf("heartbeat paper job %s err: %+v", id, err)
+			}
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	v1 "paper-translation/api/paper/service/v1"
 	"paper-translation/app/paper/service/paper"
 	"paper-translation/pkg/ds"
+	"paper-translation/pkg/event"
 	"paper-translation/pkg/service"
 
 	"github.com/google/wire"
@@ -19,11 +20,15 @@ func InitApp() micro.Service {
 		ds.NewMongoClient,
 		ds.NewMongoDatabase,
 		paper.NewMongoPaperRepository, wire.Bind(new(paper.PaperRepository), new(*paper.MongoPaperRepository)),
+		paper.NewMongoJobRepository, wire.Bind(new(paper.JobRepository), new(*paper.MongoJobRepository)),
 		NewFileService,
 		NewOCRService,
 		NewTranslationService,
 		NewEmailService,
+		event.NewBroker,
 		paper.NewPaperService, wire.Bind(new(v1.PaperServiceHandler), new(*paper.PaperService)),
+		NewWorkerOptions,
+		paper.NewPipelineWorker,
 		NewService,
 	))
 }
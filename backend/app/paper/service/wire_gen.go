@@ -10,6 +10,7 @@ import (
 	"go-micro.dev/v4"
 	"paper-translation/app/paper/service/paper"
 	"paper-translation/pkg/ds"
+	"paper-translation/pkg/event"
 	"paper-translation/pkg/service"
 )
 
@@ -21,11 +22,15 @@ func InitApp() micro.Service {
 	client := ds.NewMongoClient(config)
 	database := ds.NewMongoDatabase(config, client)
 	mongoPaperRepository := paper.NewMongoPaperRepository(database)
+	mongoJobRepository := paper.NewMongoJobRepository(database)
 	fileService := NewFileService(registry)
 	ocrService := NewOCRService(registry)
 	translationService := NewTranslationService(registry)
 	emailService := NewEmailService(registry)
-	paperService := paper.NewPaperService(mongoPaperRepository, fileService, ocrService, translationService, emailService)
-	microService := NewService(registry, config, paperService)
+	broker := event.NewBroker(config)
+	paperService := paper.NewPaperService(mongoPaperRepository, mongoJobRepository, fileService, ocrService, translationService, emailService, broker)
+	workerOptions := NewWorkerOptions(config)
+	pipelineWorker := paper.NewPipelineWorker(paperService, mongoPaperRepository, mongoJobRepository, workerOptions)
+	microService := NewService(registry, config, paperService, pipelineWorker)
 	return microService
 }
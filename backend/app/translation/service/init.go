@@ -4,9 +4,13 @@ import (
 	"go-micro.dev/v4"
 	"go-micro.dev/v4/config"
 	"go-micro.dev/v4/registry"
+	"log"
 	v1 "paper-translation/api/translation/service/v1"
+	"paper-translation/app/translation/service/translation"
 	"paper-translation/pkg/errutil"
+	"paper-translation/pkg/llm"
 	"paper-translation/pkg/service"
+	"time"
 )
 
 func NewService(registry registry.Registry, config config.Config, handler v1.TranslationServiceHandler) micro.Service {
@@ -20,3 +24,40 @@ func NewService(registry registry.Registry, config config.Config, handler v1.Tra
 	svc.Init()
 	return svc
 }
+
+// NewTranslationOptions 从配置中读取翻译任务的执行参数，参数无效时启动失败
+func NewTranslationOptions(config config.Config) translation.Options {
+	options := translation.Options{
+		Parallelism:     config.Get("translation", "parallelism").Int(4),
+		MaxAttempts:     config.Get("translation", "max_attempts").Int(3),
+		RetryInterval:   config.Get("translation", "retry_interval").Duration(time.Second * 2),
+		AcquireTimeout:  config.Get("translation", "acquire_timeout").Duration(time.Second * 60),
+		SegmentTokens:   config.Get("translation", "segment_tokens").Int(2000),
+		MemoryCacheTTL:  config.Get("translation", "memory", "cache_ttl").Duration(time.Hour * 24),
+		PromptName:      config.Get("translation", "prompt", "name").String(translation.DefaultPromptName),
+		PromptVersion:   config.Get("translation", "prompt", "version").String("v2"),
+		ContextSegments: config.Get("translation", "context", "segments").Int(1),
+		ContextTokens:   config.Get("translation", "context", "tokens").Int(512),
+	}
+	errutil.PanicIfErr(options.Validate())
+	return options
+}
+
+// NewPromptTemplates 返回内置模板和配置文件 translation.prompts 中的模板，模板有误或者在模型的上下文窗口中放不下原文时启动失败
+func NewPromptTemplates(config config.Config, chatProvider llm.ChatProvider, options translation.Options) []*translation.PromptTemplate {
+	var templates []*translation.PromptTemplate
+	err := config.Get("translation", "prompts").Scan(&templates)
+	errutil.PanicIfErr(err)
+	templates = append(translation.BuiltinPrompts(), templates...)
+	for _, tmpl := range templates {
+		p, err := tmpl.Compile()
+		errutil.PanicIfErr(err)
+		_, contextTokens, err := translation.Budget(chatProvider, options.SegmentTokens, options.ContextTokens, p)
+		errutil.PanicIfErr(err)
+		if options.ContextSegments > 0 && contextTokens < options.ContextTokens {
+			log.Printf("prompt %s/%s leaves %d tokens for context instead of %d", p.Name, p.Version, contextTokens, options.ContextTokens)
+		}
+		tmpl.Builtin = true
+	}
+	return templates
+}
@@ -0,0 +1,67 @@
+package translation_test
+
+import (
+	"context"
+	"paper-translation/app/translation/service/translation"
+	"paper-translation/pkg/llm"
+	"paper-translation/pkg/segment"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/**
+ * TestContextTail 测试上一段超出 ContextTokens 时，只把原文和译文末尾放得下的句子作为历史发给模型。
+ */
+func TestContextTail(t *testing.T) {
+	provider := &fakeProvider{replies: []string{"第一句很短。第二句比第一句长一些，包含更多的内容。第三句结束。", "第四句。"}}
+	repo := &memoryRepo{memories: map[translation.MemoryKey]*translation.Memory{}}
+	svc := newServiceWith(t, provider, repo, translation.Options{
+		Parallelism:     1,
+		MaxAttempts:     1,
+		AcquireTimeout:  time.Second,
+		ContextSegments: 1,
+	})
+	task := newTask(t)
+	task.ContextTokens = 20
+
+	text := "The first sentence is short. The second sentence is a little longer and carries more content. The third one ends.\n\nThe fourth sentence."
+	segments := segment.NewSegmenter(24, provider.Tokenizer().Count).Split(text)
+	assert.Len(t, segments, 2)
+	err := svc.StartPipeline(context.Background(), task, segments)
+	assert.Nil(t, err)
+
+	assert.Len(t, provider.histories, 2)
+	assert.Empty(t, provider.histories[0])
+	assert.Equal(t, []llm.Message{
+		{Role: llm.RoleUser, Content: "The third one ends."},
+		{Role: llm.RoleAssistant, Content: "第三句结束。"},
+	}, provider.histories[1])
+}
+
+/**
+ * TestContextFallback 测试上一段的最后一句也放不进 ContextTokens 时不带历史，改用上一段原文的最后一句作为上文。
+ */
+func TestContextFallback(t *testing.T) {
+	provider := &fakeProvider{replies: []string{"一个很长的句子，没有办法放进很小的上下文里面。", "第二段。"}}
+	repo := &memoryRepo{memories: map[translation.MemoryKey]*translation.Memory{}}
+	svc := newServiceWith(t, provider, repo, translation.Options{
+		Parallelism:     1,
+		MaxAttempts:     1,
+		AcquireTimeout:  time.Second,
+		ContextSegments: 1,
+	})
+	task := newTask(t)
+	task.ContextTokens = 4
+
+	text := "A single long sentence that cannot fit into a tiny context.\n\nThe second paragraph."
+	segments := segment.NewSegmenter(15, provider.Tokenizer().Count).Split(text)
+	assert.Len(t, segments, 2)
+	err := svc.StartPipeline(context.Background(), task, segments)
+	assert.Nil(t, err)
+
+	assert.Len(t, provider.histories, 2)
+	assert.Empty(t, provider.histories[1])
+	assert.Contains(t, provider.prompts[1], "A single long sentence that cannot fit into a tiny context.")
+}
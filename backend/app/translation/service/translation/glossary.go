@@ -0,0 +1,24 @@
+package translation
+
+import (
+	"paper-translation/pkg/glossary"
+	"time"
+)
+
+// Glossary 术语表，按原文语言和目标语言区分，可以属于某个用户
+type Glossary struct {
+	ID             string          `bson:"ID"`
+	Name           string          `bson:"Name"`
+	SourceLanguage string          `bson:"SourceLanguage"` // 原文语言
+	TargetLanguage string          `bson:"TargetLanguage"` // 目标语言
+	Owner          string          `bson:"Owner"`          // 所属用户，为空时所有用户可用
+	Terms          []glossary.Term `bson:"Terms"`
+	CreateAt       time.Time       `bson:"CreateAt"`
+	UpdateAt       time.Time       `bson:"UpdateAt"`
+}
+
+// GlossaryViolation 译文没有使用规定译法的段
+type GlossaryViolation struct {
+	Segment      int32    // 段序号，从1开始
+	MissingTerms []string // 缺少规定译法的原文术语
+}
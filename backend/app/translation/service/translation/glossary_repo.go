@@ -0,0 +1,102 @@
+package translation
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type GlossaryRepository interface {
+	Create(glossary *Glossary) error
+	Get(id string) (*Glossary, error)
+	Update(glossary *Glossary) error
+	Delete(id string) error
+	Find(sourceLanguage, targetLanguage, owner string) ([]*Glossary, error)
+}
+
+type MongoGlossaryRepository struct {
+	C *mongo.Collection
+}
+
+func NewMongoGlossaryRepository(db *mongo.Database) *MongoGlossaryRepository {
+	c := db.Collection("glossaries")
+	_, err := c.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "ID", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "SourceLanguage", Value: 1}, {Key: "TargetLanguage", Value: 1}, {Key: "Owner", Value: 1}}},
+	})
+	if err != nil {
+		log.Printf("create glossaries indexes err: %+v", err)
+	}
+	return &MongoGlossaryRepository{C: c}
+}
+
+func (t *MongoGlossaryRepository) Create(glossary *Glossary) error {
+	glossary.CreateAt = time.Now()
+	glossary.UpdateAt = glossary.CreateAt
+	_, err := t.C.InsertOne(context.TODO(), glossary)
+	return err
+}
+
+func (t *MongoGlossaryRepository) Get(id string) (g *Glossary, err error) {
+	return g, t.C.FindOne(context.TODO(), bson.M{"ID": id}).Decode(&g)
+}
+
+// Update 更新名称、语言和术语，术语表不存在时返回 mongo.ErrNoDocuments
+func (t *MongoGlossaryRepository) Update(glossary *Glossary) error {
+	glossary.UpdateAt = time.Now()
+	result, err := t.C.UpdateOne(context.TODO(), bson.M{"ID": glossary.ID}, bson.M{
+		"$set": bson.M{
+			"Name":           glossary.Name,
+			"SourceLanguage": glossary.SourceLanguage,
+			"TargetLanguage": glossary.TargetLanguage,
+			"Owner":          glossary.Owner,
+			"Terms":          glossary.Terms,
+			"UpdateAt":       glossary.UpdateAt,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Delete 删除术语表，术语表不存在时返回 mongo.ErrNoDocuments
+func (t *MongoGlossaryRepository) Delete(id string) error {
+	result, err := t.C.DeleteOne(context.TODO(), bson.M{"ID": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// Find 按语言查询术语表，不返回术语。为空的条件不作为条件，指定用户时同时返回所有用户可用的术语表
+func (t *MongoGlossaryRepository) Find(sourceLanguage, targetLanguage, owner string) ([]*Glossary, error) {
+	filter := bson.M{}
+	if sourceLanguage != "" {
+		filter["SourceLanguage"] = sourceLanguage
+	}
+	if targetLanguage != "" {
+		filter["TargetLanguage"] = targetLanguage
+	}
+	if owner != "" {
+		filter["Owner"] = bson.M{"$in": []string{owner, ""}}
+	}
+	cursor, err := t.C.Find(context.TODO(), filter, options.Find().
+		SetSort(bson.M{"UpdateAt": -1}).
+		SetProjection(bson.M{"Terms": 0}))
+	if err != nil {
+		return nil, err
+	}
+	var glossaries []*Glossary
+	return glossaries, cursor.All(context.TODO(), &glossaries)
+}
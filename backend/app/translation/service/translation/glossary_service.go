@@ -0,0 +1,147 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	v1 "paper-translation/api/translation/service/v1"
+	"paper-translation/pkg/glossary"
+	"paper-translation/pkg/lang"
+	"paper-translation/pkg/service"
+	"strings"
+
+	"github.com/google/uuid"
+	merrors "go-micro.dev/v4/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CreateGlossary 创建术语表
+func (t *TranslationService) CreateGlossary(ctx context.Context, req *v1.Glossary, resp *v1.Glossary) error {
+	g, err := convertGlossary(req)
+	if err != nil {
+		return err
+	}
+	g.ID = uuid.NewString()
+	err = t.glossaryRepo.Create(g)
+	if err != nil {
+		return err
+	}
+	ConvertGlossary(g, resp)
+	return nil
+}
+
+// UpdateGlossary 更新术语表
+func (t *TranslationService) UpdateGlossary(ctx context.Context, req *v1.Glossary, resp *v1.Glossary) error {
+	g, err := convertGlossary(req)
+	if err != nil {
+		return err
+	}
+	err = t.glossaryRepo.Update(g)
+	if err != nil {
+		return glossaryError(req.Id, err)
+	}
+	g, err = t.glossaryRepo.Get(req.Id)
+	if err != nil {
+		return glossaryError(req.Id, err)
+	}
+	ConvertGlossary(g, resp)
+	return nil
+}
+
+// DeleteGlossary 删除术语表，已经提交的翻译任务不受影响
+func (t *TranslationService) DeleteGlossary(ctx context.Context, req *v1.GlossaryID, resp *v1.GlossaryDeleted) error {
+	return glossaryError(req.Id, t.glossaryRepo.Delete(req.Id))
+}
+
+// FetchGlossary 获取术语表
+func (t *TranslationService) FetchGlossary(ctx context.Context, req *v1.GlossaryID, resp *v1.Glossary) error {
+	g, err := t.glossaryRepo.Get(req.Id)
+	if err != nil {
+		return glossaryError(req.Id, err)
+	}
+	ConvertGlossary(g, resp)
+	return nil
+}
+
+// FetchGlossaries 查询术语表
+func (t *TranslationService) FetchGlossaries(ctx context.Context, req *v1.GlossaryQuery, resp *v1.Glossaries) error {
+	var sourceLanguage, targetLanguage string
+	if req.SourceLanguage != "" {
+		sourceLanguage = languageTag(req.SourceLanguage)
+	}
+	if req.TargetLanguage != "" {
+		targetLanguage = languageTag(req.TargetLanguage)
+	}
+	glossaries, err := t.glossaryRepo.Find(sourceLanguage, targetLanguage, req.Owner)
+	if err != nil {
+		return err
+	}
+	for _, g := range glossaries {
+		var item v1.Glossary
+		ConvertGlossary(g, &item)
+		resp.Glossaries = append(resp.Glossaries, &item)
+	}
+	return nil
+}
+
+// glossaryTerms 获取翻译任务使用的术语，术语表的目标语言必须和任务一致
+func (t *TranslationService) glossaryTerms(id, language string) ([]glossary.Term, error) {
+	if id == "" {
+		return nil, nil
+	}
+	g, err := t.glossaryRepo.Get(id)
+	if err != nil {
+		return nil, glossaryError(id, err)
+	}
+	if !lang.Match(g.TargetLanguage, language) {
+		return nil, merrors.BadRequest(service.TranslationServiceName, "glossary %s target language is %s, not %s", id, g.TargetLanguage, language)
+	}
+	return g.Terms, nil
+}
+
+// glossaryError 术语表不存在时返回 NotFound
+func glossaryError(id string, err error) error {
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return merrors.NotFound(service.TranslationServiceName, "glossary %s not found", id)
+	}
+	return err
+}
+
+// convertGlossary 校验并转换请求中的术语表，语言规范化为 BCP-47 标签，去掉术语首尾的空白
+func convertGlossary(req *v1.Glossary) (*Glossary, error) {
+	if req.TargetLanguage == "" {
+		return nil, merrors.BadRequest(service.TranslationServiceName, "glossary target language is empty")
+	}
+	g := &Glossary{ID: req.Id, Name: req.Name, Owner: req.Owner}
+	var err error
+	g.TargetLanguage, err = lang.Normalize(req.TargetLanguage)
+	if err != nil {
+		return nil, merrors.BadRequest(service.TranslationServiceName, "invalid glossary target language: %v", err)
+	}
+	if req.SourceLanguage != "" {
+		g.SourceLanguage, err = lang.Normalize(req.SourceLanguage)
+		if err != nil {
+			return nil, merrors.BadRequest(service.TranslationServiceName, "invalid glossary source language: %v", err)
+		}
+	}
+	for i, term := range req.Terms {
+		source, target := strings.TrimSpace(term.Source), strings.TrimSpace(term.Target)
+		if source == "" || target == "" {
+			return nil, merrors.BadRequest(service.TranslationServiceName, "glossary term %d is empty", i+1)
+		}
+		g.Terms = append(g.Terms, glossary.Term{Source: source, Target: target, CaseSensitive: term.CaseSensitive})
+	}
+	return g, nil
+}
+
+func ConvertGlossary(g *Glossary, resp *v1.Glossary) {
+	resp.Id = g.ID
+	resp.Name = g.Name
+	resp.SourceLanguage = g.SourceLanguage
+	resp.TargetLanguage = g.TargetLanguage
+	resp.Owner = g.Owner
+	resp.CreateAt = g.CreateAt.Unix()
+	resp.UpdateAt = g.UpdateAt.Unix()
+	for _, term := range g.Terms {
+		resp.Terms = append(resp.Terms, &v1.GlossaryTerm{Source: term.Source, Target: term.Target, CaseSensitive: term.CaseSensitive})
+	}
+}
@@ -0,0 +1,209 @@
+package translation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"paper-translation/pkg/glossary"
+	"paper-translation/pkg/segment"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// MemoryKey 翻译记忆的键，相同原文在相同目标语言、模型和提示词版本下复用译文
+type MemoryKey struct {
+	SourceHash     string `bson:"SourceHash"`     // 原文的 SHA-256，十六进制
+	TargetLanguage string `bson:"TargetLanguage"` // 目标语言
+	Provider       string `bson:"Provider"`       // 大模型提供方
+	PromptVersion  string `bson:"PromptVersion"`  // 提示词模板的名称和版本，如 default/v2
+}
+
+// String 返回键的字符串形式，用作缓存的键
+func (k MemoryKey) String() string {
+	return k.SourceHash + ":" + k.TargetLanguage + ":" + k.Provider + ":" + k.PromptVersion
+}
+
+// Memory 一条翻译记忆，保存一个段落或一个句子的原文和它的译文
+type Memory struct {
+	MemoryKey      `bson:",inline"`
+	SourceText     string    `bson:"SourceText"`     // 原文
+	TranslatedText string    `bson:"TranslatedText"` // 译文，不包含原文首尾的空白
+	CreateAt       time.Time `bson:"CreateAt"`
+	UpdateAt       time.Time `bson:"UpdateAt"`
+}
+
+func (t *Memory) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, t)
+}
+
+func (t Memory) MarshalBinary() (data []byte, err error) {
+	return json.Marshal(t)
+}
+
+// HashSource 计算原文的哈希。连续的空白按一个空格计算，OCR 文本中句子内部的换行不影响命中
+func HashSource(text string) string {
+	sum := sha256.Sum256([]byte(strings.Join(strings.Fields(text), " ")))
+	return hex.EncodeToString(sum[:])
+}
+
+// piece 一段原文中可以单独复用翻译记忆的段落或句子
+type piece struct {
+	source     string // 原文，包含首尾的空白
+	paragraph  int    // 所属段落的序号
+	sentence   bool   // 是段落中的一个句子，而不是整个段落
+	text       string // 译文，不包含原文首尾的空白
+	translated bool   // 命中了翻译记忆，或者已经翻译并且译文能对应到这一部分
+}
+
+// String 返回带有原文首尾空白的译文。
+// 句子之间只隔着空格时，前一句的译文以中日韩等全角字符结尾就去掉空格，避免中文译文中出现多余的空格
+func (p piece) String() string {
+	leading, _, trailing := splitSpace(p.source)
+	if p.sentence && strings.Trim(trailing, " ") == "" {
+		if r, _ := utf8.DecodeLastRuneInString(p.text); r >= 0x2e80 {
+			trailing = ""
+		}
+	}
+	return leading + p.text + trailing
+}
+
+// splitSpace 把文本拆成开头的空白、去掉首尾空白的文本和结尾的空白
+func splitSpace(s string) (string, string, string) {
+	text := strings.TrimLeftFunc(s, unicode.IsSpace)
+	trimmed := strings.TrimRightFunc(text, unicode.IsSpace)
+	return s[:len(s)-len(text)], trimmed, text[len(trimmed):]
+}
+
+// memoryKey 返回任务中一段原文的翻译记忆键
+func (t *TranslationService) memoryKey(task *Task, text string) MemoryKey {
+	return MemoryKey{SourceHash: HashSource(text), TargetLanguage: task.TargetLanguage, Provider: t.chatProvider.Name(), PromptVersion: task.Prompt.Key()}
+}
+
+// recall 把原文拆成段落逐段查询翻译记忆，段落没有命中时按句子查询。
+// 段落中没有任何句子命中时整个段落作为一部分，不把句子拆开翻译
+func (t *TranslationService) recall(task *Task, text string) []piece {
+	var pieces []piece
+	for i, paragraph := range segment.Paragraphs(text) {
+		p := t.recallPiece(task, paragraph, i, false)
+		if p.translated {
+			pieces = append(pieces, p)
+			continue
+		}
+		var parts []piece
+		var hits int
+		if s := sentences(paragraph); len(s) > 1 {
+			for _, sentence := range s {
+				sp := t.recallPiece(task, sentence, i, true)
+				if sp.translated {
+					hits++
+				}
+				parts = append(parts, sp)
+			}
+		}
+		if hits == 0 {
+			pieces = append(pieces, p)
+			continue
+		}
+		pieces = append(pieces, parts...)
+	}
+	return pieces
+}
+
+// sentences 把段落切分为句子，句子内部的换行（如 OCR 文本按行折断）不作为句子的结尾
+func sentences(paragraph string) []string {
+	flat := strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\r' {
+			return ' '
+		}
+		return r
+	}, paragraph)
+	var result []string
+	for _, sentence := range segment.Sentences(flat) {
+		result = append(result, paragraph[:len(sentence)])
+		paragraph = paragraph[len(sentence):]
+	}
+	return result
+}
+
+// recallPiece 查询一个段落或句子的翻译记忆，没有使用规定译法的翻译记忆不复用
+func (t *TranslationService) recallPiece(task *Task, source string, paragraph int, sentence bool) piece {
+	p := piece{source: source, paragraph: paragraph, sentence: sentence}
+	_, text, _ := splitSpace(source)
+	if text == "" {
+		p.translated = true
+		return p
+	}
+	memory, err := t.memoryRepo.Get(t.memoryKey(task, text))
+	if err != nil {
+		if !errors.Is(err, mongo.ErrNoDocuments) {
+			log.Printf("get translation memory err: %+v", err)
+		}
+		return p
+	}
+	if len(glossary.Missing(glossary.Match(task.Terms, text), memory.TranslatedText)) == 0 {
+		p.text, p.translated = memory.TranslatedText, true
+	}
+	return p
+}
+
+// remember 保存一次调用的译文。只翻译了一部分时直接保存；翻译了多个完整的段落、译文的段落数相同时逐段保存；
+// 其他情况无法把译文对应到每一部分，不保存
+func (t *TranslationService) remember(task *Task, run []piece, translated string) {
+	if len(run) == 1 {
+		run[0].text, run[0].translated = translated, true
+	} else {
+		paragraphs := segment.Paragraphs(translated)
+		if len(paragraphs) != len(run) {
+			return
+		}
+		for _, p := range run {
+			if p.sentence {
+				return
+			}
+		}
+		for i := range run {
+			run[i].text, run[i].translated = strings.TrimSpace(paragraphs[i]), true
+		}
+	}
+	for _, p := range run {
+		_, text, _ := splitSpace(p.source)
+		t.saveMemory(task, text, p.text)
+	}
+}
+
+// rememberParagraphs 把按句子拼出译文的段落整段保存，下次整段命中
+func (t *TranslationService) rememberParagraphs(task *Task, pieces []piece) {
+	for start := 0; start < len(pieces); {
+		end := start + 1
+		for end < len(pieces) && pieces[end].paragraph == pieces[start].paragraph {
+			end++
+		}
+		if pieces[start].sentence {
+			var source, text strings.Builder
+			complete := true
+			for _, p := range pieces[start:end] {
+				complete = complete && p.translated
+				source.WriteString(p.source)
+				text.WriteString(p.String())
+			}
+			if complete {
+				t.saveMemory(task, strings.TrimSpace(source.String()), strings.TrimSpace(text.String()))
+			}
+		}
+		start = end
+	}
+}
+
+// saveMemory 保存一条翻译记忆，失败只影响以后的命中率
+func (t *TranslationService) saveMemory(task *Task, source, translated string) {
+	err := t.memoryRepo.Save(&Memory{MemoryKey: t.memoryKey(task, source), SourceText: source, TranslatedText: translated})
+	if err != nil {
+		log.Printf("save translation memory err: %+v", err)
+	}
+}
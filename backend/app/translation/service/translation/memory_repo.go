@@ -0,0 +1,184 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// memoryCachePrefix 翻译记忆在 Redis 中的键前缀
+const memoryCachePrefix = "tm:"
+
+// MemoryRepository 翻译记忆的存储。查询条件使用 MemoryKey，为空的字段不作为条件。
+type MemoryRepository interface {
+	Get(key MemoryKey) (*Memory, error)
+	Save(memory *Memory) error
+	Find(query MemoryKey, offset, limit int64) ([]*Memory, int64, error)
+	Each(query MemoryKey, fc func(*Memory) error) error
+	Delete(query MemoryKey) (int64, error)
+}
+
+type MongoMemoryRepository struct {
+	C *mongo.Collection
+}
+
+func NewMongoMemoryRepository(db *mongo.Database) *MongoMemoryRepository {
+	c := db.Collection("translation_memories")
+	_, err := c.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "SourceHash", Value: 1},
+			{Key: "TargetLanguage", Value: 1},
+			{Key: "Provider", Value: 1},
+			{Key: "PromptVersion", Value: 1},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Printf("create translation_memories indexes err: %+v", err)
+	}
+	return &MongoMemoryRepository{C: c}
+}
+
+func (t *MongoMemoryRepository) Get(key MemoryKey) (m *Memory, err error) {
+	return m, t.C.FindOne(context.TODO(), memoryFilter(key)).Decode(&m)
+}
+
+// Save 保存翻译记忆，已存在时覆盖译文
+func (t *MongoMemoryRepository) Save(memory *Memory) error {
+	now := time.Now()
+	_, err := t.C.UpdateOne(context.TODO(), memoryFilter(memory.MemoryKey), bson.M{
+		"$set": bson.M{
+			"SourceText":     memory.SourceText,
+			"TranslatedText": memory.TranslatedText,
+			"UpdateAt":       now,
+		},
+		"$setOnInsert": bson.M{"CreateAt": now},
+	}, options.Update().SetUpsert(true))
+	return err
+}
+
+func (t *MongoMemoryRepository) Find(query MemoryKey, offset, limit int64) ([]*Memory, int64, error) {
+	filter := memoryFilter(query)
+	total, err := t.C.CountDocuments(context.TODO(), filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	cursor, err := t.C.Find(context.TODO(), filter, options.Find().
+		SetSort(bson.M{"UpdateAt": -1}).
+		SetSkip(offset).
+		SetLimit(limit))
+	if err != nil {
+		return nil, 0, err
+	}
+	var memories []*Memory
+	return memories, total, cursor.All(context.TODO(), &memories)
+}
+
+// Each 依次处理符合条件的翻译记忆，fc 返回错误时停止
+func (t *MongoMemoryRepository) Each(query MemoryKey, fc func(*Memory) error) error {
+	cursor, err := t.C.Find(context.TODO(), memoryFilter(query))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.TODO())
+	for cursor.Next(context.TODO()) {
+		var memory Memory
+		err = cursor.Decode(&memory)
+		if err != nil {
+			return err
+		}
+		err = fc(&memory)
+		if err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func (t *MongoMemoryRepository) Delete(query MemoryKey) (int64, error) {
+	result, err := t.C.DeleteMany(context.TODO(), memoryFilter(query))
+	if err != nil {
+		return 0, err
+	}
+	return result.DeletedCount, nil
+}
+
+// memoryFilter 把查询条件转换为 Mongo 的过滤条件，为空的字段不作为条件
+func memoryFilter(query MemoryKey) bson.M {
+	filter := bson.M{}
+	for field, value := range map[string]string{
+		"SourceHash":     query.SourceHash,
+		"TargetLanguage": query.TargetLanguage,
+		"Provider":       query.Provider,
+		"PromptVersion":  query.PromptVersion,
+	} {
+		if value != "" {
+			filter[field] = value
+		}
+	}
+	return filter
+}
+
+// CachedMemoryRepository 在 Mongo 前面加一层 Redis 缓存，热点原文不用每次查询 Mongo
+type CachedMemoryRepository struct {
+	*MongoMemoryRepository
+	redisClient *redis.Client
+	ttl         time.Duration
+}
+
+func NewCachedMemoryRepository(repo *MongoMemoryRepository, redisClient *redis.Client, options Options) *CachedMemoryRepository {
+	return &CachedMemoryRepository{MongoMemoryRepository: repo, redisClient: redisClient, ttl: options.MemoryCacheTTL}
+}
+
+// Get 先查询缓存，未命中时查询 Mongo 并回填缓存
+func (t *CachedMemoryRepository) Get(key MemoryKey) (*Memory, error) {
+	var memory Memory
+	err := t.redisClient.Get(context.TODO(), memoryCachePrefix+key.String()).Scan(&memory)
+	if err == nil {
+		return &memory, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		log.Printf("get translation memory cache err: %+v", err)
+	}
+
+	m, err := t.MongoMemoryRepository.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	t.cache(m)
+	return m, nil
+}
+
+func (t *CachedMemoryRepository) Save(memory *Memory) error {
+	err := t.MongoMemoryRepository.Save(memory)
+	if err != nil {
+		return err
+	}
+	t.cache(memory)
+	return nil
+}
+
+// Delete 先清除缓存再删除，避免删除后仍然从缓存中读到
+func (t *CachedMemoryRepository) Delete(query MemoryKey) (int64, error) {
+	err := t.Each(query, func(m *Memory) error {
+		return t.redisClient.Del(context.TODO(), memoryCachePrefix+m.MemoryKey.String()).Err()
+	})
+	if err != nil {
+		return 0, err
+	}
+	return t.MongoMemoryRepository.Delete(query)
+}
+
+// cache 写入缓存，失败只影响命中率
+func (t *CachedMemoryRepository) cache(memory *Memory) {
+	err := t.redisClient.Set(context.TODO(), memoryCachePrefix+memory.MemoryKey.String(), memory, t.ttl).Err()
+	if err != nil {
+		log.Printf("set translation memory cache err: %+v", err)
+	}
+}
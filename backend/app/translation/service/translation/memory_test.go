@@ -0,0 +1,236 @@
+package translation_test
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"io"
+	v1 "paper-translation/api/translation/service/v1"
+	"paper-translation/app/translation/service/translation"
+	"paper-translation/pkg/llm"
+	"paper-translation/pkg/segment"
+	"paper-translation/pkg/signal"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"go-micro.dev/v4/broker"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// fakeProvider 按 replies 的顺序回复，记录每次调用的最后一条消息和之前的历史
+type fakeProvider struct {
+	mu        sync.Mutex
+	replies   []string
+	prompts   []string
+	histories [][]llm.Message
+}
+
+func (p *fakeProvider) Name() string        { return "fake" }
+func (p *fakeProvider) MaxConcurrency() int { return 1 }
+func (p *fakeProvider) Tokenizer() llm.Tokenizer {
+	return llm.CJKTokenizer{CharsPerToken: 1, WordsPerToken: 1}
+}
+func (p *fakeProvider) ContextWindow() int { return 8192 }
+func (p *fakeProvider) MaxTokens() int     { return 2048 }
+func (p *fakeProvider) CreateChat(ctx context.Context, messages []llm.Message, fc func(text string)) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prompts = append(p.prompts, messages[len(messages)-1].Content)
+	p.histories = append(p.histories, messages[:len(messages)-1])
+	if len(p.replies) == 0 {
+		return errors.New("model unavailable")
+	}
+	fc(p.replies[0])
+	p.replies = p.replies[1:]
+	return nil
+}
+
+type fakeSemaphore struct{}
+
+func (fakeSemaphore) Acquire() (bool, error) { return true, nil }
+func (fakeSemaphore) Release() error         { return nil }
+func (fakeSemaphore) Reset() error           { return nil }
+
+type fakeSignalFactory struct{}
+
+func (fakeSignalFactory) Semaphore(name string, max int) signal.Semaphore { return fakeSemaphore{} }
+
+// memoryRepo 保存在内存中的翻译记忆
+type memoryRepo struct {
+	mu       sync.Mutex
+	memories map[translation.MemoryKey]*translation.Memory
+}
+
+func (r *memoryRepo) Get(key translation.MemoryKey) (*translation.Memory, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if m, ok := r.memories[key]; ok {
+		return m, nil
+	}
+	return nil, mongo.ErrNoDocuments
+}
+
+func (r *memoryRepo) Save(memory *translation.Memory) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.memories[memory.MemoryKey] = memory
+	return nil
+}
+
+func (r *memoryRepo) Find(query translation.MemoryKey, offset, limit int64) ([]*translation.Memory, int64, error) {
+	return nil, 0, nil
+}
+
+func (r *memoryRepo) Each(query translation.MemoryKey, fc func(*translation.Memory) error) error {
+	return nil
+}
+
+func (r *memoryRepo) Delete(query translation.MemoryKey) (int64, error) {
+	return 0, nil
+}
+
+// importStream 把 TMX 文件作为一个分片发送给 ImportTM
+type importStream struct {
+	v1.TranslationService_ImportTMStream
+	data []byte
+	sent bool
+}
+
+func (s *importStream) Recv() (*v1.TMXChunk, error) {
+	if s.sent {
+		return nil, io.EOF
+	}
+	s.sent = true
+	return &v1.TMXChunk{Data: s.data, Last: true}, nil
+}
+
+func (s *importStream) Send(*v1.TMImportProgress) error { return nil }
+func (s *importStream) Close() error                    { return nil }
+
+func newService(t *testing.T, provider llm.ChatProvider, repo translation.MemoryRepository) *translation.TranslationService {
+	return newServiceWith(t, provider, repo, translation.Options{
+		Parallelism:    1,
+		MaxAttempts:    1,
+		AcquireTimeout: time.Second,
+		PromptName:     translation.DefaultPromptName,
+		PromptVersion:  "v2",
+	})
+}
+
+// redisHook 在内存中执行 SET 和 GET，其他命令直接忽略，不连接 Redis
+type redisHook struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+func (h *redisHook) DialHook(next redis.DialHook) redis.DialHook { return next }
+
+func (h *redisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error { return nil }
+}
+
+func (h *redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		args := cmd.Args()
+		switch cmd.Name() {
+		case "set":
+			value, _ := args[2].(encoding.BinaryMarshaler).MarshalBinary()
+			h.values[args[1].(string)] = string(value)
+		case "get":
+			value, ok := h.values[args[1].(string)]
+			if !ok {
+				cmd.SetErr(redis.Nil)
+				return redis.Nil
+			}
+			cmd.(*redis.StringCmd).SetVal(value)
+		}
+		return nil
+	}
+}
+
+func newServiceWith(t *testing.T, provider llm.ChatProvider, repo translation.MemoryRepository, options translation.Options) *translation.TranslationService {
+	b := broker.NewMemoryBroker()
+	assert.Nil(t, b.Connect())
+	redisClient := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1"})
+	redisClient.AddHook(&redisHook{values: map[string]string{}})
+	return translation.NewTranslationService(provider, fakeSignalFactory{}, redisClient, b, repo, nil, nil, options)
+}
+
+// promptText 返回提示词中需要翻译的文字
+func promptText(prompt string) string {
+	_, text, _ := strings.Cut(prompt, "需要翻译的文字：\n")
+	return text
+}
+
+func newTask(t *testing.T) *translation.Task {
+	p, err := translation.BuiltinPrompts()[1].Compile()
+	assert.Nil(t, err)
+	return &translation.Task{ID: "task", TargetLanguage: "zh-Hans", Prompt: p}
+}
+
+/**
+ * TestImportedMemory 测试导入句级 TMX 后，包含多个句子的段只把没有命中的段落交给大模型，
+ * 按句子拼出的段落整段保存为翻译记忆。
+ */
+func TestImportedMemory(t *testing.T) {
+	provider := &fakeProvider{replies: []string{"我们还提出了一个新的层。"}}
+	repo := &memoryRepo{memories: map[translation.MemoryKey]*translation.Memory{}}
+	svc := newService(t, provider, repo)
+
+	tmx := `<?xml version="1.0" encoding="UTF-8"?>
+<tmx version="1.4"><header srclang="en" datatype="plaintext" segtype="sentence" adminlang="en" o-tmf="test" creationtool="test" creationtoolversion="1"/>
+<body>
+<tu><tuv xml:lang="en"><seg>The model uses attention.</seg></tuv><tuv xml:lang="zh-CN"><seg>模型使用注意力。</seg></tuv></tu>
+<tu><tuv xml:lang="en"><seg>It is fast.</seg></tuv><tuv xml:lang="zh-CN"><seg>它很快。</seg></tuv></tu>
+</body></tmx>`
+	err := svc.ImportTM(context.Background(), &importStream{data: []byte(tmx)})
+	assert.Nil(t, err)
+
+	task := newTask(t)
+	task.TargetLanguage = "zh-CN"
+	// OCR 文本中的句子可能按行折断
+	text := "The model uses\nattention. It is fast.\n\nWe also propose a new layer."
+	segments := segment.NewSegmenter(1000, provider.Tokenizer().Count).Split(text)
+	assert.Len(t, segments, 1)
+	err = svc.StartPipeline(context.Background(), task, segments)
+	assert.Nil(t, err)
+
+	assert.Len(t, provider.prompts, 1)
+	assert.Equal(t, "We also propose a new layer.", promptText(provider.prompts[0]))
+
+	key := translation.MemoryKey{SourceHash: translation.HashSource("The model uses attention. It is fast."), TargetLanguage: "zh-CN", Provider: "fake", PromptVersion: "default/v2"}
+	memory, err := repo.Get(key)
+	assert.Nil(t, err)
+	assert.Equal(t, "模型使用注意力。它很快。", memory.TranslatedText)
+
+	// 所有段落都已命中时不再调用大模型
+	err = svc.StartPipeline(context.Background(), task, segments)
+	assert.Nil(t, err)
+	assert.Len(t, provider.prompts, 1)
+}
+
+/**
+ * TestParagraphMemory 测试翻译记忆按段落保存，同一段落和其他文本合并成不同的段时仍然命中。
+ */
+func TestParagraphMemory(t *testing.T) {
+	provider := &fakeProvider{replies: []string{"第一段。\n\n第二段。", "第三段。"}}
+	repo := &memoryRepo{memories: map[translation.MemoryKey]*translation.Memory{}}
+	svc := newService(t, provider, repo)
+	task := newTask(t)
+	segmenter := segment.NewSegmenter(1000, provider.Tokenizer().Count)
+
+	err := svc.StartPipeline(context.Background(), task, segmenter.Split("First paragraph.\n\nSecond paragraph."))
+	assert.Nil(t, err)
+	assert.Len(t, provider.prompts, 1)
+
+	err = svc.StartPipeline(context.Background(), task, segmenter.Split("Second paragraph.\n\nThird paragraph."))
+	assert.Nil(t, err)
+	assert.Len(t, provider.prompts, 2)
+	assert.Equal(t, "Third paragraph.", promptText(provider.prompts[1]))
+}
@@ -0,0 +1,68 @@
+package translation_test
+
+import (
+	"paper-translation/app/translation/service/translation"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/**
+ * TestOptionsValidate 测试执行参数的检查，并发数为0等无效参数在启动时返回错误。
+ */
+func TestOptionsValidate(t *testing.T) {
+	valid := translation.Options{Parallelism: 4, MaxAttempts: 3, SegmentTokens: 2000, ContextSegments: 1, ContextTokens: 512}
+	assert.Nil(t, valid.Validate())
+
+	noContext := valid
+	noContext.ContextSegments, noContext.ContextTokens = 0, 0
+	assert.Nil(t, noContext.Validate())
+
+	for _, modify := range []func(o *translation.Options){
+		func(o *translation.Options) { o.Parallelism = 0 },
+		func(o *translation.Options) { o.Parallelism = -1 },
+		func(o *translation.Options) { o.MaxAttempts = 0 },
+		func(o *translation.Options) { o.SegmentTokens = 0 },
+		func(o *translation.Options) { o.ContextSegments = -1 },
+		func(o *translation.Options) { o.ContextTokens = -1 },
+	} {
+		options := valid
+		modify(&options)
+		assert.NotNil(t, options.Validate(), options)
+	}
+}
+
+// windowProvider 上下文窗口可以设置的模型
+type windowProvider struct {
+	fakeProvider
+	window int
+}
+
+func (p *windowProvider) ContextWindow() int { return p.window }
+
+/**
+ * TestBudget 测试按上下文窗口计算段的预算：窗口放不下配置的历史时减少历史，提示词占满窗口时返回错误。
+ */
+func TestBudget(t *testing.T) {
+	p, err := translation.BuiltinPrompts()[1].Compile()
+	assert.Nil(t, err)
+	prompt := (&fakeProvider{}).Tokenizer().Count(p.Text)
+
+	// 窗口足够大时使用配置的预算和历史
+	provider := &windowProvider{window: 8192}
+	budget, contextTokens, err := translation.Budget(provider, 1000, 512, p)
+	assert.Nil(t, err)
+	assert.Equal(t, 1000, budget)
+	assert.Equal(t, 512, contextTokens)
+
+	// 扣除回复和提示词后只剩 1000 个 token，历史减少到一半，另一半给原文
+	provider.window = 2048 + prompt + 32 + 1000
+	budget, contextTokens, err = translation.Budget(provider, 1000, 512, p)
+	assert.Nil(t, err)
+	assert.Equal(t, 500, budget)
+	assert.Equal(t, 500, contextTokens)
+
+	provider.window = 2048 + prompt
+	_, _, err = translation.Budget(provider, 1000, 512, p)
+	assert.ErrorIs(t, err, translation.ErrNoRoom)
+}
@@ -0,0 +1,83 @@
+package translation
+
+import (
+	"paper-translation/pkg/glossary"
+	"paper-translation/pkg/prompt"
+	"time"
+)
+
+// PromptTemplate 提示词模板，内置模板和配置文件中的模板不能通过接口修改
+type PromptTemplate struct {
+	Name     string    `bson:"Name" json:"name"`
+	Version  string    `bson:"Version" json:"version"`
+	Text     string    `bson:"Text" json:"text"`
+	CreateAt time.Time `bson:"CreateAt" json:"-"`
+	Builtin  bool      `bson:"-" json:"-"`
+}
+
+// DefaultPromptName 内置提示词模板的名称
+const DefaultPromptName = "default"
+
+// builtinPrompts 内置提示词模板，同一名称按版本从旧到新排列
+var builtinPrompts = []*PromptTemplate{
+	// v1 是引入模板之前硬编码的提示词
+	{Name: DefaultPromptName, Version: "v1", Text: `帮我翻译下面这段文字为{{.TargetLanguage}}
+{{- if .Glossary}}，其中的术语必须使用给定的译法：
+{{range .Glossary}}{{.Source}} => {{.Target}}
+{{end}}
+需要翻译的文字：{{end}}
+{{.Text}}`},
+	{Name: DefaultPromptName, Version: "v2", Text: `你是一名专业的学术论文翻译，请把下面的文字{{with .SourceLanguage}}从{{.}}{{end}}翻译为{{.TargetLanguage}}。
+{{- with .Title}}
+文字摘自论文《{{.}}》。
+{{- end}}
+要求：
+1. 只输出译文，不要添加解释或说明；
+2. LaTeX 公式（如 $...$、$$...$$、\begin{...}...\end{...}）、引用标记（如 [1]、(Smith et al., 2020)）、URL 和代码原样保留；
+3. 保留 Markdown 标记以及原有的段落和换行；
+{{- if .Glossary}}
+4. 以下术语必须使用给定的译法：
+{{- range .Glossary}}
+{{.Source}} => {{.Target}}
+{{- end}}
+{{- end}}
+{{- with .Previous}}
+上文（仅供参考，不要翻译）：
+{{.}}
+{{- end}}
+需要翻译的文字：
+{{.Text}}`},
+}
+
+// BuiltinPrompts 返回内置提示词模板的副本
+func BuiltinPrompts() []*PromptTemplate {
+	var templates []*PromptTemplate
+	for _, tmpl := range builtinPrompts {
+		copied := *tmpl
+		templates = append(templates, &copied)
+	}
+	return templates
+}
+
+// promptSample 保存模板时用来试渲染的变量，提前发现引用了不存在的变量
+var promptSample = prompt.Vars{
+	SourceLanguage: "en",
+	TargetLanguage: "汉语",
+	Title:          "Attention Is All You Need",
+	Previous:       "The dominant sequence transduction models are based on recurrent neural networks.",
+	Glossary:       []glossary.Term{{Source: "Transformer", Target: "Transformer"}},
+	Text:           "We propose a new simple network architecture, the Transformer.",
+}
+
+// Compile 解析并试渲染模板
+func (t *PromptTemplate) Compile() (*prompt.Prompt, error) {
+	p, err := prompt.New(t.Name, t.Version, t.Text)
+	if err != nil {
+		return nil, err
+	}
+	_, err = p.Render(promptSample)
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
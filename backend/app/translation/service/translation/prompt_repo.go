@@ -0,0 +1,110 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"log"
+	"paper-translation/pkg/prompt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	ErrPromptNotFound = errors.New("prompt template not found")
+	ErrPromptExists   = errors.New("prompt template version already exists")
+)
+
+// PromptRepository 提示词模板的存储
+type PromptRepository interface {
+	// Get 获取并解析模板，version 为空时返回最新版本，不存在时返回 ErrPromptNotFound
+	Get(name, version string) (*prompt.Prompt, error)
+	// Create 保存新版本的模板，版本已存在时返回 ErrPromptExists
+	Create(tmpl *PromptTemplate) error
+	// Find 查询模板，name 为空时返回全部
+	Find(name string) ([]*PromptTemplate, error)
+}
+
+// MongoPromptRepository 内置模板和配置文件中的模板在前，Mongo 中保存的模板在后，同一名称后保存的版本更新
+type MongoPromptRepository struct {
+	C      *mongo.Collection
+	static []*PromptTemplate // 内置模板和配置文件中的模板
+}
+
+func NewMongoPromptRepository(db *mongo.Database, static []*PromptTemplate) *MongoPromptRepository {
+	c := db.Collection("prompt_templates")
+	_, err := c.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "Name", Value: 1}, {Key: "Version", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Printf("create prompt_templates indexes err: %+v", err)
+	}
+	return &MongoPromptRepository{C: c, static: static}
+}
+
+func (t *MongoPromptRepository) Get(name, version string) (*prompt.Prompt, error) {
+	filter := bson.M{"Name": name}
+	if version != "" {
+		filter["Version"] = version
+	}
+	var tmpl *PromptTemplate
+	err := t.C.FindOne(context.TODO(), filter, options.FindOne().SetSort(bson.M{"CreateAt": -1})).Decode(&tmpl)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		tmpl, err = t.staticTemplate(name, version), nil
+		if tmpl == nil {
+			return nil, ErrPromptNotFound
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tmpl.Compile()
+}
+
+// staticTemplate 查找内置模板和配置文件中的模板，version 为空时返回最后声明的版本
+func (t *MongoPromptRepository) staticTemplate(name, version string) *PromptTemplate {
+	for i := len(t.static) - 1; i >= 0; i-- {
+		if tmpl := t.static[i]; tmpl.Name == name && (version == "" || tmpl.Version == version) {
+			return tmpl
+		}
+	}
+	return nil
+}
+
+func (t *MongoPromptRepository) Create(tmpl *PromptTemplate) error {
+	if t.staticTemplate(tmpl.Name, tmpl.Version) != nil {
+		return ErrPromptExists
+	}
+	tmpl.CreateAt = time.Now()
+	_, err := t.C.InsertOne(context.TODO(), tmpl)
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrPromptExists
+	}
+	return err
+}
+
+func (t *MongoPromptRepository) Find(name string) ([]*PromptTemplate, error) {
+	var templates []*PromptTemplate
+	for _, tmpl := range t.static {
+		if name == "" || tmpl.Name == name {
+			templates = append(templates, tmpl)
+		}
+	}
+	filter := bson.M{}
+	if name != "" {
+		filter["Name"] = name
+	}
+	cursor, err := t.C.Find(context.TODO(), filter, options.Find().SetSort(bson.M{"CreateAt": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var saved []*PromptTemplate
+	err = cursor.All(context.TODO(), &saved)
+	if err != nil {
+		return nil, err
+	}
+	return append(templates, saved...), nil
+}
@@ -0,0 +1,72 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	v1 "paper-translation/api/translation/service/v1"
+	"paper-translation/pkg/prompt"
+	"paper-translation/pkg/service"
+
+	merrors "go-micro.dev/v4/errors"
+)
+
+// SavePrompt 保存新版本的提示词模板，已有的版本不能修改
+func (t *TranslationService) SavePrompt(ctx context.Context, req *v1.PromptTemplate, resp *v1.PromptTemplate) error {
+	if req.Name == "" || req.Version == "" {
+		return merrors.BadRequest(service.TranslationServiceName, "prompt name or version is empty")
+	}
+	tmpl := &PromptTemplate{Name: req.Name, Version: req.Version, Text: req.Text}
+	p, err := tmpl.Compile()
+	if err != nil {
+		return merrors.BadRequest(service.TranslationServiceName, "invalid prompt template: %v", err)
+	}
+	_, _, err = Budget(t.chatProvider, t.options.SegmentTokens, t.contextTokens(), p)
+	if err != nil {
+		return merrors.BadRequest(service.TranslationServiceName, err.Error())
+	}
+	err = t.promptRepo.Create(tmpl)
+	if errors.Is(err, ErrPromptExists) {
+		return merrors.Conflict(service.TranslationServiceName, "prompt %s/%s already exists", req.Name, req.Version)
+	}
+	if err != nil {
+		return err
+	}
+	ConvertPromptTemplate(tmpl, resp)
+	return nil
+}
+
+// FetchPrompts 查询提示词模板
+func (t *TranslationService) FetchPrompts(ctx context.Context, req *v1.PromptQuery, resp *v1.PromptTemplates) error {
+	templates, err := t.promptRepo.Find(req.Name)
+	if err != nil {
+		return err
+	}
+	for _, tmpl := range templates {
+		var item v1.PromptTemplate
+		ConvertPromptTemplate(tmpl, &item)
+		resp.Templates = append(resp.Templates, &item)
+	}
+	return nil
+}
+
+// promptTemplate 获取翻译任务使用的提示词模板，没有指定名称时使用配置的默认模板
+func (t *TranslationService) promptTemplate(name, version string) (*prompt.Prompt, error) {
+	if name == "" {
+		name, version = t.options.PromptName, t.options.PromptVersion
+	}
+	p, err := t.promptRepo.Get(name, version)
+	if errors.Is(err, ErrPromptNotFound) {
+		return nil, merrors.BadRequest(service.TranslationServiceName, "prompt %s/%s not found", name, version)
+	}
+	return p, err
+}
+
+func ConvertPromptTemplate(tmpl *PromptTemplate, resp *v1.PromptTemplate) {
+	resp.Name = tmpl.Name
+	resp.Version = tmpl.Version
+	resp.Text = tmpl.Text
+	resp.Builtin = tmpl.Builtin
+	if !tmpl.CreateAt.IsZero() {
+		resp.CreateAt = tmpl.CreateAt.Unix()
+	}
+}
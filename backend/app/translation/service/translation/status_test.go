@@ -0,0 +1,45 @@
+package translation_test
+
+import (
+	"context"
+	v1 "paper-translation/api/translation/service/v1"
+	"paper-translation/app/translation/service/translation"
+	"paper-translation/pkg/segment"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/**
+ * TestFailedStatus 测试有段翻译失败时任务状态为失败而不是完成，并返回失败原因和失败的段。
+ */
+func TestFailedStatus(t *testing.T) {
+	provider := &fakeProvider{replies: []string{"第一段。"}}
+	repo := &memoryRepo{memories: map[translation.MemoryKey]*translation.Memory{}}
+	svc := newService(t, provider, repo)
+	task := newTask(t)
+
+	segments := segment.NewSegmenter(5, provider.Tokenizer().Count).Split("First paragraph.\n\nSecond paragraph.")
+	assert.Len(t, segments, 2)
+	err := svc.StartPipeline(context.Background(), task, segments)
+	assert.NotNil(t, err)
+
+	var status v1.TranslatedText
+	err = svc.GetStatus(context.Background(), &v1.TranslationID{TaskId: task.ID}, &status)
+	assert.Nil(t, err)
+	assert.False(t, status.Finished)
+	assert.True(t, status.Failed)
+	assert.NotEmpty(t, status.Error)
+	assert.Equal(t, []int32{2}, status.FailedSegments)
+	assert.Empty(t, status.Text)
+
+	// 全部段成功时标记为完成
+	provider.replies = []string{"第三段。"}
+	err = svc.StartPipeline(context.Background(), task, segment.NewSegmenter(5, provider.Tokenizer().Count).Split("Third paragraph."))
+	assert.Nil(t, err)
+	err = svc.GetStatus(context.Background(), &v1.TranslationID{TaskId: task.ID}, &status)
+	assert.Nil(t, err)
+	assert.True(t, status.Finished)
+	assert.False(t, status.Failed)
+	assert.Equal(t, "第三段。", status.Text)
+}
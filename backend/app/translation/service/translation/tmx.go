@@ -0,0 +1,192 @@
+package translation
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log"
+	v1 "paper-translation/api/translation/service/v1"
+	"paper-translation/pkg/tmx"
+	"strings"
+)
+
+// tmxChunkSize 导入导出 TMX 时每个分片的大小
+const tmxChunkSize = 32 << 10
+
+// tmxImportProgressInterval 导入 TMX 时每导入多少个翻译单元推送一次进度
+const tmxImportProgressInterval = 100
+
+// TMX 中保存翻译记忆键的自定义属性
+const (
+	tmxPropProvider      = "x-provider"
+	tmxPropPromptVersion = "x-prompt-version"
+)
+
+// undeterminedLanguage BCP-47 中表示未知语言的标签
+const undeterminedLanguage = "und"
+
+// ExportTM 以 TMX 1.4 格式导出翻译记忆，文件按分片推送
+func (t *TranslationService) ExportTM(ctx context.Context, req *v1.TMExport, stream v1.TranslationService_ExportTMStream) error {
+	defer stream.Close()
+
+	sourceLanguage := req.SourceLanguage
+	if sourceLanguage == "" {
+		sourceLanguage = undeterminedLanguage
+	}
+	w := bufio.NewWriterSize(tmxChunkWriter{stream}, tmxChunkSize)
+	encoder := tmx.NewEncoder(w, tmx.Header{CreationTool: "paper-translation", CreationToolVersion: "1.0", SrcLang: sourceLanguage})
+	err := t.memoryRepo.Each(memoryQuery(req.GetQuery()), func(memory *Memory) error {
+		return encoder.Encode(&tmx.Unit{
+			Props: map[string]string{
+				tmxPropProvider:      memory.Provider,
+				tmxPropPromptVersion: memory.PromptVersion,
+			},
+			CreationDate: memory.CreateAt,
+			ChangeDate:   memory.UpdateAt,
+			Variants: []tmx.Variant{
+				{Lang: sourceLanguage, Text: memory.SourceText},
+				{Lang: languageTag(memory.TargetLanguage), Text: memory.TranslatedText},
+			},
+		})
+	})
+	if err != nil {
+		return err
+	}
+	err = encoder.Close()
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// ImportTM 导入 TMX 文件，每个翻译单元的原文和每种译文保存为一条翻译记忆。
+// 没有模型和提示词版本属性的翻译单元按当前的模型和提示词版本保存，翻译时可以直接命中。
+func (t *TranslationService) ImportTM(ctx context.Context, stream v1.TranslationService_ImportTMStream) error {
+	defer stream.Close()
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	r, w := io.Pipe()
+	defer r.Close()
+	go func() {
+		for chunk := first; ; {
+			_, err := w.Write(chunk.Data)
+			if err != nil {
+				return
+			}
+			if chunk.Last {
+				_ = w.Close()
+				return
+			}
+			chunk, err = stream.Recv()
+			if err != nil {
+				_ = w.CloseWithError(err)
+				return
+			}
+		}
+	}()
+
+	progress := &v1.TMImportProgress{}
+	decoder := tmx.NewDecoder(r)
+	for {
+		unit, err := decoder.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		var sourceLanguage string
+		if header := decoder.Header(); header != nil && header.SrcLang != tmx.AllLanguages {
+			sourceLanguage = header.SrcLang
+		}
+		if sourceLanguage == "" {
+			sourceLanguage = first.SourceLanguage
+		}
+		memories := t.unitMemories(unit, sourceLanguage)
+		if len(memories) == 0 {
+			progress.Skipped++
+			continue
+		}
+		for _, memory := range memories {
+			err = t.memoryRepo.Save(memory)
+			if err != nil {
+				return err
+			}
+		}
+		progress.Imported++
+		if progress.Imported%tmxImportProgressInterval == 0 {
+			err = stream.Send(progress)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	log.Printf("import tmx imported: %d skipped: %d", progress.Imported, progress.Skipped)
+	progress.Finished = true
+	return stream.Send(progress)
+}
+
+// unitMemories 把翻译单元转换为翻译记忆，sourceLanguage 为空时第一种语言为原文
+func (t *TranslationService) unitMemories(unit *tmx.Unit, sourceLanguage string) []*Memory {
+	source := -1
+	for i, variant := range unit.Variants {
+		if sourceLanguage == "" || strings.EqualFold(variant.Lang, sourceLanguage) {
+			source = i
+			break
+		}
+	}
+	if source < 0 {
+		// 没有完全相同的标签时按主语言子标签匹配，如 en 和 en-US
+		primary, _, _ := strings.Cut(sourceLanguage, "-")
+		for i, variant := range unit.Variants {
+			if p, _, _ := strings.Cut(variant.Lang, "-"); strings.EqualFold(p, primary) {
+				source = i
+				break
+			}
+		}
+	}
+	if source < 0 {
+		return nil
+	}
+	sourceText := strings.TrimSpace(unit.Variants[source].Text)
+	if sourceText == "" {
+		return nil
+	}
+
+	key := MemoryKey{SourceHash: HashSource(sourceText), Provider: unit.Props[tmxPropProvider], PromptVersion: unit.Props[tmxPropPromptVersion]}
+	if key.Provider == "" {
+		key.Provider = t.chatProvider.Name()
+	}
+	if key.PromptVersion == "" {
+		key.PromptVersion = t.options.PromptName + "/" + t.options.PromptVersion
+	}
+	var memories []*Memory
+	for i, variant := range unit.Variants {
+		text := strings.TrimSpace(variant.Text)
+		if i == source || text == "" {
+			continue
+		}
+		key.TargetLanguage = languageTag(variant.Lang)
+		memories = append(memories, &Memory{MemoryKey: key, SourceText: sourceText, TranslatedText: text})
+	}
+	return memories
+}
+
+// tmxChunkWriter 把写入的内容作为分片推送
+type tmxChunkWriter struct {
+	stream v1.TranslationService_ExportTMStream
+}
+
+func (t tmxChunkWriter) Write(p []byte) (int, error) {
+	// 分片在发送前会被序列化，这里可以直接引用缓冲区
+	err := t.stream.Send(&v1.TMXChunk{Data: p})
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
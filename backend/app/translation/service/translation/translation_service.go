@@ -8,21 +8,132 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
+	"go-micro.dev/v4/broker"
+	merrors "go-micro.dev/v4/errors"
 	"log"
 	v1 "paper-translation/api/translation/service/v1"
+	"paper-translation/pkg/errutil"
+	"paper-translation/pkg/event"
+	"paper-translation/pkg/glossary"
+	"paper-translation/pkg/lang"
+	"paper-translation/pkg/llm"
+	"paper-translation/pkg/prompt"
+	"paper-translation/pkg/segment"
+	"paper-translation/pkg/service"
 	"paper-translation/pkg/signal"
-	xfspark "paper-translation/pkg/xf-spark"
+	"sort"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 )
 
-const (
-	Prompt = "帮我翻译下面这段文字为%s\n%s"
-)
+// promptReserve 为提示词中的变量和消息格式预留的 token 数
+const promptReserve = 32
+
+// watchResendInterval WatchStatus 没有新事件时重新推送当前状态的间隔
+const watchResendInterval = time.Second * 30
+
+// Options 翻译任务的执行参数
+type Options struct {
+	Parallelism     int           // 每个任务同时翻译的段数，同时受模型信号量限制
+	MaxAttempts     int           // 每段的最大尝试次数
+	RetryInterval   time.Duration // 第一次重试前的等待时间，之后每次翻倍
+	AcquireTimeout  time.Duration // 等待模型信号量的超时时间，超时计为一次失败
+	SegmentTokens   int           // 每段的 token 预算
+	MemoryCacheTTL  time.Duration // 翻译记忆在 Redis 中的缓存时间
+	PromptName      string        // 请求没有指定时使用的提示词模板名称
+	PromptVersion   string        // 请求没有指定时使用的提示词模板版本
+	ContextSegments int           // 每段带上前面几段的原文和译文作为上下文，为0时不带上下文
+	ContextTokens   int           // 上下文的最大 token 数
+}
+
+// Validate 检查执行参数，并发数和尝试次数至少为1，段的 token 预算必须为正数，上下文的段数和 token 数不能为负数
+func (o Options) Validate() error {
+	switch {
+	case o.Parallelism < 1:
+		return fmt.Errorf("translation.parallelism must be at least 1, got %d", o.Parallelism)
+	case o.MaxAttempts < 1:
+		return fmt.Errorf("translation.max_attempts must be at least 1, got %d", o.MaxAttempts)
+	case o.SegmentTokens < 1:
+		return fmt.Errorf("translation.segment_tokens must be positive, got %d", o.SegmentTokens)
+	case o.ContextSegments < 0:
+		return fmt.Errorf("translation.context.segments must not be negative, got %d", o.ContextSegments)
+	case o.ContextTokens < 0:
+		return fmt.Errorf("translation.context.tokens must not be negative, got %d", o.ContextTokens)
+	}
+	return nil
+}
+
+// Task 一个翻译任务中各段共用的参数
+type Task struct {
+	ID             string
+	SourceLanguage string          // 原文语言，未知时为空
+	TargetLanguage string          // 目标语言
+	Title          string          // 文档标题，未知时为空
+	Terms          []glossary.Term // 术语表中的术语
+	Prompt         *prompt.Prompt  // 提示词模板
+	ContextTokens  int             // 多轮对话的历史最多占用的 token 数，上下文窗口放不下配置的值时更小
+}
+
+// languageTag 把语言规范化为 BCP-47 标签，无法解析时原样返回，兼容以语言名称保存的术语表和翻译记忆
+func languageTag(language string) string {
+	if tag, err := lang.Normalize(language); err == nil {
+		return tag
+	}
+	return language
+}
+
+// Status 返回带有提示词模板版本的任务状态
+func (t *Task) Status(status TranslationStatus) TranslationStatus {
+	status.PromptName, status.PromptVersion = t.Prompt.Name, t.Prompt.Version
+	return status
+}
 
 type TranslationStatus struct {
 	TranslatedText string
-	Finished       bool
+	Finished       bool                // 全部段都翻译成功
+	Failed         bool                // 任务失败或被取消，Error 为失败原因
+	SegmentsDone   int32               // 已翻译的段数
+	SegmentsTotal  int32               // 总段数
+	FailedSegments []int32             // 重试后仍然失败的段序号，从1开始
+	Error          string              // 失败原因
+	Violations     []GlossaryViolation // 译文没有使用规定译法的段
+	PromptName     string              // 使用的提示词模板名称
+	PromptVersion  string              // 使用的提示词模板版本
+}
+
+// Ended 任务是否已经结束，成功或失败
+func (t TranslationStatus) Ended() bool {
+	return t.Finished || t.Failed
+}
+
+// Progress 转换为进度消息，译文只在完成时携带
+func (t TranslationStatus) Progress() *v1.TranslationProgress {
+	progress := &v1.TranslationProgress{
+		Finished:       t.Finished,
+		Failed:         t.Failed,
+		SegmentsDone:   t.SegmentsDone,
+		SegmentsTotal:  t.SegmentsTotal,
+		FailedSegments: t.FailedSegments,
+		Error:          t.Error,
+		PromptName:     t.PromptName,
+		PromptVersion:  t.PromptVersion,
+	}
+	if t.Finished {
+		progress.Text = t.TranslatedText
+		progress.GlossaryViolations = ConvertViolations(t.Violations)
+	}
+	return progress
+}
+
+// ConvertViolations 转换术语检查结果
+func ConvertViolations(violations []GlossaryViolation) []*v1.GlossaryViolation {
+	var resp []*v1.GlossaryViolation
+	for _, violation := range violations {
+		resp = append(resp, &v1.GlossaryViolation{Segment: violation.Segment, MissingTerms: violation.MissingTerms})
+	}
+	return resp
 }
 
 func (t *TranslationStatus) UnmarshalBinary(data []byte) error {
@@ -34,55 +145,180 @@ func (t TranslationStatus) MarshalBinary() (data []byte, err error) {
 }
 
 type TranslationService struct {
-	xfSparkClient *xfspark.XFSparkClient
+	chatProvider  llm.ChatProvider
 	signalFactory signal.SignalFactory
 	redisClient   *redis.Client
+	broker        broker.Broker
+	hub           *event.Hub
+	canceller     *event.Canceller
+	options       Options
+	memoryRepo    MemoryRepository // 翻译记忆，相同的原文直接复用译文
+	glossaryRepo  GlossaryRepository
+	promptRepo    PromptRepository
 }
 
-func NewTranslationService(xfSparkClient *xfspark.XFSparkClient, signalFactory signal.SignalFactory, redisClient *redis.Client) *TranslationService {
-	return &TranslationService{xfSparkClient: xfSparkClient, signalFactory: signalFactory, redisClient: redisClient}
+func NewTranslationService(chatProvider llm.ChatProvider, signalFactory signal.SignalFactory, redisClient *redis.Client, broker broker.Broker, memoryRepo MemoryRepository, glossaryRepo GlossaryRepository, promptRepo PromptRepository, options Options) *TranslationService {
+	// 任务可能在其他实例上执行，进度通过 broker 汇总到订阅的实例
+	hub, err := event.NewHub(broker, event.TopicTranslationProgress, event.TopicTranslationCompleted, event.TopicTranslationFailed)
+	errutil.PanicIfErr(err)
+	canceller, err := event.NewCanceller(broker, event.TopicTranslationCancel)
+	errutil.PanicIfErr(err)
+	return &TranslationService{chatProvider: chatProvider, signalFactory: signalFactory, redisClient: redisClient, broker: broker, hub: hub, canceller: canceller, options: options, memoryRepo: memoryRepo, glossaryRepo: glossaryRepo, promptRepo: promptRepo}
 }
 
-func (t *TranslationService) Translate(ctx context.Context, req *v1.Translation, resp *v1.TranslationID) error {
+// ErrNoRoom 提示词模板本身就占满了上下文窗口，放不下原文和译文
+var ErrNoRoom = errors.New("prompt template leaves no room for text in the context window")
 
-	//分句
-	sentences := strings.FieldsFunc(req.Text, func(r rune) bool {
-		for _, rx := range []rune{'.', '。', '?'} {
-			if rx == r {
-				return true
-			}
-		}
-		return false
-	})
+// Budget 按模型的上下文窗口返回每段的 token 预算和多轮对话的历史最多占用的 token 数。
+// 译文长度和原文相近，每段不超过最大回复 token 数，同时提示词、历史、原文和译文要能放进上下文窗口。
+// 提示词按模板本身估算，术语和上文等变量占用的 token 由 promptReserve 和切分预算的余量承担。
+// 窗口放不下配置的 contextTokens 时先减少历史，至少给原文留出剩余空间的一半；模板本身占满窗口时返回 ErrNoRoom
+func Budget(chatProvider llm.ChatProvider, segmentTokens, contextTokens int, p *prompt.Prompt) (int, int, error) {
+	budget := segmentTokens
+	maxTokens := chatProvider.MaxTokens()
+	if maxTokens <= 0 {
+		return budget, contextTokens, nil
+	}
+	budget = min(budget, maxTokens)
+	room := chatProvider.ContextWindow() - maxTokens - chatProvider.Tokenizer().Count(p.Text) - promptReserve
+	if room <= 0 {
+		return 0, 0, fmt.Errorf("prompt %s/%s: %w", p.Name, p.Version, ErrNoRoom)
+	}
+	contextTokens = min(contextTokens, max(room-budget, room/2))
+	return min(budget, room-contextTokens), contextTokens, nil
+}
 
-	// 按照token估算分段
-	var buf bytes.Buffer
-	var segments = make([]string, 0)
-	for i := range sentences {
-		buf.WriteString(sentences[i])
-		if xfspark.WordCount(buf.String()) > 2000 { //最多8000token
-			segments = append(segments, buf.String())
-			buf.Reset()
-		}
+func (t *TranslationService) Translate(ctx context.Context, req *v1.Translation, resp *v1.TranslationID) error {
+	// 术语表在提交时读取，之后修改术语表不影响已经提交的任务
+	terms, err := t.glossaryTerms(req.GlossaryId, req.TargetLanguage)
+	if err != nil {
+		return err
+	}
+	p, err := t.promptTemplate(req.PromptName, req.PromptVersion)
+	if err != nil {
+		return err
+	}
+	task := &Task{ID: uuid.NewString(), TargetLanguage: languageTag(req.TargetLanguage), Title: req.Title, Terms: terms, Prompt: p}
+	if req.SourceLanguage != "" {
+		task.SourceLanguage = languageTag(req.SourceLanguage)
 	}
 
-	// 添加剩余部分
-	if buf.Len() > 0 {
-		segments = append(segments, buf.String())
+	budget, contextTokens, err := Budget(t.chatProvider, t.options.SegmentTokens, t.contextTokens(), p)
+	if err != nil {
+		return merrors.BadRequest(service.TranslationServiceName, err.Error())
+	}
+	if contextTokens < t.contextTokens() {
+		log.Printf("prompt %s/%s leaves %d tokens for context instead of %d", p.Name, p.Version, contextTokens, t.contextTokens())
 	}
+	task.ContextTokens = contextTokens
+
+	// 按段落和句子分段，每段不超过 token 预算
+	segments := segment.NewSegmenter(budget, t.chatProvider.Tokenizer().Count).Split(req.Text)
 
-	resp.TaskId = uuid.NewString()
-	t.redisClient.Set(ctx, resp.TaskId, TranslationStatus{TranslatedText: "", Finished: false}, time.Hour)
+	resp.TaskId = task.ID
+	t.redisClient.Set(ctx, resp.TaskId, task.Status(TranslationStatus{SegmentsTotal: int32(len(segments))}), time.Hour)
+	// 任务在请求返回后继续执行，不能使用请求的 context，通过 Cancel 取消
+	taskCtx, done := t.canceller.Start(resp.TaskId)
 	go func() {
-		err := t.StartPipeline(context.TODO(), resp.TaskId, segments, req.TargetLanguage)
+		defer done()
+		err := t.StartPipeline(taskCtx, task, segments)
 		if err != nil {
 			log.Printf("exec translate pipeline err: %+v", err)
-			return
 		}
+		t.publish(resp.TaskId, err)
 	}()
 	return nil
 }
 
+// Cancel 取消翻译任务
+func (t *TranslationService) Cancel(ctx context.Context, req *v1.TranslationID, resp *v1.TranslationCancel) error {
+	return t.canceller.Cancel(req.TaskId)
+}
+
+// contextTokens 返回上下文占用的最大 token 数，不带上下文时为0
+func (t *TranslationService) contextTokens() int {
+	if t.options.ContextSegments <= 0 {
+		return 0
+	}
+	return t.options.ContextTokens
+}
+
+// FetchMemory 分页查询翻译记忆
+func (t *TranslationService) FetchMemory(ctx context.Context, req *v1.MemoryQuery, resp *v1.MemoryEntries) error {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+	memories, total, err := t.memoryRepo.Find(memoryQuery(req), req.Offset, limit)
+	if err != nil {
+		return err
+	}
+	resp.Total = total
+	for _, memory := range memories {
+		resp.Entries = append(resp.Entries, ConvertMemory(memory))
+	}
+	return nil
+}
+
+// InvalidateMemory 删除符合条件的翻译记忆，不允许不带条件删除全部
+func (t *TranslationService) InvalidateMemory(ctx context.Context, req *v1.MemoryQuery, resp *v1.MemoryInvalidated) error {
+	query := memoryQuery(req)
+	if query == (MemoryKey{}) {
+		return merrors.BadRequest(service.TranslationServiceName, "memory query is empty")
+	}
+	deleted, err := t.memoryRepo.Delete(query)
+	if err != nil {
+		return err
+	}
+	resp.Deleted = deleted
+	return nil
+}
+
+// ExportMemory 导出符合条件的翻译记忆
+func (t *TranslationService) ExportMemory(ctx context.Context, req *v1.MemoryQuery, stream v1.TranslationService_ExportMemoryStream) error {
+	defer stream.Close()
+	return t.memoryRepo.Each(memoryQuery(req), func(memory *Memory) error {
+		return stream.Send(ConvertMemory(memory))
+	})
+}
+
+// memoryQuery 转换查询条件，指定原文时按原文计算哈希
+func memoryQuery(req *v1.MemoryQuery) MemoryKey {
+	query := MemoryKey{SourceHash: req.GetSourceHash(), Provider: req.GetProvider(), PromptVersion: req.GetPromptVersion()}
+	if req.GetTargetLanguage() != "" {
+		query.TargetLanguage = languageTag(req.GetTargetLanguage())
+	}
+	if req.GetSourceText() != "" {
+		query.SourceHash = HashSource(req.GetSourceText())
+	}
+	return query
+}
+
+func ConvertMemory(memory *Memory) *v1.MemoryEntry {
+	return &v1.MemoryEntry{
+		SourceHash:     memory.SourceHash,
+		TargetLanguage: memory.TargetLanguage,
+		Provider:       memory.Provider,
+		PromptVersion:  memory.PromptVersion,
+		SourceText:     memory.SourceText,
+		TranslatedText: memory.TranslatedText,
+		CreateAt:       memory.CreateAt.Unix(),
+		UpdateAt:       memory.UpdateAt.Unix(),
+	}
+}
+
+// publish 发布翻译任务结束事件
+func (t *TranslationService) publish(taskID string, cause error) {
+	topic, e := event.TopicTranslationCompleted, &event.TaskEvent{TaskID: taskID}
+	if cause != nil {
+		topic, e.Error = event.TopicTranslationFailed, cause.Error()
+	}
+	err := event.Publish(t.broker, topic, e)
+	if err != nil {
+		log.Printf("publish %s event err: %+v", topic, err)
+	}
+}
+
 func (t *TranslationService) GetStatus(ctx context.Context, req *v1.TranslationID, resp *v1.TranslatedText) error {
 
 	var status TranslationStatus
@@ -92,49 +328,338 @@ func (t *TranslationService) GetStatus(ctx context.Context, req *v1.TranslationI
 	}
 	resp.Text = status.TranslatedText
 	resp.Finished = status.Finished
+	resp.Failed = status.Failed
+	resp.Error = status.Error
+	resp.SegmentsDone = status.SegmentsDone
+	resp.SegmentsTotal = status.SegmentsTotal
+	resp.FailedSegments = status.FailedSegments
+	resp.GlossaryViolations = ConvertViolations(status.Violations)
+	resp.PromptName = status.PromptName
+	resp.PromptVersion = status.PromptVersion
 	return nil
 }
 
-func (t *TranslationService) StartPipeline(ctx context.Context, taskID string, segments []string, language string) error {
-	semaphore := t.signalFactory.Semaphore("xf-spark", 2)
-	ticker := time.NewTicker(time.Millisecond * 500)
-	timer := time.NewTimer(time.Second * 60)
+// WatchStatus 推送翻译进度
+// 先推送一次当前状态，之后每翻译完一段推送一次本段译文，任务结束时推送全部译文并关闭
+func (t *TranslationService) WatchStatus(ctx context.Context, req *v1.TranslationID, stream v1.TranslationService_WatchStatusStream) error {
+	defer stream.Close()
+
+	// 先开始等待再读取状态，避免错过两者之间的事件
+	events, stop := t.hub.Watch(req.TaskId)
+	defer stop()
+
+	var status TranslationStatus
+	err := t.redisClient.Get(ctx, req.TaskId).Scan(&status)
+	if err != nil {
+		return err
+	}
+	err = stream.Send(status.Progress())
+	if err != nil || status.Ended() {
+		return err
+	}
+
+	// 定期重新推送当前状态，既能发现客户端断开，也能兜底丢失的事件
+	ticker := time.NewTicker(watchResendInterval)
+	defer ticker.Stop()
 	for {
 		select {
-		case <-ticker.C:
-			acquire, err := semaphore.Acquire()
-			if err != nil {
-				return err
+		case <-ctx.Done():
+			return ctx.Err()
+		case e := <-events:
+			if e.Topic == event.TopicTranslationProgress {
+				err = stream.Send(&v1.TranslationProgress{Segment: e.Index, SegmentsDone: e.Done, SegmentsTotal: e.Total, Text: e.Text})
+				if err != nil {
+					return err
+				}
+				continue
 			}
-			if acquire {
-				goto Translate
-			}
-		case <-timer.C:
-			return errors.New("wait to translate timeout")
+		case <-ticker.C:
+		}
+
+		err = t.redisClient.Get(ctx, req.TaskId).Scan(&status)
+		if err != nil {
+			return err
+		}
+		err = stream.Send(status.Progress())
+		if err != nil || status.Ended() {
+			return err
 		}
 	}
+}
 
-Translate:
+// StartPipeline 并发翻译各段，每段单独占用一个模型信号量额度并在失败后重试，全部完成后按顺序拼接译文。
+// 开启上下文时相邻的段分到同一条流水线中按顺序翻译，每段带上同一流水线中前几段的原文和译文作为多轮对话的历史，
+// 各流水线之间并发；不开启上下文时每段单独成一条流水线。
+// 有段重试后仍然失败时任务失败，并记录失败的段序号。译文缺少术语表规定的译法时不算失败，只记录在结果中。
+func (t *TranslationService) StartPipeline(ctx context.Context, task *Task, segments []segment.Chunk) (err error) {
+	var total = int32(len(segments))
+	var texts = make([]string, len(segments)) // 按段序号保存译文，避免并发完成的顺序打乱译文
+	var failed []int32
+	var violations []GlossaryViolation
+	var done int32
 	defer func() {
-		err := semaphore.Release()
+		sort.Slice(violations, func(i, j int) bool { return violations[i].Segment < violations[j].Segment })
+		// 只有全部段都翻译成功才标记为完成
+		status := task.Status(TranslationStatus{SegmentsDone: done, SegmentsTotal: total, FailedSegments: failed, Violations: violations})
 		if err != nil {
-			log.Printf("release semaphore err: %v", err)
+			status.Failed, status.Error = true, err.Error()
+		} else {
+			status.Finished, status.TranslatedText = true, strings.Join(texts, "")
 		}
+		log.Printf("translate task %s finished: %d of %d segments, %d failed, %d chars", task.ID, done, total, len(failed), utf8.RuneCountInString(status.TranslatedText))
+		// 任务被取消时 ctx 已经结束，仍然需要记录最终状态
+		t.redisClient.Set(context.Background(), task.ID, status, time.Hour)
 	}()
-	log.Printf("begin translate text: %+v", segments)
-	//讯飞只给2并发
-	var translatedText bytes.Buffer
-	defer func() {
-		log.Printf("translate result: %s", translatedText.String())
-		t.redisClient.Set(ctx, taskID, TranslationStatus{TranslatedText: translatedText.String(), Finished: true}, time.Hour)
-	}()
-	for _, segment := range segments {
-		err := t.xfSparkClient.CreateChat(ctx, fmt.Sprintf(Prompt, language, segment), func(text string) {
-			translatedText.WriteString(text)
-		})
+
+	var mu sync.Mutex // 保证进度按完成顺序递增
+	finish := func(index int, text string, missing []glossary.Term, err error) {
+		mu.Lock()
+		defer mu.Unlock()
 		if err != nil {
-			return err
+			log.Printf("translate segment %d err: %+v", index+1, err)
+			failed = append(failed, int32(index+1))
+			return
+		}
+		texts[index] = text
+		done++
+		if len(missing) > 0 {
+			violation := GlossaryViolation{Segment: int32(index + 1)}
+			for _, term := range missing {
+				violation.MissingTerms = append(violation.MissingTerms, term.Source)
+			}
+			log.Printf("translate segment %d missing glossary terms %v", index+1, violation.MissingTerms)
+			violations = append(violations, violation)
 		}
+		t.redisClient.Set(ctx, task.ID, task.Status(TranslationStatus{SegmentsDone: done, SegmentsTotal: total}), time.Hour)
+		progress := &event.TaskEvent{TaskID: task.ID, Index: int32(index + 1), Done: done, Total: total, Text: text}
+		if err := event.Publish(t.broker, event.TopicTranslationProgress, progress); err != nil {
+			log.Printf("publish %s event err: %+v", event.TopicTranslationProgress, err)
+		}
+	}
+
+	laneSize := 1
+	if t.options.ContextSegments > 0 {
+		laneSize = (len(segments) + t.options.Parallelism - 1) / t.options.Parallelism
+	}
+	semaphore := t.signalFactory.Semaphore(t.chatProvider.Name(), t.chatProvider.MaxConcurrency())
+	var wg sync.WaitGroup
+	var limit = make(chan struct{}, t.options.Parallelism)
+	log.Printf("begin translate %d segments, %d segments per lane", len(segments), laneSize)
+	for start := 0; start < len(segments); start += laneSize {
+		select {
+		case limit <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			defer func() { <-limit }()
+			var history []contextPair
+			for index := start; index < end && ctx.Err() == nil; index++ {
+				chunk := segments[index]
+				messages := t.contextMessages(task, history)
+				// 流水线中的第一段没有历史，历史放不进上下文时也没有，用上一段原文的最后一句作为上文
+				var previous string
+				if index > 0 && len(messages) == 0 {
+					previous = lastSentence(segments[index-1].Text)
+				}
+				text, missing, err := t.translateSegment(ctx, semaphore, task, chunk, previous, messages)
+				if err == nil && chunk.Text != "" {
+					history = append(history, contextPair{Source: chunk.Text, Target: strings.TrimSpace(text)})
+				}
+				finish(index, text, missing, err)
+			}
+		}(start, min(start+laneSize, len(segments)))
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+	if len(failed) > 0 {
+		sort.Slice(failed, func(i, j int) bool { return failed[i] < failed[j] })
+		return fmt.Errorf("translate segments %v failed", failed)
 	}
 	return nil
 }
+
+// contextPair 已经翻译完成的一段原文和译文
+type contextPair struct {
+	Source string
+	Target string
+}
+
+// contextMessages 把最近 ContextSegments 段的原文和译文转换为多轮对话的历史，
+// 总 token 数不超过任务的 ContextTokens，超出时丢弃较早的段。
+// 段的预算通常比 ContextTokens 大得多，最近一段放不下时只保留原文和译文末尾的句子，各占一半
+func (t *TranslationService) contextMessages(task *Task, history []contextPair) []llm.Message {
+	tokenizer := t.chatProvider.Tokenizer()
+	var messages []llm.Message
+	var tokens int
+	for i := len(history) - 1; i >= 0 && i >= len(history)-t.options.ContextSegments; i-- {
+		pair := history[i]
+		tokens += tokenizer.Count(pair.Source) + tokenizer.Count(pair.Target)
+		if tokens > task.ContextTokens {
+			if len(messages) == 0 {
+				source := tailSentences(pair.Source, task.ContextTokens/2, tokenizer.Count)
+				target := tailSentences(pair.Target, task.ContextTokens/2, tokenizer.Count)
+				if source != "" && target != "" {
+					messages = []llm.Message{
+						{Role: llm.RoleUser, Content: source},
+						{Role: llm.RoleAssistant, Content: target},
+					}
+				}
+			}
+			break
+		}
+		messages = append([]llm.Message{
+			{Role: llm.RoleUser, Content: pair.Source},
+			{Role: llm.RoleAssistant, Content: pair.Target},
+		}, messages...)
+	}
+	return messages
+}
+
+// translateSegment 翻译一段。段中的每个段落先查询翻译记忆，段落没有命中时再按句子查询，只有没有命中的部分调用大模型，
+// 连续没有命中的部分合并为一次调用，失败后按指数退避重试。翻译记忆以段落和句子为单位保存，不受分段预算和相邻文本的影响，
+// 不同论文中相同的段落、导入的句级 TMX 都能命中。
+// 原文中出现的术语会加入提示词，返回译文中没有使用规定译法的术语；没有使用规定译法的翻译记忆不会被复用。
+// previous 为上一段原文的最后一句，作为上文提供给提示词模板；history 为前几段的原文和译文，放在本段提示词之前。
+// 原文首尾的空白原样保留在译文中
+func (t *TranslationService) translateSegment(ctx context.Context, semaphore signal.Semaphore, task *Task, chunk segment.Chunk, previous string, history []llm.Message) (string, []glossary.Term, error) {
+	if chunk.Text == "" {
+		return chunk.String(), nil, nil
+	}
+	pieces := t.recall(task, chunk.Text)
+	var buf strings.Builder
+	var offset int // 已经处理的原文长度，用于取每次调用的上文
+	for i := 0; i < len(pieces); {
+		if pieces[i].translated {
+			buf.WriteString(pieces[i].String())
+			offset += len(pieces[i].source)
+			i++
+			continue
+		}
+		j := i + 1
+		for j < len(pieces) && !pieces[j].translated {
+			j++
+		}
+		var source strings.Builder
+		for _, p := range pieces[i:j] {
+			source.WriteString(p.source)
+		}
+		leading, text, trailing := splitSpace(source.String())
+		if offset > 0 {
+			previous = lastSentence(chunk.Text[:offset])
+		}
+		translated, err := t.translateText(ctx, semaphore, task, text, previous, history)
+		if err != nil {
+			return "", nil, err
+		}
+		t.remember(task, pieces[i:j], translated)
+		buf.WriteString(leading + translated + trailing)
+		offset += source.Len()
+		i = j
+	}
+	t.rememberParagraphs(task, pieces)
+
+	text := buf.String()
+	return chunk.Leading + text + chunk.Trailing, glossary.Missing(glossary.Match(task.Terms, chunk.Text), text), nil
+}
+
+// translateText 调用大模型翻译一段去掉首尾空白的原文，失败后按指数退避重试
+func (t *TranslationService) translateText(ctx context.Context, semaphore signal.Semaphore, task *Task, text, previous string, history []llm.Message) (string, error) {
+	// 提示词中使用语言的中文名称
+	vars := prompt.Vars{
+		TargetLanguage: lang.Name(task.TargetLanguage),
+		Title:          task.Title,
+		Previous:       previous,
+		Glossary:       glossary.Match(task.Terms, text),
+		Text:           text,
+	}
+	if task.SourceLanguage != "" {
+		vars.SourceLanguage = lang.Name(task.SourceLanguage)
+	}
+	content, err := task.Prompt.Render(vars)
+	if err != nil {
+		return "", err
+	}
+
+	interval := t.options.RetryInterval
+	for attempt := 1; ; attempt++ {
+		translated, err := t.chat(ctx, semaphore, append(history, llm.Message{Role: llm.RoleUser, Content: content}))
+		if err == nil {
+			return strings.TrimSpace(translated), nil
+		}
+		if attempt >= t.options.MaxAttempts || ctx.Err() != nil {
+			return "", err
+		}
+		log.Printf("translate segment attempt %d err: %+v", attempt, err)
+		select {
+		case <-time.After(interval):
+			interval *= 2
+		case <-ctx.Done():
+			return "", context.Cause(ctx)
+		}
+	}
+}
+
+// lastSentence 返回文本的最后一句
+func lastSentence(text string) string {
+	sentences := segment.Sentences(text)
+	if len(sentences) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(sentences[len(sentences)-1])
+}
+
+// tailSentences 返回文本末尾总共不超过 budget 个 token 的完整句子，最后一句就超出预算时返回空字符串
+func tailSentences(text string, budget int, count func(string) int) string {
+	sentences := segment.Sentences(text)
+	start, tokens := len(sentences), 0
+	for start > 0 {
+		tokens += count(sentences[start-1])
+		if tokens > budget {
+			break
+		}
+		start--
+	}
+	return strings.TrimSpace(strings.Join(sentences[start:], ""))
+}
+
+// chat 获取一个模型信号量额度后调用一次大模型，返回完整的回复
+func (t *TranslationService) chat(ctx context.Context, semaphore signal.Semaphore, messages []llm.Message) (string, error) {
+	ticker := time.NewTicker(time.Millisecond * 500)
+	defer ticker.Stop()
+	timer := time.NewTimer(t.options.AcquireTimeout)
+	defer timer.Stop()
+	for acquired := false; !acquired; {
+		select {
+		case <-ticker.C:
+			var err error
+			acquired, err = semaphore.Acquire()
+			if err != nil {
+				return "", err
+			}
+		case <-timer.C:
+			return "", errors.New("wait to translate timeout")
+		case <-ctx.Done():
+			return "", context.Cause(ctx)
+		}
+	}
+	defer func() {
+		err := semaphore.Release()
+		if err != nil {
+			log.Printf("release semaphore err: %v", err)
+		}
+	}()
+
+	var text bytes.Buffer
+	err := t.chatProvider.CreateChat(ctx, messages, func(s string) {
+		text.WriteString(s)
+	})
+	return text.String(), err
+}
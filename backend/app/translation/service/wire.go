@@ -9,17 +9,27 @@ import (
 	v1 "paper-translation/api/translation/service/v1"
 	"paper-translation/app/translation/service/translation"
 	"paper-translation/pkg/ds"
+	"paper-translation/pkg/event"
+	"paper-translation/pkg/llm"
 	"paper-translation/pkg/service"
 	"paper-translation/pkg/signal"
-	xfspark "paper-translation/pkg/xf-spark"
 )
 
 func InitApp() micro.Service {
 	panic(wire.Build(
 		service.ProviderSet,
-		xfspark.NewXFSpark,
+		llm.NewChatProvider,
 		signal.NewSignalFactory,
+		ds.NewMongoClient,
+		ds.NewMongoDatabase,
 		ds.NewRedisClient,
+		event.NewBroker,
+		NewTranslationOptions,
+		translation.NewMongoMemoryRepository,
+		translation.NewCachedMemoryRepository, wire.Bind(new(translation.MemoryRepository), new(*translation.CachedMemoryRepository)),
+		translation.NewMongoGlossaryRepository, wire.Bind(new(translation.GlossaryRepository), new(*translation.MongoGlossaryRepository)),
+		NewPromptTemplates,
+		translation.NewMongoPromptRepository, wire.Bind(new(translation.PromptRepository), new(*translation.MongoPromptRepository)),
 		translation.NewTranslationService, wire.Bind(new(v1.TranslationServiceHandler), new(*translation.TranslationService)),
 		NewService,
 	))
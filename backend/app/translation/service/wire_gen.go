@@ -10,9 +10,10 @@ import (
 	"go-micro.dev/v4"
 	"paper-translation/app/translation/service/translation"
 	"paper-translation/pkg/ds"
+	"paper-translation/pkg/event"
+	"paper-translation/pkg/llm"
 	"paper-translation/pkg/service"
 	"paper-translation/pkg/signal"
-	"paper-translation/pkg/xf-spark"
 )
 
 // Injectors from wire.go:
@@ -20,10 +21,19 @@ import (
 func InitApp() micro.Service {
 	registry := service.NewRegistry()
 	config := service.NewConfig()
-	xfSparkClient := xf_spark.NewXFSpark(config)
+	chatProvider := llm.NewChatProvider(config)
 	signalFactory := signal.NewSignalFactory(config)
 	client := ds.NewRedisClient(config)
-	translationService := translation.NewTranslationService(xfSparkClient, signalFactory, client)
+	broker := event.NewBroker(config)
+	mongoClient := ds.NewMongoClient(config)
+	database := ds.NewMongoDatabase(config, mongoClient)
+	mongoMemoryRepository := translation.NewMongoMemoryRepository(database)
+	options := NewTranslationOptions(config)
+	cachedMemoryRepository := translation.NewCachedMemoryRepository(mongoMemoryRepository, client, options)
+	mongoGlossaryRepository := translation.NewMongoGlossaryRepository(database)
+	v := NewPromptTemplates(config, chatProvider, options)
+	mongoPromptRepository := translation.NewMongoPromptRepository(database, v)
+	translationService := translation.NewTranslationService(chatProvider, signalFactory, client, broker, cachedMemoryRepository, mongoGlossaryRepository, mongoPromptRepository, options)
 	microService := NewService(registry, config, translationService)
 	return microService
 }
@@ -30,6 +30,41 @@ var FileNotExistError = &Error{
 	message:  "文件不存在",
 }
 
+// 下载链接签名无效错误
+var SignatureInvalidError = &Error{
+	httpCode: http.StatusForbidden,
+	code:     40004,
+	message:  "下载链接无效",
+}
+
+// 下载链接过期错误
+var URLExpiredError = &Error{
+	httpCode: http.StatusForbidden,
+	code:     40005,
+	message:  "下载链接已过期",
+}
+
+// 论文状态冲突错误
+var PaperStateError = &Error{
+	httpCode: http.StatusConflict,
+	code:     40006,
+	message:  "论文当前状态不支持该操作",
+}
+
+// 术语表不存在错误
+var GlossaryNotExistError = &Error{
+	httpCode: http.StatusNotFound,
+	code:     40007,
+	message:  "术语表不存在",
+}
+
+// 提示词模板版本已存在错误
+var PromptVersionExistError = &Error{
+	httpCode: http.StatusConflict,
+	code:     40008,
+	message:  "提示词模板版本已存在",
+}
+
 // 服务数据库错误
 var ServerDBError = &Error{
 	httpCode: http.StatusInternalServerError,
@@ -0,0 +1,57 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go-micro.dev/v4/broker"
+)
+
+// ErrTaskCancelled 任务被取消时作为 context 的取消原因。
+var ErrTaskCancelled = errors.New("task cancelled")
+
+// Canceller 记录本实例正在执行的任务，收到取消事件时取消对应任务的 context。
+// 取消请求可能落在任意实例上，通过 broker 广播给执行任务的实例。
+type Canceller struct {
+	broker  broker.Broker
+	topic   string
+	mu      sync.Mutex
+	cancels map[string]context.CancelCauseFunc
+}
+
+// NewCanceller 创建 Canceller 并订阅取消主题 topic。
+func NewCanceller(b broker.Broker, topic string) (*Canceller, error) {
+	c := &Canceller{broker: b, topic: topic, cancels: make(map[string]context.CancelCauseFunc)}
+	_, err := Subscribe(b, topic, func(e *TaskEvent) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if cancel, ok := c.cancels[e.TaskID]; ok {
+			cancel(ErrTaskCancelled)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Start 为任务创建可取消的 context，任务结束后需要调用返回的 done。
+func (t *Canceller) Start(taskID string) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	t.mu.Lock()
+	t.cancels[taskID] = cancel
+	t.mu.Unlock()
+
+	return ctx, func() {
+		t.mu.Lock()
+		delete(t.cancels, taskID)
+		t.mu.Unlock()
+		cancel(nil)
+	}
+}
+
+// Cancel 广播取消任务，任务不存在或已经结束时忽略。
+func (t *Canceller) Cancel(taskID string) error {
+	return Publish(t.broker, t.topic, &TaskEvent{TaskID: taskID})
+}
@@ -0,0 +1,62 @@
+package event
+
+import (
+	"log"
+	"sync"
+
+	"go-micro.dev/v4/broker"
+)
+
+// hubBuffer 每个等待者缓冲的事件数，缓冲满时丢弃新的进度事件
+const hubBuffer = 64
+
+// Hub 订阅一组主题，把事件按任务ID分发给等待该任务的协程。
+// 同一个任务可以有多个等待者，没有等待者的事件直接忽略。
+type Hub struct {
+	mu      sync.Mutex
+	waiters map[string]map[chan *TaskEvent]struct{}
+}
+
+// NewHub 创建 Hub 并订阅 topics。
+func NewHub(b broker.Broker, topics ...string) (*Hub, error) {
+	h := &Hub{waiters: make(map[string]map[chan *TaskEvent]struct{})}
+	for _, topic := range topics {
+		_, err := Subscribe(b, topic, h.dispatch)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// Watch 开始等待任务的事件，用完后需要调用返回的 stop。
+func (t *Hub) Watch(taskID string) (<-chan *TaskEvent, func()) {
+	ch := make(chan *TaskEvent, hubBuffer)
+	t.mu.Lock()
+	if t.waiters[taskID] == nil {
+		t.waiters[taskID] = make(map[chan *TaskEvent]struct{})
+	}
+	t.waiters[taskID][ch] = struct{}{}
+	t.mu.Unlock()
+
+	return ch, func() {
+		t.mu.Lock()
+		delete(t.waiters[taskID], ch)
+		if len(t.waiters[taskID]) == 0 {
+			delete(t.waiters, taskID)
+		}
+		t.mu.Unlock()
+	}
+}
+
+func (t *Hub) dispatch(e *TaskEvent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for ch := range t.waiters[e.TaskID] {
+		select {
+		case ch <- e:
+		default:
+			log.Printf("drop %s event of task %s, watcher is too slow", e.Topic, e.TaskID)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+	"go-micro.dev/v4/broker"
+)
+
+// NatsBroker 基于 NATS 的 broker 实现，订阅时指定 broker.Queue 会在同名队列的订阅者之间负载均衡。
+type NatsBroker struct {
+	addrs []string
+	opts  broker.Options
+
+	mu   sync.RWMutex
+	conn *nats.Conn
+}
+
+// NewNatsBroker 创建连接到 addrs 的 NATS broker，需要调用 Connect 后才能使用。
+func NewNatsBroker(addrs []string, opts ...broker.Option) *NatsBroker {
+	b := &NatsBroker{addrs: addrs, opts: broker.Options{Context: context.Background()}}
+	_ = b.Init(opts...)
+	return b
+}
+
+func (t *NatsBroker) Init(opts ...broker.Option) error {
+	for _, o := range opts {
+		o(&t.opts)
+	}
+	if len(t.opts.Addrs) > 0 {
+		t.addrs = t.opts.Addrs
+	}
+	return nil
+}
+
+func (t *NatsBroker) Options() broker.Options {
+	return t.opts
+}
+
+func (t *NatsBroker) Address() string {
+	return strings.Join(t.addrs, ",")
+}
+
+func (t *NatsBroker) Connect() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.conn != nil {
+		return nil
+	}
+	conn, err := nats.Connect(t.Address())
+	if err != nil {
+		return err
+	}
+	t.conn = conn
+	return nil
+}
+
+func (t *NatsBroker) Disconnect() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.conn != nil {
+		t.conn.Close()
+		t.conn = nil
+	}
+	return nil
+}
+
+func (t *NatsBroker) Publish(topic string, m *broker.Message, opts ...broker.PublishOption) error {
+	conn, err := t.connection()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return conn.Publish(topic, data)
+}
+
+func (t *NatsBroker) Subscribe(topic string, h broker.Handler, opts ...broker.SubscribeOption) (broker.Subscriber, error) {
+	conn, err := t.connection()
+	if err != nil {
+		return nil, err
+	}
+	options := broker.NewSubscribeOptions(opts...)
+	handler := func(msg *nats.Msg) {
+		var m broker.Message
+		err := json.Unmarshal(msg.Data, &m)
+		if err == nil {
+			err = h(&natsEvent{topic: msg.Subject, message: &m})
+		}
+		if err != nil {
+			log.Printf("handle nats broker message on %s err: %+v", msg.Subject, err)
+		}
+	}
+
+	var sub *nats.Subscription
+	if options.Queue != "" {
+		sub, err = conn.QueueSubscribe(topic, options.Queue, handler)
+	} else {
+		sub, err = conn.Subscribe(topic, handler)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &natsSubscriber{topic: topic, opts: options, sub: sub}, nil
+}
+
+func (t *NatsBroker) String() string {
+	return "nats"
+}
+
+func (t *NatsBroker) connection() (*nats.Conn, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.conn == nil {
+		return nil, errors.New("nats broker not connected")
+	}
+	return t.conn, nil
+}
+
+type natsEvent struct {
+	topic   string
+	message *broker.Message
+}
+
+func (t *natsEvent) Topic() string            { return t.topic }
+func (t *natsEvent) Message() *broker.Message { return t.message }
+func (t *natsEvent) Ack() error               { return nil }
+func (t *natsEvent) Error() error             { return nil }
+
+type natsSubscriber struct {
+	topic string
+	opts  broker.SubscribeOptions
+	sub   *nats.Subscription
+}
+
+func (t *natsSubscriber) Options() broker.SubscribeOptions { return t.opts }
+func (t *natsSubscriber) Topic() string                    { return t.topic }
+func (t *natsSubscriber) Unsubscribe() error               { return t.sub.Unsubscribe() }
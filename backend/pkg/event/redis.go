@@ -0,0 +1,106 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/redis/go-redis/v9"
+	"go-micro.dev/v4/broker"
+)
+
+// RedisBroker 基于 Redis 发布订阅的 broker 实现。
+// Redis 发布订阅不持久化消息，也不支持队列分组，同一主题的每个订阅者都会收到全部消息。
+type RedisBroker struct {
+	client *redis.Client
+	opts   broker.Options
+}
+
+// NewRedisBroker 使用已有的 Redis 客户端创建 broker。
+func NewRedisBroker(client *redis.Client, opts ...broker.Option) *RedisBroker {
+	b := &RedisBroker{client: client, opts: broker.Options{Context: context.Background()}}
+	_ = b.Init(opts...)
+	return b
+}
+
+func (t *RedisBroker) Init(opts ...broker.Option) error {
+	for _, o := range opts {
+		o(&t.opts)
+	}
+	return nil
+}
+
+func (t *RedisBroker) Options() broker.Options {
+	return t.opts
+}
+
+func (t *RedisBroker) Address() string {
+	return t.client.Options().Addr
+}
+
+// Connect 检查 Redis 是否可用。
+func (t *RedisBroker) Connect() error {
+	return t.client.Ping(t.opts.Context).Err()
+}
+
+// Disconnect Redis 客户端由调用方管理，这里不关闭。
+func (t *RedisBroker) Disconnect() error {
+	return nil
+}
+
+func (t *RedisBroker) Publish(topic string, m *broker.Message, opts ...broker.PublishOption) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return t.client.Publish(t.opts.Context, topic, data).Err()
+}
+
+func (t *RedisBroker) Subscribe(topic string, h broker.Handler, opts ...broker.SubscribeOption) (broker.Subscriber, error) {
+	options := broker.NewSubscribeOptions(opts...)
+	pubsub := t.client.Subscribe(t.opts.Context, topic)
+	// 等待订阅确认，保证返回后发布的消息都能收到
+	_, err := pubsub.Receive(t.opts.Context)
+	if err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+
+	go func() {
+		for msg := range pubsub.Channel() {
+			var m broker.Message
+			err := json.Unmarshal([]byte(msg.Payload), &m)
+			if err == nil {
+				err = h(&redisEvent{topic: msg.Channel, message: &m})
+			}
+			if err != nil {
+				log.Printf("handle redis broker message on %s err: %+v", msg.Channel, err)
+			}
+		}
+	}()
+	return &redisSubscriber{topic: topic, opts: options, pubsub: pubsub}, nil
+}
+
+func (t *RedisBroker) String() string {
+	return "redis"
+}
+
+type redisEvent struct {
+	topic   string
+	message *broker.Message
+}
+
+func (t *redisEvent) Topic() string            { return t.topic }
+func (t *redisEvent) Message() *broker.Message { return t.message }
+func (t *redisEvent) Ack() error               { return nil }
+func (t *redisEvent) Error() error             { return nil }
+
+type redisSubscriber struct {
+	topic  string
+	opts   broker.SubscribeOptions
+	pubsub *redis.PubSub
+}
+
+func (t *redisSubscriber) Options() broker.SubscribeOptions { return t.opts }
+func (t *redisSubscriber) Topic() string                    { return t.topic }
+func (t *redisSubscriber) Unsubscribe() error               { return t.pubsub.Close() }
@@ -0,0 +1,67 @@
+package event
+
+import (
+	"encoding/json"
+
+	"go-micro.dev/v4/broker"
+)
+
+// OCR和翻译任务的事件主题
+const (
+	TopicOCRProgress          = "ocr.progress"
+	TopicOCRCompleted         = "ocr.completed"
+	TopicOCRFailed            = "ocr.failed"
+	TopicTranslationProgress  = "translation.progress"
+	TopicTranslationCompleted = "translation.completed"
+	TopicTranslationFailed    = "translation.failed"
+	TopicOCRCancel            = "ocr.cancel"         // 取消OCR任务，由执行任务的实例处理
+	TopicTranslationCancel    = "translation.cancel" // 取消翻译任务，由执行任务的实例处理
+)
+
+// 论文处理流水线的事件主题，事件的 TaskID 为论文ID
+const (
+	TopicPaperStage               = "paper.stage"
+	TopicPaperOCRProgress         = "paper.ocr.progress"
+	TopicPaperTranslationProgress = "paper.translation.progress"
+	TopicPaperFinished            = "paper.finished"
+	TopicPaperFailed              = "paper.failed"
+	TopicPaperCancelled           = "paper.cancelled"
+)
+
+// TaskEvent 任务进度或结束事件。结果文本可能很大，结束事件里只携带任务ID，订阅方收到后再通过 GetStatus 获取结果。
+type TaskEvent struct {
+	Topic  string `json:"-"`                // 事件主题，接收时填充
+	TaskID string `json:"task_id"`          // 任务ID
+	Error  string `json:"error,omitempty"`  // 失败原因，仅 *.failed 事件有值
+	Index  int32  `json:"index,omitempty"`  // 本次完成的页码或段序号，从1开始，仅 *.progress 事件有值
+	Done   int32  `json:"done,omitempty"`   // 已完成的页数或段数
+	Total  int32  `json:"total,omitempty"`  // 总页数或总段数
+	Text   string `json:"text,omitempty"`   // 本段译文，仅 *translation.progress 事件有值
+	Status int32  `json:"status,omitempty"` // 论文状态，仅 paper.stage 事件有值
+}
+
+// Publish 发布任务事件。
+func Publish(b broker.Broker, topic string, e *TaskEvent) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return b.Publish(topic, &broker.Message{
+		Header: map[string]string{"Content-Type": "application/json"},
+		Body:   body,
+	})
+}
+
+// Subscribe 订阅任务事件，无法解析的消息会被丢弃。
+func Subscribe(b broker.Broker, topic string, handler func(*TaskEvent)) (broker.Subscriber, error) {
+	return b.Subscribe(topic, func(p broker.Event) error {
+		var e TaskEvent
+		err := json.Unmarshal(p.Message().Body, &e)
+		if err != nil {
+			return err
+		}
+		e.Topic = p.Topic()
+		handler(&e)
+		return nil
+	})
+}
@@ -0,0 +1,107 @@
+package event_test
+
+import (
+	"context"
+	"paper-translation/pkg/event"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go-micro.dev/v4/broker"
+)
+
+/**
+ * TestTaskEvent 测试任务事件通过内存 broker 发布和订阅。
+ */
+func TestTaskEvent(t *testing.T) {
+	b := broker.NewMemoryBroker()
+	assert.NoError(t, b.Connect())
+	defer b.Disconnect()
+
+	received := make(chan *event.TaskEvent, 1)
+	sub, err := event.Subscribe(b, event.TopicOCRFailed, func(e *event.TaskEvent) {
+		received <- e
+	})
+	assert.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	// 其他主题的事件不会收到
+	assert.NoError(t, event.Publish(b, event.TopicOCRCompleted, &event.TaskEvent{TaskID: "a"}))
+	assert.NoError(t, event.Publish(b, event.TopicOCRFailed, &event.TaskEvent{TaskID: "b", Error: "convert pdf failed"}))
+
+	select {
+	case e := <-received:
+		assert.Equal(t, &event.TaskEvent{Topic: event.TopicOCRFailed, TaskID: "b", Error: "convert pdf failed"}, e)
+	case <-time.After(time.Second):
+		t.Fatal("task event not received")
+	}
+}
+
+/**
+ * TestHub 测试按任务ID分发事件。
+ */
+func TestHub(t *testing.T) {
+	b := broker.NewMemoryBroker()
+	assert.NoError(t, b.Connect())
+	defer b.Disconnect()
+
+	hub, err := event.NewHub(b, event.TopicTranslationProgress, event.TopicTranslationCompleted)
+	assert.NoError(t, err)
+
+	a1, stopA1 := hub.Watch("a")
+	a2, stopA2 := hub.Watch("a")
+	other, stopOther := hub.Watch("b")
+	defer stopA1()
+	defer stopOther()
+
+	assert.NoError(t, event.Publish(b, event.TopicTranslationProgress, &event.TaskEvent{TaskID: "a", Index: 1, Done: 1, Total: 2, Text: "你好"}))
+	for _, ch := range []<-chan *event.TaskEvent{a1, a2} {
+		select {
+		case e := <-ch:
+			assert.Equal(t, event.TopicTranslationProgress, e.Topic)
+			assert.Equal(t, "你好", e.Text)
+		case <-time.After(time.Second):
+			t.Fatal("progress event not received")
+		}
+	}
+
+	// 停止等待后不再收到事件
+	stopA2()
+	assert.NoError(t, event.Publish(b, event.TopicTranslationCompleted, &event.TaskEvent{TaskID: "a"}))
+	select {
+	case e := <-a1:
+		assert.Equal(t, event.TopicTranslationCompleted, e.Topic)
+	case <-time.After(time.Second):
+		t.Fatal("completed event not received")
+	}
+	assert.Empty(t, a2)
+	assert.Empty(t, other)
+}
+
+/**
+ * TestCanceller 测试通过广播取消正在执行的任务。
+ */
+func TestCanceller(t *testing.T) {
+	b := broker.NewMemoryBroker()
+	assert.NoError(t, b.Connect())
+	defer b.Disconnect()
+
+	canceller, err := event.NewCanceller(b, event.TopicOCRCancel)
+	assert.NoError(t, err)
+
+	ctx, done := canceller.Start("a")
+	defer done()
+	other, doneOther := canceller.Start("b")
+	defer doneOther()
+
+	// 不存在的任务直接忽略
+	assert.NoError(t, canceller.Cancel("c"))
+	assert.NoError(t, canceller.Cancel("a"))
+	select {
+	case <-ctx.Done():
+		assert.ErrorIs(t, context.Cause(ctx), event.ErrTaskCancelled)
+	case <-time.After(time.Second):
+		t.Fatal("task not cancelled")
+	}
+	assert.NoError(t, other.Err())
+}
@@ -0,0 +1,44 @@
+package event
+
+import (
+	"fmt"
+	"paper-translation/pkg/ds"
+	"paper-translation/pkg/errutil"
+
+	"github.com/nats-io/nats.go"
+	"go-micro.dev/v4/broker"
+	"go-micro.dev/v4/config" // 配置管理器
+)
+
+// 支持的 broker
+const (
+	DriverMemory = "memory"
+	DriverRedis  = "redis"
+	DriverNats   = "nats"
+)
+
+/**
+* 根据配置中的 broker.driver 创建并连接 broker
+* @param config - 配置管理器
+* @return 已连接的 broker
+ */
+func NewBroker(config config.Config) broker.Broker {
+
+	driver := config.Get("broker", "driver").String(DriverRedis) // 获取 broker 类型
+
+	var b broker.Broker
+	switch driver {
+	case DriverMemory:
+		b = broker.NewMemoryBroker()
+	case DriverRedis:
+		// 默认复用服务的 redis 地址
+		uri := config.Get("broker", "redis", "uri").String(config.Get("redis", "uri").String("redis://localhost:6379"))
+		b = NewRedisBroker(ds.MustGetRedisClient(uri))
+	case DriverNats:
+		b = NewNatsBroker(config.Get("broker", "nats", "addrs").StringSlice([]string{nats.DefaultURL})) // 获取 NATS 地址
+	default:
+		panic(fmt.Sprintf("unknown broker driver: %s", driver))
+	}
+	errutil.PanicIfErr(b.Connect())
+	return b
+}
@@ -0,0 +1,71 @@
+package glossary
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Term 术语表中的一条术语
+type Term struct {
+	Source        string `bson:"Source"`        // 原文术语
+	Target        string `bson:"Target"`        // 规定的译法，和原文相同时表示保留原文
+	CaseSensitive bool   `bson:"CaseSensitive"` // 原文匹配是否区分大小写
+}
+
+// Match 返回在原文中出现的术语，顺序和术语表相同。
+// 以字母或数字开头结尾的术语按整词匹配，避免 "attention" 匹配到 "inattention"。
+func Match(terms []Term, text string) []Term {
+	var matched []Term
+	for _, term := range terms {
+		if term.Source != "" && contains(text, term.Source, !term.CaseSensitive) {
+			matched = append(matched, term)
+		}
+	}
+	return matched
+}
+
+// Missing 返回译文中没有使用规定译法的术语。
+// 译法不区分大小写，也不要求整词出现，译文中的复数、词形变化不算缺少。
+func Missing(terms []Term, translated string) []Term {
+	translated = strings.ToLower(translated)
+	var missing []Term
+	for _, term := range terms {
+		if term.Target != "" && !strings.Contains(translated, strings.ToLower(term.Target)) {
+			missing = append(missing, term)
+		}
+	}
+	return missing
+}
+
+// contains 判断 text 中是否包含 term，term 的首尾是字母或数字时要求前后不能紧跟字母或数字
+func contains(text, term string, fold bool) bool {
+	if fold {
+		text, term = strings.ToLower(text), strings.ToLower(term)
+	}
+	first, _ := utf8.DecodeRuneInString(term)
+	last, _ := utf8.DecodeLastRuneInString(term)
+	for offset := 0; offset < len(text); {
+		i := strings.Index(text[offset:], term)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(term)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		if !(isWord(first) && start > 0 && isWord(before)) && !(isWord(last) && end < len(text) && isWord(after)) {
+			return true
+		}
+		_, width := utf8.DecodeRuneInString(text[start:])
+		offset = start + width
+	}
+	return false
+}
+
+// isWord 判断是否为以空格分隔单词的文字中的字母或数字，CJK 文字之间没有分隔，不需要整词匹配
+func isWord(r rune) bool {
+	if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+		return false
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
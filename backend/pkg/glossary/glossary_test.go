@@ -0,0 +1,39 @@
+package glossary_test
+
+import (
+	"paper-translation/pkg/glossary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var terms = []glossary.Term{
+	{Source: "attention", Target: "注意力"},
+	{Source: "Transformer", Target: "Transformer", CaseSensitive: true},
+	{Source: "BERT", Target: "BERT"},
+	{Source: "神经网络", Target: "neural network"},
+}
+
+/**
+ * TestMatch 测试按整词匹配原文中出现的术语。
+ */
+func TestMatch(t *testing.T) {
+	matched := glossary.Match(terms, "Self-Attention lets a transformer attend to BERT-style tokens.")
+	assert.Equal(t, []glossary.Term{terms[0], terms[2]}, matched)
+
+	// 单词的一部分不算匹配
+	assert.Empty(t, glossary.Match(terms, "Inattention and BERTology."))
+
+	// CJK 术语不需要整词匹配
+	assert.Equal(t, []glossary.Term{terms[3]}, glossary.Match(terms, "卷积神经网络"))
+}
+
+/**
+ * TestMissing 测试检查译文中缺少的规定译法。
+ */
+func TestMissing(t *testing.T) {
+	matched := glossary.Match(terms, "The Transformer relies on attention.")
+	assert.Empty(t, glossary.Missing(matched, "transformer 依赖注意力机制。"))
+	assert.Equal(t, []glossary.Term{terms[0]}, glossary.Missing(matched, "Transformer 依赖关注机制。"))
+	assert.Empty(t, glossary.Missing([]glossary.Term{terms[3]}, "Deep neural networks"))
+}
@@ -0,0 +1,215 @@
+package lang
+
+import (
+	"embed"
+	"math"
+	"path"
+	"strings"
+	"unicode"
+)
+
+// corpus 拉丁字母语言的训练文本，文件名为语言标签，启动时统计为三元组模型
+//
+//go:embed corpus/*.txt
+var corpus embed.FS
+
+// minLetters 检测需要的最少字母数，太短的文本无法可靠判断
+const minLetters = 20
+
+// maxTrigrams 检测时最多使用的三元组数，论文取开头部分已经足够判断
+const maxTrigrams = 4096
+
+// cjkWeight 一个汉字、假名或谚文相当于的拉丁字母数，中日韩文本中夹杂的公式和英文术语不会影响判断
+const cjkWeight = 3
+
+// scripts 由书写系统直接确定语言的文字，汉字和假名单独判断
+var scripts = []struct {
+	table *unicode.RangeTable
+	tag   string
+}{
+	{unicode.Hangul, "ko"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Greek, "el"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Thai, "th"},
+}
+
+// minMargin 拉丁字母文本中概率最大和第二大的语言平均每个三元组对数概率的最小差距，
+// 差距更小时说明训练文本不足以区分，不返回检测结果
+const minMargin = 0.15
+
+// simplified、traditional 简体和繁体中只在一方使用的常用字，按位置一一对应。
+// 两种字都没有出现时无法区分简繁
+const (
+	simplified = "这个们来时说国会为学对发经没过现动进种样开关长问还实点体机电当与从两应无产业数据论设计统结构类题标验证网络图书语义认识觉" +
+		"观视听读写历变区处总报级组织际维显线约响条节术资质传达运边远连选间门队陆阳阴难风飞马鱼鸟龙齐专东丝严乐习买乱争亚亲价众优" +
+		"伤备复头夺将尔层岁师带帮广庆库张归录忆态战执扩护担择换损摄旧权杂极树档检汉沟泽济测浓满灵炼热爱环画疗积称稳穷竞笔简纪纯纸" +
+		"练细终给绝继续综缩职联肤脑药获虑补装规览订训记讲许访评诉词译试话询该详误请课调谈谢负责购费赛转轮软较载输迁适递释针钱铁销" +
+		"错闭闻阶随险页项顺须领频额饭齿尽丰团属么"
+	traditional = "這個們來時說國會為學對發經沒過現動進種樣開關長問還實點體機電當與從兩應無產業數據論設計統結構類題標驗證網絡圖書語義認識覺" +
+		"觀視聽讀寫歷變區處總報級組織際維顯線約響條節術資質傳達運邊遠連選間門隊陸陽陰難風飛馬魚鳥龍齊專東絲嚴樂習買亂爭亞親價眾優" +
+		"傷備復頭奪將爾層歲師帶幫廣慶庫張歸錄憶態戰執擴護擔擇換損攝舊權雜極樹檔檢漢溝澤濟測濃滿靈煉熱愛環畫療積稱穩窮競筆簡紀純紙" +
+		"練細終給絕繼續綜縮職聯膚腦藥獲慮補裝規覽訂訓記講許訪評訴詞譯試話詢該詳誤請課調談謝負責購費賽轉輪軟較載輸遷適遞釋針錢鐵銷" +
+		"錯閉聞階隨險頁項順須領頻額飯齒盡豐團屬麼"
+)
+
+var hans, hant = runeSet(simplified), runeSet(traditional)
+
+func runeSet(s string) map[rune]bool {
+	set := make(map[rune]bool)
+	for _, r := range s {
+		set[r] = true
+	}
+	return set
+}
+
+// profile 一种语言的三元组模型
+type profile struct {
+	tag    string
+	logp   map[string]float64 // 三元组的对数概率
+	unseen float64            // 训练文本中没有出现的三元组的对数概率
+}
+
+var profiles = loadProfiles()
+
+// loadProfiles 统计训练文本的三元组，按加一平滑计算概率
+func loadProfiles() []*profile {
+	entries, err := corpus.ReadDir("corpus")
+	if err != nil {
+		panic(err)
+	}
+	counts := make([]map[string]int, len(entries))
+	vocabulary := make(map[string]struct{})
+	for i, entry := range entries {
+		data, err := corpus.ReadFile(path.Join("corpus", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		counts[i] = make(map[string]int)
+		eachTrigram(string(data), func(trigram string) bool {
+			counts[i][trigram]++
+			vocabulary[trigram] = struct{}{}
+			return true
+		})
+	}
+
+	var ps []*profile
+	for i, entry := range entries {
+		total := len(vocabulary)
+		for _, n := range counts[i] {
+			total += n
+		}
+		p := &profile{
+			tag:    strings.TrimSuffix(entry.Name(), path.Ext(entry.Name())),
+			logp:   make(map[string]float64, len(counts[i])),
+			unseen: math.Log(1 / float64(total)),
+		}
+		for trigram, n := range counts[i] {
+			p.logp[trigram] = math.Log(float64(n+1) / float64(total))
+		}
+		ps = append(ps, p)
+	}
+	return ps
+}
+
+// eachTrigram 依次返回文本中每个单词首尾补空格后的三元组，fc 返回 false 时停止
+func eachTrigram(text string, fc func(trigram string) bool) {
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool { return !unicode.IsLetter(r) }) {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			if !fc(string(runes[i : i+3])) {
+				return
+			}
+		}
+	}
+}
+
+// Detect 离线检测文本的语言，返回 BCP-47 标签，文本太短或无法判断时返回空字符串。
+// 先按文字判断，中文、日文、韩文等由文字直接确定，拉丁字母的文本再用三元组模型判断具体语言。
+// 中文按简繁特有的字返回 zh-Hans 或 zh-Hant，无法区分时返回不带书写系统的 zh
+func Detect(text string) string {
+	var latin, han, kana, hansCount, hantCount int
+	counts := make([]int, len(scripts))
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+		case unicode.Is(unicode.Han, r):
+			han++
+			if hans[r] {
+				hansCount++
+			} else if hant[r] {
+				hantCount++
+			}
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		default:
+			for i, script := range scripts {
+				if unicode.Is(script.table, r) {
+					counts[i]++
+					break
+				}
+			}
+		}
+	}
+
+	tag, best := "", 0
+	if (han+kana)*cjkWeight > best {
+		// 日文同时使用汉字和假名，假名占一成以上时判断为日文
+		tag, best = "zh", (han+kana)*cjkWeight
+		switch {
+		case kana*10 >= han+kana:
+			tag = "ja"
+		case hantCount > hansCount:
+			tag = "zh-Hant"
+		case hansCount > hantCount:
+			tag = "zh-Hans"
+		}
+	}
+	for i, script := range scripts {
+		n := counts[i]
+		if script.table == unicode.Hangul {
+			n *= cjkWeight
+		}
+		if n > best {
+			tag, best = script.tag, n
+		}
+	}
+	if latin > best {
+		tag, best = detectLatin(text), latin
+	}
+	if best < minLetters {
+		return ""
+	}
+	return tag
+}
+
+// detectLatin 用三元组模型判断拉丁字母文本的语言，返回概率最大的语言，和第二名差距太小时返回空字符串
+func detectLatin(text string) string {
+	scores := make([]float64, len(profiles))
+	n := 0
+	eachTrigram(text, func(trigram string) bool {
+		for i, p := range profiles {
+			if logp, ok := p.logp[trigram]; ok {
+				scores[i] += logp
+			} else {
+				scores[i] += p.unseen
+			}
+		}
+		n++
+		return n < maxTrigrams
+	})
+	best, second := 0, -1
+	for i := range scores {
+		if scores[i] > scores[best] {
+			best, second = i, best
+		} else if i != best && (second < 0 || scores[i] > scores[second]) {
+			second = i
+		}
+	}
+	if n == 0 || second >= 0 && (scores[best]-scores[second])/float64(n) < minMargin {
+		return ""
+	}
+	return profiles[best].tag
+}
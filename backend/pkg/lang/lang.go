@@ -0,0 +1,98 @@
+// Package lang 校验和规范化语言标签，并离线检测文本的语言
+package lang
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
+)
+
+// commonLanguages 可以按名称指定的语言，英文、中文和本地语言名称都可以解析为对应的标签
+var commonLanguages = []string{
+	"en", "zh", "zh-Hans", "zh-Hant", "ja", "ko", "fr", "de", "es", "it", "pt", "nl", "ru",
+	"ar", "el", "he", "hi", "th", "vi", "id", "ms", "tr", "pl", "uk", "cs", "sv", "da", "fi", "no", "hu", "ro",
+}
+
+// names 语言名称到标签的映射，键为小写
+var names = func() map[string]string {
+	m := map[string]string{
+		"汉语": "zh",
+		"中文": "zh",
+	}
+	for _, s := range commonLanguages {
+		tag := language.MustParse(s)
+		for _, name := range []string{
+			display.English.Languages().Name(tag),
+			display.Chinese.Languages().Name(tag),
+			display.Self.Name(tag),
+		} {
+			if name == "" {
+				continue
+			}
+			m[strings.ToLower(name)] = s
+			// 中文名称同时接受“语”和“文”两种说法，如英语和英文
+			if prefix, ok := strings.CutSuffix(name, "语"); ok {
+				m[prefix+"文"] = s
+			}
+		}
+	}
+	return m
+}()
+
+// Normalize 校验并规范化语言，返回规范的 BCP-47 标签。
+// 接受大小写和分隔符不规范的 BCP-47 标签（如 EN_us），以及常见语言的英文、中文和本地名称（如 English、英语、英文）。
+func Normalize(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if tag, ok := names[strings.ToLower(s)]; ok {
+		return tag, nil
+	}
+	tag, err := language.Parse(strings.ReplaceAll(s, "_", "-"))
+	if err != nil || tag == language.Und {
+		return "", fmt.Errorf("invalid language %q", s)
+	}
+	return tag.String(), nil
+}
+
+// Name 返回语言的中文名称，用于提示词，无法解析时原样返回
+func Name(s string) string {
+	tag, err := Normalize(s)
+	if err != nil {
+		return s
+	}
+	if name := display.Chinese.Languages().Name(language.Make(tag)); name != "" {
+		return name
+	}
+	return tag
+}
+
+// Match 判断两个语言是否为同一种语言：主语言相同且书写系统相同，不比较地区。
+// 没有指定书写系统时按最可能的书写系统比较，如 zh 和 zh-CN 相同，和 zh-TW 不同。无法解析时按原样比较
+func Match(a, b string) bool {
+	ta, errA := Normalize(a)
+	tb, errB := Normalize(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	x, y := language.Make(ta), language.Make(tb)
+	xBase, _ := x.Base()
+	yBase, _ := y.Base()
+	xScript, _ := x.Script()
+	yScript, _ := y.Script()
+	return xBase == yBase && xScript == yScript
+}
+
+// IsTarget 判断原文是否确定已经是目标语言，用于决定能否跳过翻译。
+// 和 Match 不同，原文的书写系统只能猜测时（如 zh 可能是简体也可能是繁体）不认为是目标语言
+func IsTarget(source, target string) bool {
+	if !Match(source, target) {
+		return false
+	}
+	tag, err := Normalize(source)
+	if err != nil {
+		return false
+	}
+	_, confidence := language.Make(tag).Script()
+	return confidence >= language.High
+}
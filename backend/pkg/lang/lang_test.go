@@ -0,0 +1,92 @@
+package lang_test
+
+import (
+	"paper-translation/pkg/lang"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/**
+ * TestNormalize 测试规范化标签和语言名称，以及拒绝无效的语言。
+ */
+func TestNormalize(t *testing.T) {
+	for input, want := range map[string]string{
+		"en":      "en",
+		"EN_us":   "en-US",
+		"English": "en",
+		"英语":      "en",
+		"英文":      "en",
+		"汉语":      "zh",
+		"中文":      "zh",
+		"zh-hans": "zh-Hans",
+		"日本語":     "ja",
+		"Deutsch": "de",
+		"西班牙语":    "es",
+	} {
+		tag, err := lang.Normalize(input)
+		assert.Nil(t, err, input)
+		assert.Equal(t, want, tag, input)
+	}
+
+	for _, input := range []string{"", "und", "english language", "x", "英格兰话"} {
+		_, err := lang.Normalize(input)
+		assert.NotNil(t, err, input)
+	}
+}
+
+/**
+ * TestNameAndMatch 测试提示词中的语言名称和语言比较。
+ */
+func TestNameAndMatch(t *testing.T) {
+	assert.Equal(t, "英语", lang.Name("en"))
+	assert.Equal(t, "日语", lang.Name("Japanese"))
+	assert.Equal(t, "klingon?", lang.Name("klingon?"))
+
+	assert.True(t, lang.Match("en", "en-GB"))
+	assert.True(t, lang.Match("英语", "en-US"))
+	assert.True(t, lang.Match("zh", "zh-CN"))
+	assert.False(t, lang.Match("zh", "zh-TW"))
+	assert.False(t, lang.Match("en", "de"))
+
+	assert.True(t, lang.IsTarget("en", "en-US"))
+	assert.True(t, lang.IsTarget("zh-Hans", "zh-CN"))
+	assert.True(t, lang.IsTarget("zh-Hant", "zh-TW"))
+	assert.False(t, lang.IsTarget("zh", "zh-Hans"))
+	assert.False(t, lang.IsTarget("zh-Hant", "zh-Hans"))
+	assert.False(t, lang.IsTarget("en", "de"))
+}
+
+/**
+ * TestDetect 测试按文字和三元组模型检测语言。
+ */
+func TestDetect(t *testing.T) {
+	for want, text := range map[string]string{
+		"en":      "We evaluate the proposed model on three public benchmarks and compare it with strong baselines.",
+		"fr":      "Nous évaluons le modèle proposé sur trois jeux de données publics et le comparons avec des méthodes de référence.",
+		"de":      "Wir bewerten das vorgeschlagene Modell auf drei öffentlichen Datensätzen und vergleichen es mit starken Verfahren.",
+		"es":      "Evaluamos el modelo propuesto en tres conjuntos de datos públicos y lo comparamos con métodos de referencia.",
+		"it":      "Valutiamo il modello proposto su tre insiemi di dati pubblici e lo confrontiamo con metodi di riferimento.",
+		"pt":      "Avaliamos o modelo proposto em três conjuntos de dados públicos e o comparamos com métodos de referência.",
+		"nl":      "We evalueren het voorgestelde model op drie openbare datasets en vergelijken het met sterke methoden.",
+		"zh-Hans": "我们在三个公开数据集上评估了所提出的模型，并与 Transformer 等基线方法进行了比较。",
+		"zh-Hant": "我們在三個公開資料集上評估了所提出的模型，並與 Transformer 等基線方法進行了比較。",
+		"ja":      "提案したモデルを三つの公開データセットで評価し、既存の手法と比較した。",
+		"ko":      "제안한 모델을 세 개의 공개 데이터셋에서 평가하고 기존 방법과 비교하였다.",
+		"ru":      "Мы оцениваем предложенную модель на трёх открытых наборах данных и сравниваем её с базовыми методами.",
+	} {
+		assert.Equal(t, want, lang.Detect(text), text)
+	}
+	assert.Equal(t, "", lang.Detect("x = y + 1"))
+}
+
+/**
+ * TestDetectMixed 测试混合多种语言的文本：以一种语言为主时返回该语言，
+ * 拉丁字母语言各占一半无法区分时不返回结果。
+ */
+func TestDetectMixed(t *testing.T) {
+	assert.Equal(t, "zh-Hans", lang.Detect("本文提出的 Graph Attention Network 在 ImageNet 和 COCO 上都取得了最好的结果，代码见 GitHub。"))
+	assert.Equal(t, "zh-Hant", lang.Detect("本文提出的 Graph Attention Network 在 ImageNet 和 COCO 上都取得了最好的結果，程式碼見 GitHub。"))
+	assert.Equal(t, "en", lang.Detect("As noted in the original survey, the model is called “模型” in Chinese, and it performs well on all of the public benchmarks we tested."))
+	assert.Equal(t, "", lang.Detect("The results show that the method converges quickly. Die Ergebnisse zeigen, dass das Verfahren schnell konvergiert."))
+}
@@ -0,0 +1,171 @@
+package llm
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// OpenAIProvider 兼容 OpenAI Chat Completions 接口的大模型，如 OpenAI、vLLM、Ollama 等自建服务。
+type OpenAIProvider struct {
+	baseURL        string
+	apiKey         string
+	model          string
+	temperature    float64
+	maxTokens      int64
+	maxConcurrency int
+	contextWindow  int
+	httpClient     *http.Client
+}
+
+// NewOpenAIProvider 创建提供方。
+//
+// 参数:
+// - baseURL string: 接口地址，如 https://api.openai.com/v1、http://vllm:8000/v1、http://ollama:11434/v1。
+// - apiKey string: 鉴权密钥，自建服务可以为空。
+// - model string: 模型名称。
+// - temperature float64: 采样温度。
+// - maxTokens int64: 最大回复 token 数。
+// - maxConcurrency int: 最大并发数。
+// - contextWindow int: 模型的上下文窗口大小。
+func NewOpenAIProvider(baseURL, apiKey, model string, temperature float64, maxTokens int64, maxConcurrency, contextWindow int) *OpenAIProvider {
+	return &OpenAIProvider{
+		baseURL:        strings.TrimRight(baseURL, "/"),
+		apiKey:         apiKey,
+		model:          model,
+		temperature:    temperature,
+		maxTokens:      maxTokens,
+		maxConcurrency: maxConcurrency,
+		contextWindow:  contextWindow,
+		httpClient:     &http.Client{},
+	}
+}
+
+// Name 以模型名区分信号量，不同模型的并发配额互不影响。
+func (t *OpenAIProvider) Name() string {
+	return "openai-" + t.model
+}
+
+// MaxConcurrency 返回最大并发数。
+func (t *OpenAIProvider) MaxConcurrency() int {
+	return t.maxConcurrency
+}
+
+// Tokenizer 按 cl100k 词表的切分规则估算。
+func (t *OpenAIProvider) Tokenizer() Tokenizer {
+	return CL100KEstimator{}
+}
+
+// ContextWindow 返回上下文窗口大小。
+func (t *OpenAIProvider) ContextWindow() int {
+	return t.contextWindow
+}
+
+// MaxTokens 返回最大回复 token 数。
+func (t *OpenAIProvider) MaxTokens() int {
+	return int(t.maxTokens)
+}
+
+// chatMessage 是对话消息。
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatRequest 是 /chat/completions 的请求体。
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature float64       `json:"temperature"`
+	MaxTokens   int64         `json:"max_tokens,omitempty"`
+	Stream      bool          `json:"stream"`
+}
+
+// chatChunk 是流式返回的一个分片。
+type chatChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// CreateChat 以 SSE 流式方式调用 /chat/completions。
+func (t *OpenAIProvider) CreateChat(ctx context.Context, messages []Message, fc func(text string)) error {
+	var history []chatMessage
+	for _, message := range messages {
+		history = append(history, chatMessage{Role: message.Role, Content: message.Content})
+	}
+	body, err := json.Marshal(chatRequest{
+		Model:       t.model,
+		Messages:    history,
+		Temperature: t.temperature,
+		MaxTokens:   t.maxTokens,
+		Stream:      true,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	if t.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+t.apiKey)
+	}
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("chat completions failed: code=%d,body=%s", resp.StatusCode, string(msg))
+	}
+	return t.readEvents(resp.Body, fc)
+}
+
+// readEvents 逐行读取 SSE 事件，直到 [DONE] 或连接结束。
+func (t *OpenAIProvider) readEvents(reader io.Reader, fc func(text string)) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			return nil
+		}
+
+		var chunk chatChunk
+		err := json.Unmarshal([]byte(data), &chunk)
+		if err != nil {
+			return err
+		}
+		if chunk.Error != nil {
+			return errors.New(chunk.Error.Message)
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Delta.Content != "" {
+				fc(choice.Delta.Content)
+			}
+		}
+	}
+	return scanner.Err()
+}
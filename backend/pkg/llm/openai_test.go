@@ -0,0 +1,71 @@
+package llm_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"paper-translation/pkg/llm"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/**
+ * TestOpenAIProvider_CreateChat 用一个模拟的 SSE 服务测试请求参数和流式解析。
+ */
+func TestOpenAIProvider_CreateChat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+
+		var req map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "qwen", req["model"])
+		assert.Equal(t, 0.2, req["temperature"])
+		assert.Equal(t, float64(512), req["max_tokens"])
+		assert.Equal(t, true, req["stream"])
+		assert.Equal(t, []any{
+			map[string]any{"role": "user", "content": "hi"},
+			map[string]any{"role": "assistant", "content": "你好"},
+			map[string]any{"role": "user", "content": "hello"},
+		}, req["messages"])
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, text := range []string{"你好", "，", "世界"} {
+			fmt.Fprintf(w, "data: {\"choices\":[{\"delta\":{\"content\":%q}}]}\n\n", text)
+		}
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	provider := llm.NewOpenAIProvider(server.URL+"/v1/", "key", "qwen", 0.2, 512, 4, 4096)
+	var buf strings.Builder
+	messages := []llm.Message{
+		{Role: llm.RoleUser, Content: "hi"},
+		{Role: llm.RoleAssistant, Content: "你好"},
+		{Role: llm.RoleUser, Content: "hello"},
+	}
+	err := provider.CreateChat(context.Background(), messages, func(text string) {
+		buf.WriteString(text)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "你好，世界", buf.String())
+	assert.Equal(t, "openai-qwen", provider.Name())
+}
+
+/**
+ * TestOpenAIProvider_CreateChatError 测试非 200 响应会返回错误。
+ */
+func TestOpenAIProvider_CreateChatError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "model not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	provider := llm.NewOpenAIProvider(server.URL, "", "none", 0.2, 512, 4, 4096)
+	err := provider.CreateChat(context.Background(), []llm.Message{{Role: llm.RoleUser, Content: "hello"}}, func(text string) {})
+	assert.ErrorContains(t, err, "model not found")
+}
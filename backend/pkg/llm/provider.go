@@ -0,0 +1,41 @@
+package llm
+
+import "context"
+
+// 消息的角色
+const (
+	RoleUser      = "user"      // 用户
+	RoleAssistant = "assistant" // 模型
+)
+
+// Message 多轮对话中的一条消息
+type Message struct {
+	Role    string
+	Content string
+}
+
+// ChatProvider 大模型对话接口，翻译服务通过它调用不同厂商或自建的大模型。
+type ChatProvider interface {
+
+	// Name 返回提供方名称，同时作为分布式并发信号量的名称
+	Name() string
+
+	// MaxConcurrency 返回提供方允许的最大并发数
+	MaxConcurrency() int
+
+	// Tokenizer 返回估算该模型 token 数的分词器
+	Tokenizer() Tokenizer
+
+	// ContextWindow 返回模型的上下文窗口大小，包括提示词和回复
+	ContextWindow() int
+
+	// MaxTokens 返回最大回复 token 数
+	MaxTokens() int
+
+	// CreateChat 发起一次对话，模型的回复以流式分段通过 fc 回调
+	// @param ctx - context
+	// @param messages - 按时间顺序排列的多轮对话，用户和模型的消息交替出现，最后一条为本次的用户输入
+	// @param fc - 回复文本回调
+	// @return error
+	CreateChat(ctx context.Context, messages []Message, fc func(text string)) error
+}
@@ -0,0 +1,101 @@
+package llm
+
+import (
+	"math"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Tokenizer 估算文本在模型中占用的 token 数，翻译服务用它按模型的上下文窗口切分待翻译文本。
+type Tokenizer interface {
+	Count(text string) int
+}
+
+// CJKTokenizer 按字符类别估算 token 数。
+// 汉字、假名、谚文等没有空格分隔的文字按字符计数，其他文字按单词计数，标点符号各计一个。
+type CJKTokenizer struct {
+	CharsPerToken float64 // 每个 token 对应的 CJK 字符数
+	WordsPerToken float64 // 每个 token 对应的单词数
+}
+
+// Count 估算 token 数
+func (t CJKTokenizer) Count(text string) int {
+	var chars, words, puncts int
+	var inWord bool
+	for _, r := range text {
+		switch {
+		case isCJK(r):
+			chars++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if !inWord {
+				words++
+			}
+			inWord = true
+		default:
+			inWord = false
+			if !unicode.IsSpace(r) {
+				puncts++
+			}
+		}
+	}
+	return int(math.Ceil(float64(chars)/t.CharsPerToken+float64(words)/t.WordsPerToken)) + puncts
+}
+
+// CL100KEstimator 按 cl100k 词表的预切分规则估算 token 数，适用于 OpenAI 风格的模型。
+// 它不是 BPE 编码器，不加载词表，只按片段的类别和长度估算：英文单词连同前面的空格通常是一个 token，
+// 长单词约每5个字母一个 token；数字每3位一个 token；CJK 和其他非 ASCII 文字约每个字符一个 token；
+// 标点每个一个 token；连续的换行合并为一个 token。
+//
+// 与真实的 cl100k 编码相比，英文正文的误差通常在 ±25% 以内：常见长单词在词表中是一个 token，会被高估，
+// 罕见词和拼写变体会被低估。中文常用字大多是一个 token，生僻字和部分日韩文字是 2 到 3 个 token，
+// 这类文本会被低估，最多约一半。切分预算应按这个误差留出余量。
+type CL100KEstimator struct{}
+
+// Count 估算 token 数
+func (t CL100KEstimator) Count(text string) int {
+	var count int
+	for len(text) > 0 {
+		r, _ := utf8.DecodeRuneInString(text)
+		var n int
+		switch {
+		case isCJK(r):
+			n = prefixLen(text, isCJK)
+			count += utf8.RuneCountInString(text[:n])
+		case r < utf8.RuneSelf && unicode.IsLetter(r):
+			n = prefixLen(text, func(r rune) bool { return r < utf8.RuneSelf && unicode.IsLetter(r) })
+			count += 1 + (n-1)/5
+		case unicode.IsLetter(r):
+			n = prefixLen(text, func(r rune) bool { return !isCJK(r) && unicode.IsLetter(r) })
+			count += utf8.RuneCountInString(text[:n])
+		case unicode.IsDigit(r):
+			n = prefixLen(text, unicode.IsDigit)
+			count += (utf8.RuneCountInString(text[:n]) + 2) / 3
+		case r == '\n' || r == '\r':
+			n = prefixLen(text, unicode.IsSpace)
+			count++
+		case unicode.IsSpace(r):
+			// 空格合并到后面的单词中
+			_, n = utf8.DecodeRuneInString(text)
+		default:
+			_, n = utf8.DecodeRuneInString(text)
+			count++
+		}
+		text = text[n:]
+	}
+	return count
+}
+
+// prefixLen 返回 text 开头连续满足 f 的字符的字节数
+func prefixLen(text string, f func(rune) bool) int {
+	for i, r := range text {
+		if !f(r) {
+			return i
+		}
+	}
+	return len(text)
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
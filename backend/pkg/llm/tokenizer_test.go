@@ -0,0 +1,39 @@
+package llm_test
+
+import (
+	"paper-translation/pkg/llm"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/**
+ * TestCJKTokenizer 测试没有空格分隔的中文也能按字符估算 token 数。
+ */
+func TestCJKTokenizer(t *testing.T) {
+	tokenizer := llm.CJKTokenizer{CharsPerToken: 1.5, WordsPerToken: 0.8}
+
+	// 6个汉字 + 2个标点
+	assert.Equal(t, 6, tokenizer.Count("你好世界，再见。"))
+	// 4个单词 + 1个标点
+	assert.Equal(t, 6, tokenizer.Count("Hello world, 2023 edition"))
+	// 按空格分词时整段中文只算一个词，这里随长度增长
+	assert.Greater(t, tokenizer.Count(strings.Repeat("翻译", 1000)), 1000)
+	assert.Equal(t, 0, tokenizer.Count(" \n\t"))
+}
+
+/**
+ * TestCL100KEstimator 测试按 cl100k 预切分规则估算 token 数。
+ */
+func TestCL100KEstimator(t *testing.T) {
+	tokenizer := llm.CL100KEstimator{}
+
+	assert.Equal(t, 4, tokenizer.Count("Hello world!\n\n"))
+	// 长单词按每5个字母一个 token 估算
+	assert.Equal(t, 4, tokenizer.Count("internationalization"))
+	// 数字每3位一个 token
+	assert.Equal(t, 3, tokenizer.Count("1234567"))
+	assert.Equal(t, 5, tokenizer.Count("你好，世界"))
+	assert.Equal(t, 0, tokenizer.Count(""))
+}
@@ -0,0 +1,52 @@
+package llm
+
+import (
+	"fmt"
+	xfspark "paper-translation/pkg/xf-spark"
+
+	"go-micro.dev/v4/config" // 配置管理器
+)
+
+// 支持的大模型提供方
+const (
+	ProviderXFSpark = "xfspark"
+	ProviderOpenAI  = "openai"
+)
+
+/**
+* 根据配置中的 llm.provider 创建大模型提供方
+* @param config - 配置管理器
+* @return 大模型提供方
+ */
+func NewChatProvider(config config.Config) ChatProvider {
+
+	provider := config.Get("llm", "provider").String(ProviderXFSpark) // 获取提供方
+
+	switch provider {
+	case ProviderXFSpark:
+		client := xfspark.NewXFSpark(config)
+		client.SetChatOptions(xfspark.ChatOptions{
+			Domain:      config.Get("llm", "model").String(xfspark.DefaultChatOptions.Domain),             // 获取模型版本
+			Temperature: config.Get("llm", "temperature").Float64(xfspark.DefaultChatOptions.Temperature), // 获取采样温度
+			TopK:        xfspark.DefaultChatOptions.TopK,
+			MaxTokens:   int64(config.Get("llm", "max_tokens").Int(int(xfspark.DefaultChatOptions.MaxTokens))), // 获取最大回复 token 数
+		})
+		return NewXFSparkProvider(
+			client,
+			config.Get("llm", "concurrency").Int(2),
+			config.Get("llm", "context_window").Int(8192), // 获取上下文窗口大小
+		)
+	case ProviderOpenAI:
+		return NewOpenAIProvider(
+			config.Get("llm", "openai", "base_url").String("https://api.openai.com/v1"), // 获取接口地址
+			config.Get("llm", "openai", "api_key").String(""),                           // 获取鉴权密钥
+			config.Get("llm", "model").String("gpt-3.5-turbo"),                          // 获取模型名称
+			config.Get("llm", "temperature").Float64(0.3),                               // 获取采样温度
+			int64(config.Get("llm", "max_tokens").Int(2048)),                            // 获取最大回复 token 数
+			config.Get("llm", "concurrency").Int(4),                                     // 获取最大并发数
+			config.Get("llm", "context_window").Int(4096),                               // 获取上下文窗口大小
+		)
+	default:
+		panic(fmt.Sprintf("unknown llm provider: %s", provider))
+	}
+}
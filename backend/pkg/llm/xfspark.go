@@ -0,0 +1,52 @@
+package llm
+
+import (
+	"context"
+	xfspark "paper-translation/pkg/xf-spark"
+)
+
+// XFSparkProvider 讯飞星火大模型。
+type XFSparkProvider struct {
+	client         *xfspark.XFSparkClient
+	maxConcurrency int
+	contextWindow  int
+}
+
+// NewXFSparkProvider 使用已有的星火客户端创建提供方。
+func NewXFSparkProvider(client *xfspark.XFSparkClient, maxConcurrency, contextWindow int) *XFSparkProvider {
+	return &XFSparkProvider{client: client, maxConcurrency: maxConcurrency, contextWindow: contextWindow}
+}
+
+// Name 沿用原来的信号量名称，保证新旧实例共用同一个并发配额。
+func (t *XFSparkProvider) Name() string {
+	return "xf-spark"
+}
+
+// MaxConcurrency 讯飞只给2并发。
+func (t *XFSparkProvider) MaxConcurrency() int {
+	return t.maxConcurrency
+}
+
+// Tokenizer 按星火的计费规则估算：1 token 约等于 1.5 个汉字或 0.8 个英文单词。
+func (t *XFSparkProvider) Tokenizer() Tokenizer {
+	return CJKTokenizer{CharsPerToken: 1.5, WordsPerToken: 0.8}
+}
+
+// ContextWindow 返回上下文窗口大小。
+func (t *XFSparkProvider) ContextWindow() int {
+	return t.contextWindow
+}
+
+// MaxTokens 返回星火客户端配置的最大回复 token 数。
+func (t *XFSparkProvider) MaxTokens() int {
+	return int(t.client.ChatOptions().MaxTokens)
+}
+
+// CreateChat 发起对话。
+func (t *XFSparkProvider) CreateChat(ctx context.Context, messages []Message, fc func(text string)) error {
+	var history []xfspark.Message
+	for _, message := range messages {
+		history = append(history, xfspark.Message{Role: message.Role, Content: message.Content})
+	}
+	return t.client.CreateChatMessages(ctx, history, fc)
+}
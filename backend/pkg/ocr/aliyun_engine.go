@@ -0,0 +1,97 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/alibabacloud-go/ocr-api-20210707/client" // 阿里云OCR SDK
+	"github.com/alibabacloud-go/tea/tea"                 // 阿里云Tea工具库
+)
+
+// aliYunMultiLanguages 语言代码到阿里云多语种识别 Languages 参数的映射
+var aliYunMultiLanguages = map[string]string{
+	"ja": "ja",
+	"ko": "kor",
+	"ru": "eluosi",
+	"el": "xila",
+	"th": "taiwen",
+	"fr": "lading",
+	"de": "lading",
+	"es": "lading",
+	"it": "lading",
+	"pt": "lading",
+	"nl": "lading",
+}
+
+// AliYunOCREngine 基于阿里云文字识别的引擎，图片内容直接随请求上传，不需要先放到对象存储。
+type AliYunOCREngine struct {
+	client *client.Client
+}
+
+// NewAliYunOCREngine 使用已有的阿里云OCR客户端创建引擎。
+func NewAliYunOCREngine(client *client.Client) *AliYunOCREngine {
+	return &AliYunOCREngine{client: client}
+}
+
+// Name 返回引擎名称
+func (t *AliYunOCREngine) Name() string {
+	return EngineAliYun
+}
+
+// Recognize 英文走英语专项识别，中文走通用识别，其他语言走多语种识别。
+func (t *AliYunOCREngine) Recognize(ctx context.Context, image []byte, language string) (*Result, error) {
+	var data *string
+	switch language {
+	case "", "en":
+		resp, err := t.client.RecognizeEnglish(&client.RecognizeEnglishRequest{Body: bytes.NewReader(image)})
+		if err != nil {
+			return nil, err
+		}
+		data = resp.Body.Data
+	case "zh":
+		resp, err := t.client.RecognizeGeneral(&client.RecognizeGeneralRequest{Body: bytes.NewReader(image)})
+		if err != nil {
+			return nil, err
+		}
+		data = resp.Body.Data
+	default:
+		lang, ok := aliYunMultiLanguages[language]
+		if !ok {
+			return nil, errors.New("aliyun ocr does not support language: " + language)
+		}
+		resp, err := t.client.RecognizeMultiLanguage(&client.RecognizeMultiLanguageRequest{
+			Languages: []*string{tea.String(lang)},
+			Body:      bytes.NewReader(image),
+		})
+		if err != nil {
+			return nil, err
+		}
+		data = resp.Body.Data
+	}
+
+	if data == nil {
+		return nil, errors.New("aliyun ocr returns empty data")
+	}
+
+	// 解析OCR响应数据，prism_wordsInfo 中是每个文字块的置信度（0 到 100）
+	var result struct {
+		Content string `json:"content"`
+		Words   []struct {
+			Prob float64 `json:"prob"`
+		} `json:"prism_wordsInfo"`
+	}
+	err := json.Unmarshal([]byte(*data), &result)
+	if err != nil {
+		return nil, err
+	}
+	var confidence float64
+	for _, word := range result.Words {
+		confidence += word.Prob
+	}
+	if len(result.Words) > 0 {
+		confidence /= float64(len(result.Words)) * 100
+	}
+	return &Result{Text: result.Content, Confidence: confidence}, nil
+}
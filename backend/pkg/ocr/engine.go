@@ -0,0 +1,23 @@
+package ocr
+
+import "context"
+
+// Result 一张图片的识别结果
+type Result struct {
+	Text       string  // 识别文本
+	Confidence float64 // 所有文字的平均置信度，0 到 1，没有识别出文字时为 0
+}
+
+// OCREngine 文字识别引擎接口，输入图片内容和语言，返回识别出的文本。
+type OCREngine interface {
+
+	// Name 引擎名称，与配置 ocr.engine 的取值相同
+	Name() string
+
+	// Recognize 识别一张图片
+	// @param ctx - context
+	// @param image - 图片内容
+	// @param language - 图片中文字的语言，如 en、zh，为空时由引擎自行决定
+	// @return 识别结果, error
+	Recognize(ctx context.Context, image []byte, language string) (*Result, error)
+}
@@ -0,0 +1,102 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// tesseractLanguages 语言代码到 tesseract 语言包名称的映射
+var tesseractLanguages = map[string]string{
+	"en": "eng",
+	"zh": "chi_sim",
+	"ja": "jpn",
+	"ko": "kor",
+	"fr": "fra",
+	"de": "deu",
+	"es": "spa",
+	"it": "ita",
+	"pt": "por",
+	"ru": "rus",
+	"nl": "nld",
+}
+
+// TesseractOCREngine 调用本地 tesseract 命令识别图片，可以完全离线运行。
+type TesseractOCREngine struct {
+	path            string // tesseract 可执行文件路径
+	defaultLanguage string // 未指定语言时使用的 tesseract 语言包，如 eng
+}
+
+// NewTesseractOCREngine 创建 tesseract 引擎。
+func NewTesseractOCREngine(path, defaultLanguage string) *TesseractOCREngine {
+	return &TesseractOCREngine{path: path, defaultLanguage: defaultLanguage}
+}
+
+// Name 返回引擎名称
+func (t *TesseractOCREngine) Name() string {
+	return EngineTesseract
+}
+
+// Recognize 通过 stdin 把图片交给 tesseract，同时输出文本和带有每个单词置信度的 TSV。
+func (t *TesseractOCREngine) Recognize(ctx context.Context, image []byte, language string) (*Result, error) {
+	lang := t.defaultLanguage
+	if language != "" {
+		lang = tesseractLanguages[language]
+		if lang == "" {
+			// 未知语言直接当作 tesseract 语言包名称，方便使用 chi_tra 之类的细分语言包
+			lang = language
+		}
+	}
+
+	// 同时输出两种格式时只能写到文件，tesseract 在输出路径后加上 .txt 和 .tsv
+	dir, err := os.MkdirTemp("", "tesseract-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	output := filepath.Join(dir, "page")
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, t.path, "stdin", output, "-l", lang, "txt", "tsv")
+	cmd.Stdin = bytes.NewReader(image)
+	cmd.Stderr = &stderr
+	err = cmd.Run()
+	if err != nil {
+		return nil, fmt.Errorf("tesseract failed: %w, stderr: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	text, err := os.ReadFile(output + ".txt")
+	if err != nil {
+		return nil, err
+	}
+	// 没有 TSV 时置信度为 0，不影响识别结果
+	tsv, _ := os.ReadFile(output + ".tsv")
+	return &Result{Text: string(text), Confidence: tsvConfidence(tsv)}, nil
+}
+
+// tsvConfidence 计算 TSV 中所有单词置信度的平均值。
+// 第一行是表头，最后两列是置信度（0 到 100，不是单词的行为 -1）和文字
+func tsvConfidence(tsv []byte) float64 {
+	var sum float64
+	var words int
+	for i, line := range strings.Split(string(tsv), "\n") {
+		fields := strings.Split(line, "\t")
+		if i == 0 || len(fields) < 12 || strings.TrimSpace(fields[11]) == "" {
+			continue
+		}
+		conf, err := strconv.ParseFloat(fields[10], 64)
+		if err != nil || conf < 0 {
+			continue
+		}
+		sum += conf
+		words++
+	}
+	if words == 0 {
+		return 0
+	}
+	return sum / float64(words) / 100
+}
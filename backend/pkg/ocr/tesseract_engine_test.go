@@ -0,0 +1,40 @@
+package ocr_test
+
+import (
+	"context"
+	"os"
+	"paper-translation/pkg/ocr"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/**
+ * TestTesseractOCREngine 用一个假的 tesseract 脚本测试参数传递、置信度和错误处理。
+ * 脚本把语言参数和 stdin 内容原样写到文本输出，TSV 中有两个单词和一个非单词行。
+ */
+func TestTesseractOCREngine(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "tesseract")
+	tsv := "level\\tpage_num\\tblock_num\\tpar_num\\tline_num\\tword_num\\tleft\\ttop\\twidth\\theight\\tconf\\ttext\\n" +
+		"4\\t1\\t1\\t1\\t1\\t0\\t0\\t0\\t10\\t5\\t-1\\t\\n" +
+		"5\\t1\\t1\\t1\\t1\\t1\\t0\\t0\\t5\\t5\\t90\\ta\\n" +
+		"5\\t1\\t1\\t1\\t1\\t2\\t5\\t0\\t5\\t5\\t70\\tb\\n"
+	err := os.WriteFile(script, []byte("#!/bin/sh\n[ \"$4\" = bad ] && echo 'no language' >&2 && exit 1\n"+
+		"{ printf '%s:' \"$4\"; cat; } > \"$2.txt\"\nprintf '"+tsv+"' > \"$2.tsv\"\n"), 0755)
+	assert.NoError(t, err)
+
+	engine := ocr.NewTesseractOCREngine(script, "eng")
+
+	result, err := engine.Recognize(context.Background(), []byte("image"), "")
+	assert.NoError(t, err)
+	assert.Equal(t, "eng:image", result.Text)
+	assert.InDelta(t, 0.8, result.Confidence, 1e-9)
+
+	result, err = engine.Recognize(context.Background(), []byte("image"), "zh")
+	assert.NoError(t, err)
+	assert.Equal(t, "chi_sim:image", result.Text)
+
+	_, err = engine.Recognize(context.Background(), []byte("image"), "bad")
+	assert.ErrorContains(t, err, "no language")
+}
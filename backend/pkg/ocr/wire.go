@@ -1,6 +1,8 @@
 package ocr
 
 import (
+	"fmt"
+
 	"github.com/alibabacloud-go/ocr-api-20210707/client" // 阿里云OCR SDK
 	"go-micro.dev/v4/config"                             // 配置管理器
 )
@@ -18,3 +20,30 @@ func NewAliYunOCR(config config.Config) *client.Client {
 
 	return MustGetAliYunOCR(region, keyID, secret) // 获取客户端
 }
+
+// 支持的OCR引擎
+const (
+	EngineAliYun    = "aliyun"
+	EngineTesseract = "tesseract"
+)
+
+/**
+* 根据配置中的 ocr.engine 创建OCR引擎
+* @param config - 配置管理器
+* @return OCR引擎
+ */
+func NewOCREngine(config config.Config) OCREngine {
+
+	engine := config.Get("ocr", "engine").String(EngineAliYun) // 获取OCR引擎
+
+	switch engine {
+	case EngineAliYun:
+		return NewAliYunOCREngine(NewAliYunOCR(config))
+	case EngineTesseract:
+		path := config.Get("ocr", "tesseract", "path").String("tesseract")   // 获取tesseract路径
+		language := config.Get("ocr", "tesseract", "language").String("eng") // 获取默认语言包
+		return NewTesseractOCREngine(path, language)
+	default:
+		panic(fmt.Sprintf("unknown ocr engine: %s", engine))
+	}
+}
@@ -0,0 +1,368 @@
+package pdf
+
+import "errors"
+
+// CCITT 传真编码，扫描件中的黑白图片常用。支持一维（K=0）、混合（K>0）和二维（K<0，即 Group 4）编码
+
+// ccittCode 变长编码表中的一项，run 为游程长度，或为负数表示二维模式
+type ccittCode struct {
+	bits string
+	run  int
+}
+
+// 二维模式
+const (
+	modePass = -1 - iota
+	modeHorizontal
+	modeV0
+	modeVR1
+	modeVR2
+	modeVR3
+	modeVL1
+	modeVL2
+	modeVL3
+	modeEOL
+)
+
+var whiteCodes = []ccittCode{
+	{"00110101", 0}, {"000111", 1}, {"0111", 2}, {"1000", 3}, {"1011", 4}, {"1100", 5}, {"1110", 6}, {"1111", 7},
+	{"10011", 8}, {"10100", 9}, {"00111", 10}, {"01000", 11}, {"001000", 12}, {"000011", 13}, {"110100", 14},
+	{"110101", 15}, {"101010", 16}, {"101011", 17}, {"0100111", 18}, {"0001100", 19}, {"0001000", 20},
+	{"0010111", 21}, {"0000011", 22}, {"0000100", 23}, {"0101000", 24}, {"0101011", 25}, {"0010011", 26},
+	{"0100100", 27}, {"0011000", 28}, {"00000010", 29}, {"00000011", 30}, {"00011010", 31}, {"00011011", 32},
+	{"00010010", 33}, {"00010011", 34}, {"00010100", 35}, {"00010101", 36}, {"00010110", 37}, {"00010111", 38},
+	{"00101000", 39}, {"00101001", 40}, {"00101010", 41}, {"00101011", 42}, {"00101100", 43}, {"00101101", 44},
+	{"00000100", 45}, {"00000101", 46}, {"00001010", 47}, {"00001011", 48}, {"01010010", 49}, {"01010011", 50},
+	{"01010100", 51}, {"01010101", 52}, {"00100100", 53}, {"00100101", 54}, {"01011000", 55}, {"01011001", 56},
+	{"01011010", 57}, {"01011011", 58}, {"01001010", 59}, {"01001011", 60}, {"00110010", 61}, {"00110011", 62},
+	{"00110100", 63},
+	{"11011", 64}, {"10010", 128}, {"010111", 192}, {"0110111", 256}, {"00110110", 320}, {"00110111", 384},
+	{"01100100", 448}, {"01100101", 512}, {"01101000", 576}, {"01100111", 640}, {"011001100", 704},
+	{"011001101", 768}, {"011010010", 832}, {"011010011", 896}, {"011010100", 960}, {"011010101", 1024},
+	{"011010110", 1088}, {"011010111", 1152}, {"011011000", 1216}, {"011011001", 1280}, {"011011010", 1344},
+	{"011011011", 1408}, {"010011000", 1472}, {"010011001", 1536}, {"010011010", 1600}, {"011000", 1664},
+	{"010011011", 1728},
+}
+
+var blackCodes = []ccittCode{
+	{"0000110111", 0}, {"010", 1}, {"11", 2}, {"10", 3}, {"011", 4}, {"0011", 5}, {"0010", 6}, {"00011", 7},
+	{"000101", 8}, {"000100", 9}, {"0000100", 10}, {"0000101", 11}, {"0000111", 12}, {"00000100", 13},
+	{"00000111", 14}, {"000011000", 15}, {"0000010111", 16}, {"0000011000", 17}, {"0000001000", 18},
+	{"00001100111", 19}, {"00001101000", 20}, {"00001101100", 21}, {"00000110111", 22}, {"00000101000", 23},
+	{"00000010111", 24}, {"00000011000", 25}, {"000011001010", 26}, {"000011001011", 27}, {"000011001100", 28},
+	{"000011001101", 29}, {"000001101000", 30}, {"000001101001", 31}, {"000001101010", 32}, {"000001101011", 33},
+	{"000011010010", 34}, {"000011010011", 35}, {"000011010100", 36}, {"000011010101", 37}, {"000011010110", 38},
+	{"000011010111", 39}, {"000001101100", 40}, {"000001101101", 41}, {"000011011010", 42}, {"000011011011", 43},
+	{"000001010100", 44}, {"000001010101", 45}, {"000001010110", 46}, {"000001010111", 47}, {"000001100100", 48},
+	{"000001100101", 49}, {"000001010010", 50}, {"000001010011", 51}, {"000000100100", 52}, {"000000110111", 53},
+	{"000000111000", 54}, {"000000100111", 55}, {"000000101000", 56}, {"000001011000", 57}, {"000001011001", 58},
+	{"000000101011", 59}, {"000000101100", 60}, {"000001011010", 61}, {"000001100110", 62}, {"000001100111", 63},
+	{"0000001111", 64}, {"000011001000", 128}, {"000011001001", 192}, {"000001011011", 256}, {"000000110011", 320},
+	{"000000110100", 384}, {"000000110101", 448}, {"0000001101100", 512}, {"0000001101101", 576},
+	{"0000001001010", 640}, {"0000001001011", 704}, {"0000001001100", 768}, {"0000001001101", 832},
+	{"0000001110010", 896}, {"0000001110011", 960}, {"0000001110100", 1024}, {"0000001110101", 1088},
+	{"0000001110110", 1152}, {"0000001110111", 1216}, {"0000001010010", 1280}, {"0000001010011", 1344},
+	{"0000001010100", 1408}, {"0000001010101", 1472}, {"0000001011010", 1536}, {"0000001011011", 1600},
+	{"0000001100100", 1664}, {"0000001100101", 1728},
+}
+
+// extendedCodes 黑白共用的扩展补充码
+var extendedCodes = []ccittCode{
+	{"00000001000", 1792}, {"00000001100", 1856}, {"00000001101", 1920}, {"000000010010", 1984},
+	{"000000010011", 2048}, {"000000010100", 2112}, {"000000010101", 2176}, {"000000010110", 2240},
+	{"000000010111", 2304}, {"000000011100", 2368}, {"000000011101", 2432}, {"000000011110", 2496},
+	{"000000011111", 2560}, {"000000000001", modeEOL},
+}
+
+var modeCodes = []ccittCode{
+	{"0001", modePass}, {"001", modeHorizontal}, {"1", modeV0}, {"011", modeVR1}, {"000011", modeVR2},
+	{"0000011", modeVR3}, {"010", modeVL1}, {"000010", modeVL2}, {"0000010", modeVL3}, {"000000000001", modeEOL},
+}
+
+// codeTable 按码长和码值查找的编码表
+type codeTable map[[2]int]int
+
+func newCodeTable(lists ...[]ccittCode) codeTable {
+	t := codeTable{}
+	for _, list := range lists {
+		for _, c := range list {
+			v := 0
+			for _, b := range c.bits {
+				v = v<<1 | int(b-'0')
+			}
+			t[[2]int{len(c.bits), v}] = c.run
+		}
+	}
+	return t
+}
+
+var (
+	whiteTable = newCodeTable(whiteCodes, extendedCodes)
+	blackTable = newCodeTable(blackCodes, extendedCodes)
+	modeTable  = newCodeTable(modeCodes)
+)
+
+var errCCITT = errors.New("pdf: invalid ccitt data")
+
+// bitReader 按位读取数据
+type bitReader struct {
+	data []byte
+	pos  int // 位的位置
+}
+
+func (r *bitReader) bit() (int, bool) {
+	if r.pos >= len(r.data)*8 {
+		return 0, false
+	}
+	b := int(r.data[r.pos/8]>>(7-r.pos%8)) & 1
+	r.pos++
+	return b, true
+}
+
+// peek 读取接下来的 n 位而不移动位置，超出数据的部分补 0
+func (r *bitReader) peek(n int) int {
+	v := 0
+	for i := 0; i < n; i++ {
+		p := r.pos + i
+		b := 0
+		if p < len(r.data)*8 {
+			b = int(r.data[p/8]>>(7-p%8)) & 1
+		}
+		v = v<<1 | b
+	}
+	return v
+}
+
+func (r *bitReader) align() {
+	r.pos = (r.pos + 7) / 8 * 8
+}
+
+// code 读取一个变长编码
+func (r *bitReader) code(t codeTable) (int, error) {
+	v := 0
+	for n := 1; n <= 13; n++ {
+		b, ok := r.bit()
+		if !ok {
+			return 0, errCCITT
+		}
+		v = v<<1 | b
+		if run, ok := t[[2]int{n, v}]; ok {
+			return run, nil
+		}
+	}
+	return 0, errCCITT
+}
+
+// run 读取一个游程长度，补充码之后跟着终止码
+func (r *bitReader) run(t codeTable) (int, error) {
+	total := 0
+	for {
+		n, err := r.code(t)
+		if err != nil {
+			return 0, err
+		}
+		if n == modeEOL {
+			return 0, errCCITT
+		}
+		total += n
+		if n < 64 {
+			return total, nil
+		}
+	}
+}
+
+// ccittParams 解码参数
+type ccittParams struct {
+	k         int
+	columns   int
+	rows      int
+	blackIs1  bool
+	byteAlign bool
+}
+
+func ccittParamsFrom(param dict) ccittParams {
+	p := ccittParams{columns: 1728}
+	if v, ok := param[name("K")].(int64); ok {
+		p.k = int(v)
+	}
+	if v, ok := param[name("Columns")].(int64); ok && v > 0 {
+		p.columns = int(v)
+	}
+	if v, ok := param[name("Rows")].(int64); ok && v > 0 {
+		p.rows = int(v)
+	}
+	p.blackIs1, _ = param[name("BlackIs1")].(bool)
+	p.byteAlign, _ = param[name("EncodedByteAlign")].(bool)
+	return p
+}
+
+// decodeCCITT 解码传真编码的数据，输出每像素一位、每行按字节对齐的数据。
+// 默认 0 表示黑色，BlackIs1 为 true 时 1 表示黑色，与图片字典中的 Decode 配合使用。
+// 数据损坏时返回已经解码的行
+func decodeCCITT(data []byte, p ccittParams) []byte {
+	r := &bitReader{data: data}
+	rowBytes := (p.columns + 7) / 8
+	var out []byte
+	// ref 参考行上颜色变化的位置，从白色开始交替，末尾用列数补齐
+	ref := []int{p.columns, p.columns}
+	for p.rows == 0 || len(out)/rowBytes < p.rows {
+		if p.byteAlign && p.k >= 0 {
+			r.align()
+		}
+		// 跳过行首的 EOL（至少 11 个 0 后跟一个 1，前面可能有填充的 0），连续的 EOL 表示数据结束
+		eols := 0
+		for r.peek(11) == 0 && r.pos < len(data)*8 {
+			for r.pos < len(data)*8 && r.peek(1) == 0 {
+				r.pos++
+			}
+			r.pos++
+			eols++
+		}
+		if eols > 1 || r.pos >= len(data)*8 {
+			break
+		}
+		twoD := p.k < 0
+		if p.k > 0 {
+			b, ok := r.bit()
+			if !ok {
+				break
+			}
+			twoD = b == 0
+		}
+
+		var changes []int
+		var err error
+		if twoD {
+			changes, err = decode2D(r, ref, p.columns)
+		} else {
+			changes, err = decode1D(r, p.columns)
+		}
+		if err != nil {
+			break
+		}
+		out = append(out, packRow(changes, p.columns, p.blackIs1)...)
+		ref = append(changes, p.columns, p.columns)
+		if p.byteAlign && p.k < 0 {
+			r.align()
+		}
+	}
+	return out
+}
+
+// addChange 记录颜色变化的位置，长度为 0 的游程与上一个变化抵消
+func addChange(changes []int, x int) []int {
+	if n := len(changes); n > 0 && changes[n-1] == x {
+		return changes[:n-1]
+	}
+	return append(changes, x)
+}
+
+func decode1D(r *bitReader, columns int) ([]int, error) {
+	var changes []int
+	x, white := 0, true
+	for x < columns {
+		table := whiteTable
+		if !white {
+			table = blackTable
+		}
+		n, err := r.run(table)
+		if err != nil {
+			return nil, err
+		}
+		x = min(x+n, columns)
+		if x < columns {
+			changes = addChange(changes, x)
+		}
+		white = !white
+	}
+	return changes, nil
+}
+
+func decode2D(r *bitReader, ref []int, columns int) ([]int, error) {
+	var changes []int
+	a0, white := -1, true
+	for a0 < columns {
+		// b1 是参考行上 a0 右侧第一个变为与当前相反颜色的位置，偶数下标变为黑色，奇数下标变为白色
+		i := 0
+		for i < len(ref) && (ref[i] <= a0 || (i%2 == 0) != white) {
+			i++
+		}
+		b1, b2 := columns, columns
+		if i < len(ref) {
+			b1 = ref[i]
+		}
+		if i+1 < len(ref) {
+			b2 = ref[i+1]
+		}
+
+		mode, err := r.code(modeTable)
+		if err != nil {
+			return nil, err
+		}
+		start := max(a0, 0)
+		switch mode {
+		case modePass:
+			a0 = b2
+		case modeHorizontal:
+			first, second := whiteTable, blackTable
+			if !white {
+				first, second = blackTable, whiteTable
+			}
+			n1, err := r.run(first)
+			if err != nil {
+				return nil, err
+			}
+			n2, err := r.run(second)
+			if err != nil {
+				return nil, err
+			}
+			a1 := min(start+n1, columns)
+			a2 := min(a1+n2, columns)
+			if a1 < columns {
+				changes = addChange(changes, a1)
+			}
+			if a2 < columns {
+				changes = addChange(changes, a2)
+			}
+			a0 = a2
+		case modeV0, modeVR1, modeVR2, modeVR3, modeVL1, modeVL2, modeVL3:
+			offset := map[int]int{modeV0: 0, modeVR1: 1, modeVR2: 2, modeVR3: 3, modeVL1: -1, modeVL2: -2, modeVL3: -3}[mode]
+			a1 := b1 + offset
+			if a1 < start || a1 > columns {
+				return nil, errCCITT
+			}
+			if a1 < columns {
+				changes = addChange(changes, a1)
+			}
+			a0 = a1
+			white = !white
+		default:
+			return nil, errCCITT
+		}
+	}
+	return changes, nil
+}
+
+// packRow 把颜色变化的位置转换为按位存储的一行像素
+func packRow(changes []int, columns int, blackIs1 bool) []byte {
+	row := make([]byte, (columns+7)/8)
+	if !blackIs1 {
+		for i := range row {
+			row[i] = 0xff
+		}
+	}
+	for i := 0; i < len(changes); i += 2 {
+		end := columns
+		if i+1 < len(changes) {
+			end = changes[i+1]
+		}
+		for x := changes[i]; x < end; x++ {
+			if blackIs1 {
+				row[x/8] |= 0x80 >> (x % 8)
+			} else {
+				row[x/8] &^= 0x80 >> (x % 8)
+			}
+		}
+	}
+	return row
+}
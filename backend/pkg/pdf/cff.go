@@ -0,0 +1,766 @@
+package pdf
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// cffStandardStrings CFF 的标准字符串，SID 小于 391 时引用这张表
+var cffStandardStrings = strings.Fields(
+	".notdef space exclam quotedbl numbersign dollar percent ampersand quoteright parenleft parenright " +
+		"asterisk plus comma hyphen period slash zero one two three four five six seven eight nine colon " +
+		"semicolon less equal greater question at A B C D E F G H I J K L M N O P Q R S T U V W X Y Z " +
+		"bracketleft backslash bracketright asciicircum underscore quoteleft a b c d e f g h i j k l m n o p " +
+		"q r s t u v w x y z braceleft bar braceright asciitilde exclamdown cent sterling fraction yen florin " +
+		"section currency quotesingle quotedblleft guillemotleft guilsinglleft guilsinglright fi fl endash " +
+		"dagger daggerdbl periodcentered paragraph bullet quotesinglbase quotedblbase quotedblright " +
+		"guillemotright ellipsis perthousand questiondown grave acute circumflex tilde macron breve dotaccent " +
+		"dieresis ring cedilla hungarumlaut ogonek caron emdash AE ordfeminine Lslash Oslash OE ordmasculine " +
+		"ae dotlessi lslash oslash oe germandbls onesuperior logicalnot mu trademark Eth onehalf plusminus " +
+		"Thorn onequarter divide brokenbar degree thorn threequarters twosuperior registered minus eth " +
+		"multiply threesuperior copyright Aacute Acircumflex Adieresis Agrave Aring Atilde Ccedilla Eacute " +
+		"Ecircumflex Edieresis Egrave Iacute Icircumflex Idieresis Igrave Ntilde Oacute Ocircumflex Odieresis " +
+		"Ograve Otilde Scaron Uacute Ucircumflex Udieresis Ugrave Yacute Ydieresis Zcaron aacute acircumflex " +
+		"adieresis agrave aring atilde ccedilla eacute ecircumflex edieresis egrave iacute icircumflex " +
+		"idieresis igrave ntilde oacute ocircumflex odieresis ograve otilde scaron uacute ucircumflex " +
+		"udieresis ugrave yacute ydieresis zcaron exclamsmall Hungarumlautsmall dollaroldstyle dollarsuperior " +
+		"ampersandsmall Acutesmall parenleftsuperior parenrightsuperior twodotenleader onedotenleader " +
+		"zerooldstyle oneoldstyle twooldstyle threeoldstyle fouroldstyle fiveoldstyle sixoldstyle " +
+		"sevenoldstyle eightoldstyle nineoldstyle commasuperior threequartersemdash periodsuperior " +
+		"questionsmall asuperior bsuperior centsuperior dsuperior esuperior isuperior lsuperior msuperior " +
+		"nsuperior osuperior rsuperior ssuperior tsuperior ff ffi ffl parenleftinferior parenrightinferior " +
+		"Circumflexsmall hyphensuperior Gravesmall Asmall Bsmall Csmall Dsmall Esmall Fsmall Gsmall Hsmall " +
+		"Ismall Jsmall Ksmall Lsmall Msmall Nsmall Osmall Psmall Qsmall Rsmall Ssmall Tsmall Usmall Vsmall " +
+		"Wsmall Xsmall Ysmall Zsmall colonmonetary onefitted rupiah Tildesmall exclamdownsmall centoldstyle " +
+		"Lslashsmall Scaronsmall Zcaronsmall Dieresissmall Brevesmall Caronsmall Dotaccentsmall Macronsmall " +
+		"figuredash hypheninferior Ogoneksmall Ringsmall Cedillasmall questiondownsmall oneeighth " +
+		"threeeighths fiveeighths seveneighths onethird twothirds zerosuperior foursuperior fivesuperior " +
+		"sixsuperior sevensuperior eightsuperior ninesuperior zeroinferior oneinferior twoinferior " +
+		"threeinferior fourinferior fiveinferior sixinferior seveninferior eightinferior nineinferior " +
+		"centinferior dollarinferior periodinferior commainferior Agravesmall Aacutesmall Acircumflexsmall " +
+		"Atildesmall Adieresissmall Aringsmall AEsmall Ccedillasmall Egravesmall Eacutesmall Ecircumflexsmall " +
+		"Edieresissmall Igravesmall Iacutesmall Icircumflexsmall Idieresissmall Ethsmall Ntildesmall " +
+		"Ogravesmall Oacutesmall Ocircumflexsmall Otildesmall Odieresissmall OEsmall Oslashsmall Ugravesmall " +
+		"Uacutesmall Ucircumflexsmall Udieresissmall Yacutesmall Thornsmall Ydieresissmall 001.000 001.001 " +
+		"001.002 001.003 Black Bold Book Light Medium Regular Roman Semibold",
+)
+
+// cff CFF 字体程序（FontFile3 的 Type1C 和 CIDFontType0C），字形程序为 Type 2 格式
+type cff struct {
+	charStrings [][]byte
+	gsubrs      [][]byte
+	// subrs 各个 FD 的局部子程序，非 CID 字体只有一个
+	subrs    [][][]byte
+	fdSelect []byte
+	matrix   []matrix
+	// names 字形名称到字形编号的映射，CID 字体为空
+	names   map[string]int
+	byRune  map[rune]int
+	builtin map[int]int
+	// cids CID 到字形编号的映射，为空时两者相同
+	cids map[int]int
+}
+
+// cffIndex 读取 INDEX 结构，返回各项数据和结构之后的位置
+func cffIndex(data []byte, pos int) ([][]byte, int, error) {
+	if pos+2 > len(data) {
+		return nil, 0, errFont
+	}
+	count := int(binary.BigEndian.Uint16(data[pos:]))
+	if count == 0 {
+		return nil, pos + 2, nil
+	}
+	if pos+3 > len(data) {
+		return nil, 0, errFont
+	}
+	offSize := int(data[pos+2])
+	if offSize < 1 || offSize > 4 {
+		return nil, 0, errFont
+	}
+	pos += 3
+	if pos+(count+1)*offSize > len(data) {
+		return nil, 0, errFont
+	}
+	offset := func(i int) int {
+		v := 0
+		for _, b := range data[pos+i*offSize : pos+(i+1)*offSize] {
+			v = v<<8 | int(b)
+		}
+		return v
+	}
+	base := pos + (count+1)*offSize - 1
+	items := make([][]byte, count)
+	for i := range items {
+		start, end := base+offset(i), base+offset(i+1)
+		if start < base+1 || end < start || end > len(data) {
+			return nil, 0, errFont
+		}
+		items[i] = data[start:end]
+	}
+	return items, base + offset(count), nil
+}
+
+// cffDict 解析 DICT 结构，两字节的操作符记为 1200 加第二个字节
+func cffDict(data []byte) map[int][]float64 {
+	d := map[int][]float64{}
+	var operands []float64
+	for i := 0; i < len(data); {
+		b := int(data[i])
+		switch {
+		case b == 12 && i+1 < len(data):
+			d[1200+int(data[i+1])] = operands
+			operands = nil
+			i += 2
+		case b <= 21:
+			d[b] = operands
+			operands = nil
+			i++
+		case b == 28 && i+2 < len(data):
+			operands = append(operands, float64(int16(binary.BigEndian.Uint16(data[i+1:]))))
+			i += 3
+		case b == 29 && i+4 < len(data):
+			operands = append(operands, float64(int32(binary.BigEndian.Uint32(data[i+1:]))))
+			i += 5
+		case b == 30:
+			v, n := cffReal(data[i+1:])
+			operands = append(operands, v)
+			i += 1 + n
+		case b >= 32 && b <= 246:
+			operands = append(operands, float64(b-139))
+			i++
+		case b >= 247 && b <= 250 && i+1 < len(data):
+			operands = append(operands, float64((b-247)*256+int(data[i+1])+108))
+			i += 2
+		case b >= 251 && b <= 254 && i+1 < len(data):
+			operands = append(operands, float64(-(b-251)*256-int(data[i+1])-108))
+			i += 2
+		default:
+			i++
+		}
+	}
+	return d
+}
+
+// cffReal 读取以半字节编码的实数，返回数值和占用的字节数
+func cffReal(data []byte) (float64, int) {
+	var sb strings.Builder
+	for i, b := range data {
+		for _, nibble := range []byte{b >> 4, b & 15} {
+			switch {
+			case nibble <= 9:
+				sb.WriteByte('0' + nibble)
+			case nibble == 0xa:
+				sb.WriteByte('.')
+			case nibble == 0xb:
+				sb.WriteByte('E')
+			case nibble == 0xc:
+				sb.WriteString("E-")
+			case nibble == 0xe:
+				sb.WriteByte('-')
+			case nibble == 0xf:
+				v, _ := strconv.ParseFloat(sb.String(), 64)
+				return v, i + 1
+			}
+		}
+	}
+	return 0, len(data)
+}
+
+func parseCFF(data []byte) (*cff, error) {
+	if len(data) < 4 {
+		return nil, errFont
+	}
+	_, pos, err := cffIndex(data, int(data[2]))
+	if err != nil {
+		return nil, err
+	}
+	topDicts, pos, err := cffIndex(data, pos)
+	if err != nil || len(topDicts) == 0 {
+		return nil, errFont
+	}
+	strs, pos, err := cffIndex(data, pos)
+	if err != nil {
+		return nil, err
+	}
+	c := &cff{}
+	if c.gsubrs, _, err = cffIndex(data, pos); err != nil {
+		return nil, err
+	}
+	top := cffDict(topDicts[0])
+	offset := func(d map[int][]float64, key int) int {
+		if v := d[key]; len(v) > 0 && v[len(v)-1] > 0 && int(v[len(v)-1]) < len(data) {
+			return int(v[len(v)-1])
+		}
+		return 0
+	}
+	charStrings := offset(top, 17)
+	if charStrings == 0 {
+		return nil, errFont
+	}
+	if c.charStrings, _, err = cffIndex(data, charStrings); err != nil {
+		return nil, err
+	}
+	topMatrix := cffMatrix(top[1207], matrix{0.001, 0, 0, 0.001, 0, 0})
+
+	sid := func(s int) string {
+		if s < len(cffStandardStrings) {
+			return cffStandardStrings[s]
+		}
+		if s-len(cffStandardStrings) < len(strs) {
+			return string(strs[s-len(cffStandardStrings)])
+		}
+		return ""
+	}
+	charset := c.charset(data, offset(top, 15))
+
+	if _, cidKeyed := top[1230]; cidKeyed {
+		// CID 字体：charset 中是 CID，每个 FD 有自己的 Private DICT
+		c.cids = make(map[int]int, len(charset))
+		for gid, cid := range charset {
+			c.cids[cid] = gid
+		}
+		fds, _, err := cffIndex(data, offset(top, 1236))
+		if err != nil {
+			return nil, err
+		}
+		for _, fd := range fds {
+			d := cffDict(fd)
+			c.subrs = append(c.subrs, c.privateSubrs(data, d[18]))
+			c.matrix = append(c.matrix, cffMatrix(d[1207], matrix{1, 0, 0, 1, 0, 0}).mul(topMatrix))
+		}
+		c.fdSelect = c.parseFDSelect(data, offset(top, 1237))
+		return c, nil
+	}
+
+	c.subrs = [][][]byte{c.privateSubrs(data, top[18])}
+	c.matrix = []matrix{topMatrix}
+	c.names = make(map[string]int, len(charset))
+	for gid, s := range charset {
+		c.names[sid(s)] = gid
+	}
+	c.byRune = runeIndex(c.names)
+	// 编码为 0 和 1 时分别是标准编码和专家编码，按 PDF 中的编码处理
+	if enc := offset(top, 16); enc > 1 {
+		c.builtin = c.encoding(data, enc, charset)
+	}
+	return c, nil
+}
+
+func cffMatrix(v []float64, def matrix) matrix {
+	if len(v) != 6 {
+		return def
+	}
+	return matrix{v[0], v[1], v[2], v[3], v[4], v[5]}
+}
+
+// charset 读取每个字形的 SID（CID 字体中为 CID），字形 0 固定为 .notdef
+func (c *cff) charset(data []byte, pos int) []int {
+	n := len(c.charStrings)
+	ids := make([]int, 1, n)
+	if pos == 0 {
+		// ISOAdobe 字符集，SID 与字形编号相同
+		for gid := 1; gid < n; gid++ {
+			ids = append(ids, gid)
+		}
+		return ids
+	}
+	u16 := func(i int) int {
+		if i+2 > len(data) {
+			return 0
+		}
+		return int(binary.BigEndian.Uint16(data[i:]))
+	}
+	format := data[pos]
+	pos++
+	for len(ids) < n && pos < len(data) {
+		switch format {
+		case 0:
+			ids = append(ids, u16(pos))
+			pos += 2
+		case 1, 2:
+			first, left := u16(pos), 0
+			if format == 1 {
+				if pos+2 >= len(data) {
+					return ids
+				}
+				left = int(data[pos+2])
+				pos += 3
+			} else {
+				left = u16(pos + 2)
+				pos += 4
+			}
+			for i := 0; i <= left && len(ids) < n; i++ {
+				ids = append(ids, first+i)
+			}
+		default:
+			return ids
+		}
+	}
+	return ids
+}
+
+// encoding 读取自定义编码，返回字符编码到字形编号的映射
+func (c *cff) encoding(data []byte, pos int, charset []int) map[int]int {
+	m := map[int]int{}
+	if pos >= len(data) {
+		return m
+	}
+	format := data[pos]
+	pos++
+	gid := 1
+	switch format & 0x7f {
+	case 0:
+		if pos >= len(data) {
+			return m
+		}
+		n := int(data[pos])
+		pos++
+		for i := 0; i < n && pos < len(data); i++ {
+			m[int(data[pos])] = gid
+			gid++
+			pos++
+		}
+	case 1:
+		if pos >= len(data) {
+			return m
+		}
+		n := int(data[pos])
+		pos++
+		for i := 0; i < n && pos+1 < len(data); i++ {
+			first, left := int(data[pos]), int(data[pos+1])
+			pos += 2
+			for j := 0; j <= left; j++ {
+				m[first+j] = gid
+				gid++
+			}
+		}
+	}
+	// 补充编码把额外的编码映射到按 SID 指定的字形
+	if format&0x80 != 0 && pos < len(data) {
+		n := int(data[pos])
+		pos++
+		bySID := make(map[int]int, len(charset))
+		for g, s := range charset {
+			bySID[s] = g
+		}
+		for i := 0; i < n && pos+2 < len(data); i++ {
+			if g, ok := bySID[int(binary.BigEndian.Uint16(data[pos+1:]))]; ok {
+				m[int(data[pos])] = g
+			}
+			pos += 3
+		}
+	}
+	return m
+}
+
+// privateSubrs 读取 Private DICT 中的局部子程序，偏移相对于 Private DICT
+func (c *cff) privateSubrs(data []byte, private []float64) [][]byte {
+	if len(private) != 2 {
+		return nil
+	}
+	size, pos := int(private[0]), int(private[1])
+	if pos < 0 || size < 0 || pos+size > len(data) {
+		return nil
+	}
+	subrs := cffDict(data[pos : pos+size])[19]
+	if len(subrs) == 0 {
+		return nil
+	}
+	items, _, err := cffIndex(data, pos+int(subrs[0]))
+	if err != nil {
+		return nil
+	}
+	return items
+}
+
+// parseFDSelect 读取每个字形所属的 FD
+func (c *cff) parseFDSelect(data []byte, pos int) []byte {
+	n := len(c.charStrings)
+	fds := make([]byte, n)
+	if pos == 0 || pos >= len(data) {
+		return fds
+	}
+	switch data[pos] {
+	case 0:
+		copy(fds, data[pos+1:])
+	case 3:
+		if pos+3 > len(data) {
+			return fds
+		}
+		ranges := int(binary.BigEndian.Uint16(data[pos+1:]))
+		p := pos + 3
+		for i := 0; i < ranges && p+5 <= len(data); i++ {
+			first := int(binary.BigEndian.Uint16(data[p:]))
+			fd := data[p+2]
+			end := int(binary.BigEndian.Uint16(data[p+3:]))
+			for g := first; g < end && g < n; g++ {
+				fds[g] = fd
+			}
+			p += 3
+		}
+	}
+	return fds
+}
+
+func (c *cff) outline(f *font, cc code) (outline, bool) {
+	gid := cc.value
+	switch {
+	case f.twoByte:
+		// 只支持 Identity 编码，CID 等于字符编码
+		if c.cids != nil {
+			g, ok := c.cids[cc.value]
+			if !ok {
+				return nil, false
+			}
+			gid = g
+		}
+	default:
+		g, ok := glyphIndex(f, cc.value, c.names, c.builtin, c.byRune)
+		if !ok {
+			return nil, false
+		}
+		gid = g
+	}
+	if gid <= 0 || gid >= len(c.charStrings) {
+		return nil, false
+	}
+	fd := 0
+	if gid < len(c.fdSelect) && int(c.fdSelect[gid]) < len(c.subrs) {
+		fd = int(c.fdSelect[gid])
+	}
+	p := &pen{m: c.matrix[fd]}
+	t := &type2{c: c, p: p, subrs: c.subrs[fd]}
+	t.run(c.charStrings[gid], 0)
+	p.closePath()
+	return p.out, len(p.out) > 0
+}
+
+// type2 Type 2 字形程序的解释器
+type type2 struct {
+	c       *cff
+	p       *pen
+	subrs   [][]byte
+	stack   []float64
+	stems   int
+	width   bool
+	ended   bool
+	scratch [32]float64
+}
+
+// subrBias 子程序编号的偏移量
+func subrBias(n int) int {
+	switch {
+	case n < 1240:
+		return 107
+	case n < 33900:
+		return 1131
+	default:
+		return 32768
+	}
+}
+
+// clearWidth 处理第一个清空栈的操作符前可选的宽度参数
+func (t *type2) clearWidth(odd bool) {
+	if !t.width {
+		t.width = true
+		if odd && len(t.stack) > 0 {
+			t.stack = t.stack[1:]
+		}
+	}
+}
+
+func (t *type2) run(cs []byte, depth int) {
+	if depth > 10 {
+		t.ended = true
+		return
+	}
+	p := t.p
+	for i := 0; i < len(cs) && !t.ended; {
+		b := int(cs[i])
+		i++
+		switch {
+		case b >= 32 && b <= 246:
+			t.stack = append(t.stack, float64(b-139))
+			continue
+		case b >= 247 && b <= 250 && i < len(cs):
+			t.stack = append(t.stack, float64((b-247)*256+int(cs[i])+108))
+			i++
+			continue
+		case b >= 251 && b <= 254 && i < len(cs):
+			t.stack = append(t.stack, float64(-(b-251)*256-int(cs[i])-108))
+			i++
+			continue
+		case b == 28 && i+1 < len(cs):
+			t.stack = append(t.stack, float64(int16(binary.BigEndian.Uint16(cs[i:]))))
+			i += 2
+			continue
+		case b == 255 && i+3 < len(cs):
+			t.stack = append(t.stack, float64(int32(binary.BigEndian.Uint32(cs[i:])))/65536)
+			i += 4
+			continue
+		}
+		s := t.stack
+		switch b {
+		case 1, 3, 18, 23: // hstem vstem hstemhm vstemhm
+			t.clearWidth(len(s)%2 == 1)
+			t.stems += len(t.stack) / 2
+		case 19, 20: // hintmask cntrmask
+			t.clearWidth(len(s)%2 == 1)
+			t.stems += len(t.stack) / 2
+			i += (t.stems + 7) / 8
+		case 21: // rmoveto
+			t.clearWidth(len(s) > 2)
+			if s = t.stack; len(s) >= 2 {
+				p.moveTo(p.x+s[0], p.y+s[1])
+			}
+		case 22: // hmoveto
+			t.clearWidth(len(s) > 1)
+			if s = t.stack; len(s) >= 1 {
+				p.moveTo(p.x+s[0], p.y)
+			}
+		case 4: // vmoveto
+			t.clearWidth(len(s) > 1)
+			if s = t.stack; len(s) >= 1 {
+				p.moveTo(p.x, p.y+s[0])
+			}
+		case 5: // rlineto
+			for ; len(s) >= 2; s = s[2:] {
+				p.lineTo(p.x+s[0], p.y+s[1])
+			}
+		case 6, 7: // hlineto vlineto
+			horizontal := b == 6
+			for ; len(s) >= 1; s = s[1:] {
+				if horizontal {
+					p.lineTo(p.x+s[0], p.y)
+				} else {
+					p.lineTo(p.x, p.y+s[0])
+				}
+				horizontal = !horizontal
+			}
+		case 8: // rrcurveto
+			for ; len(s) >= 6; s = s[6:] {
+				t.curve(s[0], s[1], s[2], s[3], s[4], s[5])
+			}
+		case 24: // rcurveline
+			for ; len(s) >= 8; s = s[6:] {
+				t.curve(s[0], s[1], s[2], s[3], s[4], s[5])
+			}
+			if len(s) >= 2 {
+				p.lineTo(p.x+s[0], p.y+s[1])
+			}
+		case 25: // rlinecurve
+			for ; len(s) >= 8; s = s[2:] {
+				p.lineTo(p.x+s[0], p.y+s[1])
+			}
+			if len(s) >= 6 {
+				t.curve(s[0], s[1], s[2], s[3], s[4], s[5])
+			}
+		case 26: // vvcurveto
+			dx := 0.0
+			if len(s)%2 == 1 {
+				dx, s = s[0], s[1:]
+			}
+			for ; len(s) >= 4; s = s[4:] {
+				t.curve(dx, s[0], s[1], s[2], 0, s[3])
+				dx = 0
+			}
+		case 27: // hhcurveto
+			dy := 0.0
+			if len(s)%2 == 1 {
+				dy, s = s[0], s[1:]
+			}
+			for ; len(s) >= 4; s = s[4:] {
+				t.curve(s[0], dy, s[1], s[2], s[3], 0)
+				dy = 0
+			}
+		case 30, 31: // vhcurveto hvcurveto
+			horizontal := b == 31
+			for ; len(s) >= 4; s = s[4:] {
+				last := 0.0
+				if len(s) == 5 {
+					last = s[4]
+				}
+				if horizontal {
+					t.curve(s[0], 0, s[1], s[2], last, s[3])
+				} else {
+					t.curve(0, s[0], s[1], s[2], s[3], last)
+				}
+				horizontal = !horizontal
+			}
+		case 10, 29: // callsubr callgsubr
+			if len(s) == 0 {
+				t.ended = true
+				return
+			}
+			subrs := t.subrs
+			if b == 29 {
+				subrs = t.c.gsubrs
+			}
+			n := int(s[len(s)-1]) + subrBias(len(subrs))
+			t.stack = s[:len(s)-1]
+			if n < 0 || n >= len(subrs) {
+				t.ended = true
+				return
+			}
+			t.run(subrs[n], depth+1)
+			continue
+		case 11: // return
+			return
+		case 14: // endchar
+			t.clearWidth(len(s) == 1 || len(s) == 5)
+			if s = t.stack; len(s) == 4 {
+				t.seac(s[0], s[1], int(s[2]), int(s[3]))
+			}
+			p.closePath()
+			t.ended = true
+			return
+		case 12:
+			if i >= len(cs) {
+				return
+			}
+			b2 := cs[i]
+			i++
+			if t.escape(b2) {
+				continue
+			}
+		}
+		t.stack = t.stack[:0]
+	}
+}
+
+// escape 处理两字节的操作符，返回 true 表示结果留在栈上
+func (t *type2) escape(op byte) bool {
+	s := t.stack
+	pop := func() float64 {
+		if len(t.stack) == 0 {
+			return 0
+		}
+		v := t.stack[len(t.stack)-1]
+		t.stack = t.stack[:len(t.stack)-1]
+		return v
+	}
+	switch op {
+	case 35: // flex
+		if len(s) >= 12 {
+			t.curve(s[0], s[1], s[2], s[3], s[4], s[5])
+			t.curve(s[6], s[7], s[8], s[9], s[10], s[11])
+		}
+	case 34: // hflex
+		if len(s) >= 7 {
+			t.curve(s[0], 0, s[1], s[2], s[3], 0)
+			t.curve(s[4], 0, s[5], -s[2], s[6], 0)
+		}
+	case 36: // hflex1
+		if len(s) >= 9 {
+			y := t.p.y
+			t.curve(s[0], s[1], s[2], s[3], s[4], 0)
+			t.curve(s[5], 0, s[6], s[7], s[8], y-t.p.y-s[7])
+		}
+	case 37: // flex1
+		if len(s) >= 11 {
+			x, y := t.p.x, t.p.y
+			dx := s[0] + s[2] + s[4] + s[6] + s[8]
+			dy := s[1] + s[3] + s[5] + s[7] + s[9]
+			t.curve(s[0], s[1], s[2], s[3], s[4], s[5])
+			if math.Abs(dx) > math.Abs(dy) {
+				t.curve(s[6], s[7], s[8], s[9], x+dx+s[10]-(t.p.x+s[6]+s[8]), y-(t.p.y+s[7]+s[9]))
+			} else {
+				t.curve(s[6], s[7], s[8], s[9], x-(t.p.x+s[6]+s[8]), y+dy+s[10]-(t.p.y+s[7]+s[9]))
+			}
+		}
+	case 9: // abs
+		t.stack = append(t.stack, math.Abs(pop()))
+		return true
+	case 10: // add
+		b, a := pop(), pop()
+		t.stack = append(t.stack, a+b)
+		return true
+	case 11: // sub
+		b, a := pop(), pop()
+		t.stack = append(t.stack, a-b)
+		return true
+	case 12: // div
+		b, a := pop(), pop()
+		if b != 0 {
+			a /= b
+		}
+		t.stack = append(t.stack, a)
+		return true
+	case 14: // neg
+		t.stack = append(t.stack, -pop())
+		return true
+	case 18: // drop
+		pop()
+		return true
+	case 24: // mul
+		b, a := pop(), pop()
+		t.stack = append(t.stack, a*b)
+		return true
+	case 26: // sqrt
+		t.stack = append(t.stack, math.Sqrt(math.Abs(pop())))
+		return true
+	case 27: // dup
+		v := pop()
+		t.stack = append(t.stack, v, v)
+		return true
+	case 28: // exch
+		b, a := pop(), pop()
+		t.stack = append(t.stack, b, a)
+		return true
+	case 20: // put
+		i, v := int(pop()), pop()
+		if i >= 0 && i < len(t.scratch) {
+			t.scratch[i] = v
+		}
+		return true
+	case 21: // get
+		i := int(pop())
+		v := 0.0
+		if i >= 0 && i < len(t.scratch) {
+			v = t.scratch[i]
+		}
+		t.stack = append(t.stack, v)
+		return true
+	}
+	return false
+}
+
+// curve 以相对坐标画三次曲线
+func (t *type2) curve(dx1, dy1, dx2, dy2, dx3, dy3 float64) {
+	p := t.p
+	x1, y1 := p.x+dx1, p.y+dy1
+	x2, y2 := x1+dx2, y1+dy2
+	p.curveTo(x1, y1, x2, y2, x2+dx3, y2+dy3)
+}
+
+// seac 用 endchar 的四个参数组合基础字形和重音符号，字符按标准编码查找
+func (t *type2) seac(adx, ady float64, base, accent int) {
+	lookup := func(c int) ([]byte, bool) {
+		if c < 0 || c > 255 {
+			return nil, false
+		}
+		g, ok := t.c.byRune[standardEncoding[c]]
+		if !ok || g >= len(t.c.charStrings) {
+			return nil, false
+		}
+		return t.c.charStrings[g], true
+	}
+	p := t.p
+	if cs, ok := lookup(base); ok {
+		sub := &type2{c: t.c, p: p, subrs: t.subrs, width: true}
+		p.x, p.y = 0, 0
+		sub.run(cs, 1)
+	}
+	if cs, ok := lookup(accent); ok {
+		p.closePath()
+		m := p.m
+		p.m = translate(adx, ady).mul(m)
+		p.x, p.y = 0, 0
+		sub := &type2{c: t.c, p: p, subrs: t.subrs, width: true}
+		sub.run(cs, 1)
+		p.closePath()
+		p.m = m
+	}
+}
@@ -0,0 +1,254 @@
+package pdf
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/md5"
+	"crypto/rc4"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"hash"
+)
+
+// passwordPadding 标准安全处理器用于补齐密码的 32 字节
+var passwordPadding = []byte{
+	0x28, 0xbf, 0x4e, 0x5e, 0x4e, 0x75, 0x8a, 0x41, 0x64, 0x00, 0x4e, 0x56, 0xff, 0xfa, 0x01, 0x08,
+	0x2e, 0x2e, 0x00, 0xb6, 0xd0, 0x68, 0x3e, 0x80, 0x2f, 0x0c, 0xa9, 0xfe, 0x64, 0x53, 0x69, 0x7a,
+}
+
+// crypt 标准安全处理器的解密参数。只支持用户密码为空的文件，
+// 这类文件只用所有者密码限制打印和复制，任何阅读器都可以直接打开
+type crypt struct {
+	key []byte
+	// aes 为 true 时使用 AES，否则使用 RC4
+	aes bool
+	// v5 AES-256 直接使用文件密钥，不按对象派生
+	v5 bool
+	// stream、str 为 false 时对应的数据使用 Identity 过滤器，没有加密
+	stream, str     bool
+	encryptMetadata bool
+}
+
+// newCrypt 用空的用户密码计算文件密钥，需要密码时返回 ErrEncrypted
+func (d *Document) newCrypt(enc dict) (*crypt, error) {
+	if d.resolve(enc[name("Filter")]) != name("Standard") {
+		return nil, ErrEncrypted
+	}
+	v, _ := d.resolve(enc[name("V")]).(int64)
+	r, _ := d.resolve(enc[name("R")]).(int64)
+	o, _ := d.resolve(enc[name("O")]).(string)
+	u, _ := d.resolve(enc[name("U")]).(string)
+	p, _ := d.resolve(enc[name("P")]).(int64)
+	c := &crypt{stream: true, str: true, encryptMetadata: true}
+	if m, ok := d.resolve(enc[name("EncryptMetadata")]).(bool); ok {
+		c.encryptMetadata = m
+	}
+
+	length := 40
+	if n, ok := d.resolve(enc[name("Length")]).(int64); ok && n >= 40 && n <= 256 {
+		length = int(n)
+	}
+	if v >= 4 {
+		// 字符串和流分别指定加密过滤器，CFM 为 V2 时是 RC4，AESV2、AESV3 是 AES
+		filters, _ := d.resolve(enc[name("CF")]).(dict)
+		method := func(key name) (name, bool) {
+			f, _ := d.resolve(enc[key]).(name)
+			if f == "" || f == "Identity" {
+				return "", false
+			}
+			cf, _ := d.resolve(filters[f]).(dict)
+			cfm, _ := d.resolve(cf[name("CFM")]).(name)
+			if n, ok := d.resolve(cf[name("Length")]).(int64); ok && v == 4 {
+				// Length 可能以位或字节为单位
+				if n <= 32 {
+					n *= 8
+				}
+				length = int(n)
+			}
+			return cfm, true
+		}
+		var cfm name
+		cfm, c.stream = method("StmF")
+		if m, ok := method("StrF"); ok {
+			cfm, c.str = m, true
+		} else {
+			c.str = false
+		}
+		c.aes = cfm == "AESV2" || cfm == "AESV3"
+	}
+
+	if r >= 5 {
+		c.aes, c.v5 = true, true
+		ue, _ := d.resolve(enc[name("UE")]).(string)
+		if len(u) < 48 || len(ue) < 32 {
+			return nil, ErrEncrypted
+		}
+		check, salt := []byte(u[32:40]), []byte(u[40:48])
+		if !bytes.Equal(hash2B(nil, check, nil, r), []byte(u[:32])) {
+			return nil, ErrEncrypted
+		}
+		block, err := aes.NewCipher(hash2B(nil, salt, nil, r))
+		if err != nil {
+			return nil, ErrEncrypted
+		}
+		c.key = make([]byte, 32)
+		cipher.NewCBCDecrypter(block, make([]byte, 16)).CryptBlocks(c.key, []byte(ue[:32]))
+		return c, nil
+	}
+
+	// 算法 2：空密码补齐后与 O、P 和文件 ID 一起计算 MD5
+	n := length / 8
+	if r == 2 {
+		n = 5
+	}
+	var id string
+	if ids, ok := d.resolve(d.trailer[name("ID")]).(array); ok && len(ids) > 0 {
+		id, _ = d.resolve(ids[0]).(string)
+	}
+	h := md5.New()
+	h.Write(passwordPadding)
+	h.Write([]byte(o))
+	binary.Write(h, binary.LittleEndian, uint32(p))
+	h.Write([]byte(id))
+	if r >= 4 && !c.encryptMetadata {
+		h.Write([]byte{0xff, 0xff, 0xff, 0xff})
+	}
+	key := h.Sum(nil)
+	if r >= 3 {
+		for i := 0; i < 50; i++ {
+			sum := md5.Sum(key[:n])
+			key = sum[:]
+		}
+	}
+	c.key = key[:n]
+
+	// 用计算出的密钥加密补齐串，与 U 一致说明用户密码确实为空
+	var expected []byte
+	if r == 2 {
+		expected = rc4Crypt(c.key, passwordPadding)
+	} else {
+		sum := md5.Sum(append(append([]byte{}, passwordPadding...), id...))
+		expected = sum[:]
+		for i := 0; i < 20; i++ {
+			k := make([]byte, len(c.key))
+			for j := range k {
+				k[j] = c.key[j] ^ byte(i)
+			}
+			expected = rc4Crypt(k, expected)
+		}
+		u = u[:min(16, len(u))]
+	}
+	if !bytes.Equal(expected, []byte(u)) {
+		return nil, ErrEncrypted
+	}
+	return c, nil
+}
+
+// hash2B PDF 2.0 的密码哈希（算法 2.B），R5 只做一次 SHA-256
+func hash2B(password, salt, udata []byte, r int64) []byte {
+	h := sha256.New()
+	h.Write(password)
+	h.Write(salt)
+	h.Write(udata)
+	k := h.Sum(nil)
+	if r < 6 {
+		return k
+	}
+	for i := 0; ; i++ {
+		k1 := bytes.Repeat(append(append(append([]byte{}, password...), k...), udata...), 64)
+		block, _ := aes.NewCipher(k[:16])
+		e := make([]byte, len(k1))
+		cipher.NewCBCEncrypter(block, k[16:32]).CryptBlocks(e, k1)
+		sum := 0
+		for _, b := range e[:16] {
+			sum += int(b)
+		}
+		var next hash.Hash
+		switch sum % 3 {
+		case 0:
+			next = sha256.New()
+		case 1:
+			next = sha512.New384()
+		default:
+			next = sha512.New()
+		}
+		next.Write(e)
+		k = next.Sum(nil)
+		if i >= 63 && int(e[len(e)-1]) <= i+1-32 {
+			break
+		}
+	}
+	return k[:32]
+}
+
+func rc4Crypt(key, data []byte) []byte {
+	c, err := rc4.NewCipher(key)
+	if err != nil {
+		return nil
+	}
+	out := make([]byte, len(data))
+	c.XORKeyStream(out, data)
+	return out
+}
+
+// decrypt 解密对象 ref 中的字符串或流数据
+func (c *crypt) decrypt(ref objref, data []byte) []byte {
+	key := c.key
+	if !c.v5 {
+		// 算法 1：文件密钥加上对象号和代号计算每个对象的密钥
+		buf := append(append([]byte{}, c.key...), byte(ref.id), byte(ref.id>>8), byte(ref.id>>16), byte(ref.gen), byte(ref.gen>>8))
+		if c.aes {
+			buf = append(buf, "sAlT"...)
+		}
+		sum := md5.Sum(buf)
+		key = sum[:min(len(c.key)+5, 16)]
+	}
+	if !c.aes {
+		return rc4Crypt(key, data)
+	}
+	// AES-CBC，前 16 字节是初始向量，末尾按 PKCS#5 补齐
+	if len(data) < 32 || len(data)%16 != 0 {
+		return nil
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil
+	}
+	out := make([]byte, len(data)-16)
+	cipher.NewCBCDecrypter(block, data[:16]).CryptBlocks(out, data[16:])
+	if pad := int(out[len(out)-1]); pad >= 1 && pad <= 16 {
+		out = out[:len(out)-pad]
+	}
+	return out
+}
+
+// decryptObject 解密间接对象中所有的字符串和流数据，对象流中的对象已随对象流解密
+func (c *crypt) decryptObject(ref objref, v any) any {
+	switch v := v.(type) {
+	case string:
+		if !c.str {
+			return v
+		}
+		return string(c.decrypt(ref, []byte(v)))
+	case array:
+		for i := range v {
+			v[i] = c.decryptObject(ref, v[i])
+		}
+	case dict:
+		for k := range v {
+			v[k] = c.decryptObject(ref, v[k])
+		}
+	case *stream:
+		typ, _ := v.hdr[name("Type")].(name)
+		if typ == "XRef" {
+			return v
+		}
+		c.decryptObject(ref, v.hdr)
+		if c.stream && (typ != "Metadata" || c.encryptMetadata) {
+			v.data = c.decrypt(ref, v.data)
+		}
+	}
+	return v
+}
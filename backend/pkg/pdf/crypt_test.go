@@ -0,0 +1,80 @@
+package pdf_test
+
+import (
+	"crypto/md5"
+	"crypto/rc4"
+	"encoding/binary"
+	"fmt"
+	"paper-translation/pkg/pdf"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var padding = []byte("\x28\xbf\x4e\x5e\x4e\x75\x8a\x41\x64\x00\x4e\x56\xff\xfa\x01\x08" +
+	"\x2e\x2e\x00\xb6\xd0\x68\x3e\x80\x2f\x0c\xa9\xfe\x64\x53\x69\x7a")
+
+func rc4Encrypt(key, data []byte) []byte {
+	c, _ := rc4.NewCipher(key)
+	out := make([]byte, len(data))
+	c.XORKeyStream(out, data)
+	return out
+}
+
+// encryptedPDF 用户密码为空、128 位 RC4 加密（R3）的单页文档，u 为 nil 时按空密码计算 U
+func encryptedPDF(u []byte) []byte {
+	owner := []byte("owner-password-hash-0123456789ab")
+	id := []byte("0123456789abcdef")
+	p := int32(-3904)
+
+	h := md5.New()
+	h.Write(padding)
+	h.Write(owner)
+	_ = binary.Write(h, binary.LittleEndian, p)
+	h.Write(id)
+	key := h.Sum(nil)
+	for i := 0; i < 50; i++ {
+		sum := md5.Sum(key)
+		key = sum[:]
+	}
+	if u == nil {
+		sum := md5.Sum(append(append([]byte{}, padding...), id...))
+		u = sum[:]
+		for i := 0; i < 20; i++ {
+			k := make([]byte, len(key))
+			for j := range k {
+				k[j] = key[j] ^ byte(i)
+			}
+			u = rc4Encrypt(k, u)
+		}
+		u = append(u, make([]byte, 16)...)
+	}
+	objectKey := func(id int) []byte {
+		sum := md5.Sum(append(append([]byte{}, key...), byte(id), 0, 0, 0, 0))
+		return sum[:]
+	}
+
+	content := rc4Encrypt(objectKey(4), []byte("BT /F1 12 Tf 72 720 Td (Encrypted text) Tj ET"))
+	return buildPDFTrailer(fmt.Sprintf("/Encrypt 6 0 R /ID [<%x> <%x>] ", id, id),
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Contents 4 0 R /Resources << /Font << /F1 5 0 R >> >> >>",
+		streamObject("", content),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		fmt.Sprintf("<< /Filter /Standard /V 2 /R 3 /Length 128 /O <%x> /U <%x> /P %d >>", owner, u, p),
+	)
+}
+
+/**
+ * TestEncrypted 测试用户密码为空的加密文件可以直接解密，需要用户密码的文件返回 ErrEncrypted。
+ */
+func TestEncrypted(t *testing.T) {
+	d, err := pdf.Open(encryptedPDF(nil))
+	assert.Nil(t, err)
+	text, err := d.PageText(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "Encrypted text", text)
+
+	_, err = pdf.Open(encryptedPDF(make([]byte, 32)))
+	assert.ErrorIs(t, err, pdf.ErrEncrypted)
+}
@@ -0,0 +1,520 @@
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// ErrEncrypted 需要密码才能打开的PDF文件无法解析
+var ErrEncrypted = errors.New("pdf: encrypted document")
+
+// xrefEntry 交叉引用表中的一项，stream 不为 0 时对象保存在该对象流的第 index 个位置
+type xrefEntry struct {
+	offset int
+	stream int
+	index  int
+}
+
+// Document 一个已解析的PDF文件，只读取提取文本和渲染页面需要的对象
+type Document struct {
+	data    []byte
+	xref    map[int]xrefEntry
+	trailer dict
+	objs    map[int]any
+	objStms map[int]*objStm
+	fonts   map[objref]*font
+	pages   []page
+	crypt   *crypt
+}
+
+// objStm 已解码的对象流
+type objStm struct {
+	data    []byte
+	offsets []int
+}
+
+// page 页面字典和从页面树继承的属性
+type page struct {
+	dict      dict
+	resources dict
+	mediaBox  array
+	cropBox   array
+	rotate    int64
+}
+
+// OpenFile 读取并解析PDF文件
+func OpenFile(name string) (*Document, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return Open(data)
+}
+
+// Open 解析PDF文件，交叉引用表损坏时扫描整个文件重建
+func Open(data []byte) (d *Document, err error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, "\x00\t\r\n "), []byte("%PDF-")) {
+		return nil, errors.New("pdf: not a pdf file")
+	}
+	// 损坏的文件可能导致意料之外的越界，作为错误返回
+	defer func() {
+		if r := recover(); r != nil {
+			d, err = nil, fmt.Errorf("pdf: open: %v", r)
+		}
+	}()
+	d = &Document{
+		data:    data,
+		xref:    map[int]xrefEntry{},
+		objs:    map[int]any{},
+		objStms: map[int]*objStm{},
+		fonts:   map[objref]*font{},
+	}
+	if err := d.readXref(); err != nil || d.catalog() == nil {
+		d.xref, d.trailer, d.objs, d.objStms = map[int]xrefEntry{}, nil, map[int]any{}, map[int]*objStm{}
+		d.rebuildXref()
+	}
+	if enc, ok := d.trailer[name("Encrypt")]; ok {
+		if err := d.setupCrypt(enc); err != nil {
+			return nil, err
+		}
+	}
+	root := d.catalog()
+	if root == nil {
+		return nil, errors.New("pdf: missing document catalog")
+	}
+	pages, _ := d.resolve(root[name("Pages")]).(dict)
+	d.walkPages(pages, page{}, map[any]bool{})
+	return d, nil
+}
+
+// setupCrypt 读取加密字典。加密字典本身没有加密，之前读取并缓存的对象需要重新读取
+func (d *Document) setupCrypt(v any) error {
+	enc, ok := d.resolve(v).(dict)
+	if !ok {
+		return ErrEncrypted
+	}
+	c, err := d.newCrypt(enc)
+	if err != nil {
+		return err
+	}
+	d.objs, d.objStms = map[int]any{}, map[int]*objStm{}
+	if ref, ok := v.(objref); ok {
+		d.objs[ref.id] = enc
+	}
+	d.crypt = c
+	return nil
+}
+
+// NumPages 返回页数
+func (d *Document) NumPages() int {
+	return len(d.pages)
+}
+
+func (d *Document) catalog() dict {
+	root, _ := d.resolve(d.trailer[name("Root")]).(dict)
+	return root
+}
+
+// readXref 从 startxref 开始读取交叉引用表或交叉引用流，并沿 Prev 读取更早的部分
+func (d *Document) readXref() error {
+	idx := bytes.LastIndex(d.data, []byte("startxref"))
+	if idx < 0 {
+		return errors.New("pdf: missing startxref")
+	}
+	l := &lexer{data: d.data, pos: idx + len("startxref")}
+	tok, err := l.token()
+	if err != nil {
+		return err
+	}
+	offset, ok := tok.(int64)
+	if !ok {
+		return errSyntax
+	}
+
+	seen := map[int64]bool{}
+	for offset > 0 && !seen[offset] {
+		seen[offset] = true
+		trailer, err := d.readXrefSection(int(offset))
+		if err != nil {
+			return err
+		}
+		if d.trailer == nil {
+			d.trailer = trailer
+		}
+		// 混合引用的文件同时有交叉引用表和交叉引用流
+		if stm, ok := trailer[name("XRefStm")].(int64); ok && !seen[stm] {
+			seen[stm] = true
+			if _, err := d.readXrefSection(int(stm)); err != nil {
+				return err
+			}
+		}
+		offset, _ = trailer[name("Prev")].(int64)
+	}
+	if d.trailer == nil {
+		return errors.New("pdf: missing trailer")
+	}
+	return nil
+}
+
+// readXrefSection 读取一段交叉引用，已经读取的对象不会被更早的部分覆盖
+func (d *Document) readXrefSection(offset int) (dict, error) {
+	if offset < 0 || offset >= len(d.data) {
+		return nil, errSyntax
+	}
+	l := &lexer{data: d.data, pos: offset}
+	save := l.pos
+	tok, err := l.token()
+	if err != nil {
+		return nil, err
+	}
+	if tok != keyword("xref") {
+		l.pos = save
+		return d.readXrefStream(l)
+	}
+
+	for {
+		tok, err := l.token()
+		if err != nil {
+			return nil, err
+		}
+		if tok == keyword("trailer") {
+			v, err := l.object()
+			if err != nil {
+				return nil, err
+			}
+			trailer, ok := v.(dict)
+			if !ok {
+				return nil, errSyntax
+			}
+			return trailer, nil
+		}
+		start, ok1 := tok.(int64)
+		count, err := l.token()
+		n, ok2 := count.(int64)
+		if err != nil || !ok1 || !ok2 {
+			return nil, errSyntax
+		}
+		for i := 0; i < int(n); i++ {
+			off, _ := l.token()
+			_, _ = l.token()
+			typ, err := l.token()
+			if err != nil {
+				return nil, err
+			}
+			id := int(start) + i
+			if _, ok := d.xref[id]; ok {
+				continue
+			}
+			if o, ok := off.(int64); ok && typ == keyword("n") {
+				d.xref[id] = xrefEntry{offset: int(o)}
+			} else {
+				// 空闲对象也要占位，避免被更早的部分覆盖
+				d.xref[id] = xrefEntry{offset: -1}
+			}
+		}
+	}
+}
+
+// readXrefStream 读取交叉引用流，流字典同时是文件尾字典
+func (d *Document) readXrefStream(l *lexer) (dict, error) {
+	_, v, err := l.indirect(d.length)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := v.(*stream)
+	if !ok || s.hdr[name("Type")] != name("XRef") {
+		return nil, errors.New("pdf: expected xref stream")
+	}
+	data, err := d.decode(s)
+	if err != nil {
+		return nil, err
+	}
+
+	w, _ := s.hdr[name("W")].(array)
+	if len(w) < 3 {
+		return nil, errSyntax
+	}
+	widths := make([]int, 3)
+	for i := range widths {
+		n, _ := w[i].(int64)
+		widths[i] = int(n)
+	}
+	index, _ := s.hdr[name("Index")].(array)
+	if len(index) == 0 {
+		size, _ := s.hdr[name("Size")].(int64)
+		index = array{int64(0), size}
+	}
+
+	field := func(b []byte) int {
+		v := 0
+		for _, c := range b {
+			v = v<<8 | int(c)
+		}
+		return v
+	}
+	row := widths[0] + widths[1] + widths[2]
+	pos := 0
+	for i := 0; i+1 < len(index); i += 2 {
+		start, _ := index[i].(int64)
+		count, _ := index[i+1].(int64)
+		for j := 0; j < int(count) && pos+row <= len(data); j++ {
+			b := data[pos : pos+row]
+			pos += row
+			typ := 1
+			if widths[0] > 0 {
+				typ = field(b[:widths[0]])
+			}
+			f2 := field(b[widths[0] : widths[0]+widths[1]])
+			f3 := field(b[widths[0]+widths[1]:])
+			id := int(start) + j
+			if _, ok := d.xref[id]; ok {
+				continue
+			}
+			switch typ {
+			case 1:
+				d.xref[id] = xrefEntry{offset: f2}
+			case 2:
+				d.xref[id] = xrefEntry{stream: f2, index: f3}
+			default:
+				d.xref[id] = xrefEntry{offset: -1}
+			}
+		}
+	}
+	return s.hdr, nil
+}
+
+var objPattern = regexp.MustCompile(`(?m)(?:^|[\r\n\s])(\d+)\s+(\d+)\s+obj\b`)
+
+// rebuildXref 扫描整个文件中的“id gen obj”重建交叉引用表，同一对象出现多次时以最后一次为准
+func (d *Document) rebuildXref() {
+	for _, m := range objPattern.FindAllSubmatchIndex(d.data, -1) {
+		id, _ := strconv.Atoi(string(d.data[m[2]:m[3]]))
+		d.xref[id] = xrefEntry{offset: m[2]}
+	}
+	// 对象流中的对象
+	ids := make([]int, 0, len(d.xref))
+	for id := range d.xref {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		s, ok := d.object(id).(*stream)
+		if !ok || s.hdr[name("Type")] != name("ObjStm") {
+			continue
+		}
+		if stm := d.objStm(id); stm != nil {
+			d.addObjStm(id, stm)
+		}
+	}
+
+	if idx := bytes.LastIndex(d.data, []byte("trailer")); idx >= 0 {
+		l := &lexer{data: d.data, pos: idx + len("trailer")}
+		if v, err := l.object(); err == nil {
+			d.trailer, _ = v.(dict)
+		}
+	}
+	if d.catalog() != nil {
+		return
+	}
+	// 没有文件尾或文件尾损坏时找文档目录
+	ids = ids[:0]
+	for id := range d.xref {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		if o, ok := d.object(id).(dict); ok && o[name("Type")] == name("Catalog") {
+			d.trailer = dict{name("Root"): objref{id: id}}
+			return
+		}
+	}
+}
+
+// addObjStm 把对象流中的对象加入交叉引用表，文件中直接出现的对象优先
+func (d *Document) addObjStm(id int, stm *objStm) {
+	header := &lexer{data: stm.data}
+	for i := range stm.offsets {
+		tok, err := header.token()
+		if err != nil {
+			return
+		}
+		_, _ = header.token()
+		if n, ok := tok.(int64); ok {
+			if _, exists := d.xref[int(n)]; !exists {
+				d.xref[int(n)] = xrefEntry{stream: id, index: i}
+			}
+		}
+	}
+}
+
+// length 解析流字典中的长度，可能是间接引用
+func (d *Document) length(v any) int {
+	if ref, ok := v.(objref); ok {
+		// 长度对象一定不在对象流中，直接读取，避免解析流时递归
+		e, ok := d.xref[ref.id]
+		if !ok || e.stream != 0 || e.offset < 0 {
+			return -1
+		}
+		l := &lexer{data: d.data, pos: e.offset}
+		_, v, err := l.indirect(func(any) int { return -1 })
+		if err != nil {
+			return -1
+		}
+		n, _ := v.(int64)
+		return int(n)
+	}
+	n, ok := v.(int64)
+	if !ok {
+		return -1
+	}
+	return int(n)
+}
+
+// object 读取间接对象，对象不存在或损坏时返回 nil
+func (d *Document) object(id int) any {
+	if v, ok := d.objs[id]; ok {
+		return v
+	}
+	d.objs[id] = nil // 防止循环引用
+	e, ok := d.xref[id]
+	if !ok || e.offset < 0 {
+		return nil
+	}
+	var v any
+	if e.stream != 0 {
+		v = d.streamObject(e)
+	} else if e.offset < len(d.data) {
+		l := &lexer{data: d.data, pos: e.offset}
+		ref, obj, err := l.indirect(d.length)
+		if err == nil {
+			v = obj
+			if d.crypt != nil {
+				v = d.crypt.decryptObject(ref, v)
+			}
+		}
+	}
+	d.objs[id] = v
+	return v
+}
+
+// streamObject 读取对象流中的对象
+func (d *Document) streamObject(e xrefEntry) any {
+	stm := d.objStm(e.stream)
+	if stm == nil || e.index >= len(stm.offsets) {
+		return nil
+	}
+	l := &lexer{data: stm.data, pos: stm.offsets[e.index]}
+	v, err := l.object()
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+// objStm 解码对象流并读取其中每个对象的位置
+func (d *Document) objStm(id int) *objStm {
+	if stm, ok := d.objStms[id]; ok {
+		return stm
+	}
+	d.objStms[id] = nil
+	s, ok := d.object(id).(*stream)
+	if !ok {
+		return nil
+	}
+	data, err := d.decode(s)
+	if err != nil {
+		return nil
+	}
+	n, _ := s.hdr[name("N")].(int64)
+	first, _ := s.hdr[name("First")].(int64)
+	stm := &objStm{data: data}
+	l := &lexer{data: data}
+	for i := 0; i < int(n); i++ {
+		_, err1 := l.token()
+		off, err2 := l.token()
+		o, ok := off.(int64)
+		if err1 != nil || err2 != nil || !ok {
+			break
+		}
+		stm.offsets = append(stm.offsets, int(first+o))
+	}
+	d.objStms[id] = stm
+	return stm
+}
+
+// resolve 解析间接引用，其他对象原样返回
+func (d *Document) resolve(v any) any {
+	for i := 0; i < 32; i++ {
+		ref, ok := v.(objref)
+		if !ok {
+			return v
+		}
+		v = d.object(ref.id)
+	}
+	return nil
+}
+
+// walkPages 按顺序遍历页面树，资源、页面大小和旋转角度从父节点继承
+func (d *Document) walkPages(node dict, inherited page, seen map[any]bool) {
+	if node == nil {
+		return
+	}
+	if r, ok := d.resolve(node[name("Resources")]).(dict); ok {
+		inherited.resources = r
+	}
+	if box, ok := d.resolve(node[name("MediaBox")]).(array); ok && len(box) == 4 {
+		inherited.mediaBox = box
+	}
+	if box, ok := d.resolve(node[name("CropBox")]).(array); ok && len(box) == 4 {
+		inherited.cropBox = box
+	}
+	if rotate, ok := d.resolve(node[name("Rotate")]).(int64); ok {
+		inherited.rotate = rotate
+	}
+	kids, ok := d.resolve(node[name("Kids")]).(array)
+	if !ok || node[name("Type")] == name("Page") {
+		inherited.dict = node
+		d.pages = append(d.pages, inherited)
+		return
+	}
+	for _, kid := range kids {
+		if ref, ok := kid.(objref); ok {
+			if seen[ref] {
+				continue
+			}
+			seen[ref] = true
+		}
+		child, _ := d.resolve(kid).(dict)
+		d.walkPages(child, inherited, seen)
+	}
+}
+
+// contents 返回页面内容流解码后的数据，多个内容流之间用换行连接
+func (d *Document) contents(p page) ([]byte, error) {
+	var streams []any
+	switch c := d.resolve(p.dict[name("Contents")]).(type) {
+	case *stream:
+		streams = append(streams, c)
+	case array:
+		streams = c
+	}
+	var buf bytes.Buffer
+	for _, v := range streams {
+		s, ok := d.resolve(v).(*stream)
+		if !ok {
+			continue
+		}
+		data, err := d.decode(s)
+		if err != nil {
+			return nil, fmt.Errorf("decode page contents: %w", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,163 @@
+package pdf
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/unicode/norm"
+)
+
+// encoding 单字节编码到 Unicode 的映射，0 表示没有对应的字符
+type encoding [256]rune
+
+// standardEncoding Adobe 标准编码，Type1 字体没有指定编码时使用
+var standardEncoding = func() encoding {
+	var e encoding
+	for c := 0x20; c < 0x7f; c++ {
+		e[c] = rune(c)
+	}
+	e['\''] = '’'
+	e['`'] = '‘'
+	for c, r := range map[int]rune{
+		0xa1: '¡', 0xa2: '¢', 0xa3: '£', 0xa4: '⁄', 0xa5: '¥', 0xa6: 'ƒ', 0xa7: '§', 0xa8: '¤',
+		0xa9: '\'', 0xaa: '“', 0xab: '«', 0xac: '‹', 0xad: '›', 0xae: 'ﬁ', 0xaf: 'ﬂ',
+		0xb1: '–', 0xb2: '†', 0xb3: '‡', 0xb4: '·', 0xb6: '¶', 0xb7: '•', 0xb8: '‚', 0xb9: '„',
+		0xba: '”', 0xbb: '»', 0xbc: '…', 0xbd: '‰', 0xbf: '¿',
+		0xc1: '`', 0xc2: '´', 0xc3: 'ˆ', 0xc4: '˜', 0xc5: '¯', 0xc6: '˘', 0xc7: '˙', 0xc8: '¨',
+		0xca: '˚', 0xcb: '¸', 0xcd: '˝', 0xce: '˛', 0xcf: 'ˇ', 0xd0: '—',
+		0xe1: 'Æ', 0xe3: 'ª', 0xe8: 'Ł', 0xe9: 'Ø', 0xea: 'Œ', 0xeb: 'º',
+		0xf1: 'æ', 0xf5: 'ı', 0xf8: 'ł', 0xf9: 'ø', 0xfa: 'œ', 0xfb: 'ß',
+	} {
+		e[c] = r
+	}
+	return e
+}()
+
+// charmapEncoding 把 x/text 中的单字节字符集转换为编码表
+func charmapEncoding(cm *charmap.Charmap) encoding {
+	var e encoding
+	for c := 0; c < 256; c++ {
+		if r := cm.DecodeByte(byte(c)); r != utf8.RuneError {
+			e[c] = r
+		}
+	}
+	return e
+}
+
+var (
+	winAnsiEncoding  = charmapEncoding(charmap.Windows1252)
+	macRomanEncoding = charmapEncoding(charmap.Macintosh)
+)
+
+// pdfDocEncoding 与 Latin-1 只在少数位置不同，这里只用于文档信息，按 Latin-1 处理
+var pdfDocEncoding = charmapEncoding(charmap.ISO8859_1)
+
+// baseEncoding 按名称返回预定义的编码
+func baseEncoding(n name) (encoding, bool) {
+	switch n {
+	case "WinAnsiEncoding":
+		return winAnsiEncoding, true
+	case "MacRomanEncoding", "MacExpertEncoding":
+		return macRomanEncoding, true
+	case "StandardEncoding":
+		return standardEncoding, true
+	case "PDFDocEncoding":
+		return pdfDocEncoding, true
+	}
+	return encoding{}, false
+}
+
+// glyphs 常用字形名称对应的字符，覆盖 LaTeX 等生成的论文中常见的拉丁字母、标点、连字、希腊字母和数学符号
+var glyphs = map[string]string{
+	"space": " ", "exclam": "!", "quotedbl": "\"", "numbersign": "#", "dollar": "$", "percent": "%",
+	"ampersand": "&", "quotesingle": "'", "quoteright": "’", "parenleft": "(", "parenright": ")",
+	"asterisk": "*", "plus": "+", "comma": ",", "hyphen": "-", "period": ".", "slash": "/",
+	"zero": "0", "one": "1", "two": "2", "three": "3", "four": "4", "five": "5", "six": "6",
+	"seven": "7", "eight": "8", "nine": "9", "colon": ":", "semicolon": ";", "less": "<",
+	"equal": "=", "greater": ">", "question": "?", "at": "@", "bracketleft": "[", "backslash": "\\",
+	"bracketright": "]", "asciicircum": "^", "underscore": "_", "quoteleft": "‘", "grave": "`",
+	"braceleft": "{", "bar": "|", "braceright": "}", "asciitilde": "~",
+	"ff": "ff", "fi": "fi", "fl": "fl", "ffi": "ffi", "ffl": "ffl",
+	"endash": "–", "emdash": "—", "quotedblleft": "“", "quotedblright": "”", "quotesinglbase": "‚",
+	"quotedblbase": "„", "guillemotleft": "«", "guillemotright": "»", "guilsinglleft": "‹",
+	"guilsinglright": "›", "bullet": "•", "dagger": "†", "daggerdbl": "‡", "section": "§",
+	"paragraph": "¶", "ellipsis": "…", "degree": "°", "minus": "−", "multiply": "×", "divide": "÷",
+	"plusminus": "±", "periodcentered": "·", "dotlessi": "ı", "dotlessj": "ȷ", "germandbls": "ß",
+	"ae": "æ", "AE": "Æ", "oe": "œ", "OE": "Œ", "oslash": "ø", "Oslash": "Ø", "lslash": "ł",
+	"Lslash": "Ł", "copyright": "©", "registered": "®", "trademark": "™", "cent": "¢",
+	"sterling": "£", "yen": "¥", "Euro": "€", "perthousand": "‰", "fraction": "⁄",
+	"exclamdown": "¡", "questiondown": "¿", "ordfeminine": "ª", "ordmasculine": "º",
+	"nbspace": " ", "sfthyphen": "-", "visiblespace": "␣",
+	"alpha": "α", "beta": "β", "gamma": "γ", "delta": "δ", "epsilon": "ε", "epsilon1": "ϵ",
+	"zeta": "ζ", "eta": "η", "theta": "θ", "theta1": "ϑ", "iota": "ι", "kappa": "κ",
+	"lambda": "λ", "mu": "μ", "nu": "ν", "xi": "ξ", "omicron": "ο", "pi": "π", "rho": "ρ",
+	"sigma": "σ", "sigma1": "ς", "tau": "τ", "upsilon": "υ", "phi": "φ", "phi1": "ϕ", "chi": "χ",
+	"psi": "ψ", "omega": "ω", "Gamma": "Γ", "Delta": "Δ", "Theta": "Θ", "Lambda": "Λ", "Xi": "Ξ",
+	"Pi": "Π", "Sigma": "Σ", "Upsilon": "Υ", "Phi": "Φ", "Psi": "Ψ", "Omega": "Ω",
+	"infinity": "∞", "partialdiff": "∂", "summation": "∑", "product": "∏", "integral": "∫",
+	"radical": "√", "lessequal": "≤", "greaterequal": "≥", "notequal": "≠", "approxequal": "≈",
+	"equivalence": "≡", "element": "∈", "notelement": "∉", "arrowright": "→", "arrowleft": "←",
+	"arrowdblright": "⇒", "arrowdblleft": "⇐", "arrowboth": "↔", "arrowup": "↑", "arrowdown": "↓",
+	"universal": "∀", "existential": "∃", "nabla": "∇", "emptyset": "∅", "intersection": "∩",
+	"union": "∪", "propersubset": "⊂", "propersuperset": "⊃", "reflexsubset": "⊆",
+	"reflexsuperset": "⊇", "logicaland": "∧", "logicalor": "∨", "logicalnot": "¬",
+	"angleleft": "⟨", "angleright": "⟩", "prime": "′", "similar": "∼", "proportional": "∝",
+	"circleplus": "⊕", "circlemultiply": "⊗", "dotmath": "⋅", "asteriskmath": "∗",
+}
+
+// accents 字形名称中的重音后缀对应的组合附加符号
+var accents = map[string]rune{
+	"acute": '́', "grave": '̀', "circumflex": '̂', "dieresis": '̈',
+	"tilde": '̃', "ring": '̊', "cedilla": '̧', "caron": '̌',
+	"macron": '̄', "breve": '̆', "ogonek": '̨', "dotaccent": '̇',
+	"hungarumlaut": '̋', "commaaccent": '̦',
+}
+
+// glyphText 返回字形名称对应的文本，无法识别时返回空字符串。
+// 支持 uniXXXX、uXXXX 形式的名称、带 .sc 等后缀的变体、用下划线连接的连字以及带重音的字母
+func glyphText(g string) string {
+	if base, _, ok := strings.Cut(g, "."); ok && base != "" {
+		g = base
+	}
+	if s, ok := glyphs[g]; ok {
+		return s
+	}
+	if len(g) == 1 && (g[0] >= 'a' && g[0] <= 'z' || g[0] >= 'A' && g[0] <= 'Z') {
+		return g
+	}
+	if strings.Contains(g, "_") {
+		var b strings.Builder
+		for _, part := range strings.Split(g, "_") {
+			s := glyphText(part)
+			if s == "" {
+				return ""
+			}
+			b.WriteString(s)
+		}
+		return b.String()
+	}
+	if hexCodes, ok := strings.CutPrefix(g, "uni"); ok && len(hexCodes) >= 4 && len(hexCodes)%4 == 0 {
+		var b strings.Builder
+		for i := 0; i < len(hexCodes); i += 4 {
+			v, err := strconv.ParseUint(hexCodes[i:i+4], 16, 16)
+			if err != nil {
+				return ""
+			}
+			b.WriteRune(rune(v))
+		}
+		return b.String()
+	}
+	if code, ok := strings.CutPrefix(g, "u"); ok && len(code) >= 4 && len(code) <= 6 {
+		if v, err := strconv.ParseUint(code, 16, 32); err == nil {
+			return string(rune(v))
+		}
+	}
+	if len(g) > 1 {
+		if mark, ok := accents[g[1:]]; ok && (g[0] >= 'a' && g[0] <= 'z' || g[0] >= 'A' && g[0] <= 'Z') {
+			return norm.NFC.String(string([]rune{rune(g[0]), mark}))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,272 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/ascii85"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// decode 按流字典中的过滤器依次解码流数据，图片专用的过滤器不支持
+func (d *Document) decode(s *stream) ([]byte, error) {
+	data, filter, _, err := d.decodeImage(s)
+	if err == nil && filter != "" {
+		err = fmt.Errorf("pdf: unsupported filter %v", filter)
+	}
+	return data, err
+}
+
+// imageFilters 图片专用的过滤器，只能是最后一个过滤器，解码后直接得到图片
+var imageFilters = map[name]name{
+	"DCTDecode": "DCTDecode", "DCT": "DCTDecode",
+	"CCITTFaxDecode": "CCITTFaxDecode", "CCF": "CCITTFaxDecode",
+	"JBIG2Decode": "JBIG2Decode", "JPXDecode": "JPXDecode",
+}
+
+// decodeImage 依次解码流数据，遇到图片专用的过滤器时停止，返回该过滤器的名称和参数
+func (d *Document) decodeImage(s *stream) ([]byte, name, dict, error) {
+	var filters, params array
+	switch f := d.resolve(s.hdr[name("Filter")]).(type) {
+	case name:
+		filters = array{f}
+	case array:
+		filters = f
+	}
+	switch p := d.resolve(s.hdr[name("DecodeParms")]).(type) {
+	case dict:
+		params = array{p}
+	case array:
+		params = p
+	}
+
+	data := s.data
+	for i, f := range filters {
+		var param dict
+		if i < len(params) {
+			param, _ = d.resolve(params[i]).(dict)
+		}
+		if n, ok := d.resolve(f).(name); ok && imageFilters[n] != "" {
+			return data, imageFilters[n], param, nil
+		}
+		var err error
+		switch d.resolve(f) {
+		case name("FlateDecode"), name("Fl"):
+			data, err = inflate(data)
+			if err == nil {
+				data, err = unpredict(data, param)
+			}
+		case name("ASCIIHexDecode"), name("AHx"):
+			data, err = asciiHex(data)
+		case name("ASCII85Decode"), name("A85"):
+			data, err = ascii85Decode(data)
+		case name("RunLengthDecode"), name("RL"):
+			data = runLength(data)
+		case name("LZWDecode"), name("LZW"):
+			early, ok := param[name("EarlyChange")].(int64)
+			data = lzw(data, !ok || early != 0)
+			data, err = unpredict(data, param)
+		default:
+			err = fmt.Errorf("pdf: unsupported filter %v", f)
+		}
+		if err != nil {
+			return nil, "", nil, err
+		}
+	}
+	return data, "", nil, nil
+}
+
+// inflate 解压 zlib 数据，数据截断时返回已经解压的部分
+func inflate(data []byte) ([]byte, error) {
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	out, err := io.ReadAll(r)
+	if err != nil && len(out) == 0 {
+		return nil, err
+	}
+	return out, nil
+}
+
+// unpredict 还原 PNG 预测器，交叉引用流通常使用
+func unpredict(data []byte, param dict) ([]byte, error) {
+	predictor, _ := param[name("Predictor")].(int64)
+	if predictor < 10 {
+		return data, nil
+	}
+	columns, colors, bpc := int64(1), int64(1), int64(8)
+	if v, ok := param[name("Columns")].(int64); ok {
+		columns = v
+	}
+	if v, ok := param[name("Colors")].(int64); ok {
+		colors = v
+	}
+	if v, ok := param[name("BitsPerComponent")].(int64); ok {
+		bpc = v
+	}
+	bpp := int(max(colors*bpc/8, 1))
+	rowSize := int((columns*colors*bpc + 7) / 8)
+	if rowSize <= 0 {
+		return nil, errSyntax
+	}
+
+	var out []byte
+	prev := make([]byte, rowSize)
+	for pos := 0; pos+1+rowSize <= len(data); pos += 1 + rowSize {
+		filter, row := data[pos], append([]byte(nil), data[pos+1:pos+1+rowSize]...)
+		for i := range row {
+			var left, upLeft byte
+			if i >= bpp {
+				left, upLeft = row[i-bpp], prev[i-bpp]
+			}
+			up := prev[i]
+			switch filter {
+			case 1:
+				row[i] += left
+			case 2:
+				row[i] += up
+			case 3:
+				row[i] += byte((int(left) + int(up)) / 2)
+			case 4:
+				row[i] += paeth(left, up, upLeft)
+			}
+		}
+		out = append(out, row...)
+		prev = row
+	}
+	return out, nil
+}
+
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	if pa <= pb && pa <= pc {
+		return a
+	}
+	if pb <= pc {
+		return b
+	}
+	return c
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func asciiHex(data []byte) ([]byte, error) {
+	if i := bytes.IndexByte(data, '>'); i >= 0 {
+		data = data[:i]
+	}
+	digits := bytes.Map(func(r rune) rune {
+		if isWhite(byte(r)) {
+			return -1
+		}
+		return r
+	}, data)
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	out := make([]byte, len(digits)/2)
+	_, err := hex.Decode(out, digits)
+	return out, err
+}
+
+func ascii85Decode(data []byte) ([]byte, error) {
+	data = bytes.TrimPrefix(bytes.TrimSpace(data), []byte("<~"))
+	if i := bytes.Index(data, []byte("~>")); i >= 0 {
+		data = data[:i]
+	}
+	// z 表示四个零字节，按最大可能的长度分配
+	out := make([]byte, 4*len(data)+4)
+	n, _, err := ascii85.Decode(out, data, true)
+	if err != nil {
+		return nil, err
+	}
+	return out[:n], nil
+}
+
+// runLength 解码 RunLengthDecode，长度字节小于 128 时复制后面的字节，大于 128 时重复下一个字节
+func runLength(data []byte) []byte {
+	var out []byte
+	for i := 0; i < len(data); {
+		n := int(data[i])
+		i++
+		switch {
+		case n == 128:
+			return out
+		case n < 128:
+			end := min(i+n+1, len(data))
+			out = append(out, data[i:end]...)
+			i = end
+		case i < len(data):
+			out = append(out, bytes.Repeat(data[i:i+1], 257-n)...)
+			i++
+		}
+	}
+	return out
+}
+
+// lzw 解码 LZWDecode，与 compress/lzw 不同，PDF 默认提前一个编码增加码长
+func lzw(data []byte, early bool) []byte {
+	var out []byte
+	var table [][]byte
+	reset := func() {
+		table = table[:0]
+		for i := 0; i < 256; i++ {
+			table = append(table, []byte{byte(i)})
+		}
+		// 256 清空编码表，257 结束
+		table = append(table, nil, nil)
+	}
+	reset()
+	width, bits, nbits := 9, 0, 0
+	var prev []byte
+	for _, c := range data {
+		bits = bits<<8 | int(c)
+		nbits += 8
+		for nbits >= width {
+			code := bits >> (nbits - width) & (1<<width - 1)
+			nbits -= width
+			switch {
+			case code == 256:
+				reset()
+				width, prev = 9, nil
+				continue
+			case code == 257:
+				return out
+			}
+			var entry []byte
+			if code < len(table) {
+				entry = table[code]
+			} else if prev != nil {
+				entry = append(append([]byte(nil), prev...), prev[0])
+			} else {
+				return out
+			}
+			out = append(out, entry...)
+			if prev != nil && len(table) < 4096 {
+				table = append(table, append(append([]byte(nil), prev...), entry[0]))
+			}
+			prev = entry
+			next := len(table)
+			if early {
+				next++
+			}
+			switch {
+			case next >= 2048:
+				width = 12
+			case next >= 1024:
+				width = 11
+			case next >= 512:
+				width = 10
+			}
+		}
+	}
+	return out
+}
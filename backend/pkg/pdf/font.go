@@ -0,0 +1,412 @@
+package pdf
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"unicode/utf16"
+)
+
+// font 把字符串中的字符编码转换为文本，并提供字形宽度用于判断字间距
+type font struct {
+	// twoByte Type0 字体的编码，没有 ToUnicode 时按两字节处理
+	twoByte bool
+	// cmap ToUnicode 映射，优先于 encoding
+	cmap *cmap
+	// encoding 简单字体的编码
+	encoding encoding
+	// unknown 自定义编码中无法识别的字形，Type3 字体常见
+	unknown map[int]bool
+	widths  map[int]float64
+	dw      float64
+
+	// 以下用于渲染字形
+	dict dict
+	// differences Differences 中指定的字形名称
+	differences map[int]string
+	// explicitEncoding PDF 中指定了基础编码，否则使用字体程序的内置编码
+	explicitEncoding bool
+	// outlines 字形轮廓，第一次渲染时加载
+	outlines     glyphSource
+	outlinesRead bool
+	glyphs       map[code]outline
+}
+
+// code 字符串中的一个字符编码
+type code struct {
+	value int
+	bytes int
+}
+
+// cmap ToUnicode 映射，只支持 bfchar 和 bfrange
+type cmap struct {
+	// codespace 各长度的编码范围，为空时按一字节处理
+	codespace []codespace
+	chars     map[code]string
+}
+
+type codespace struct {
+	bytes     int
+	low, high int
+}
+
+// font 读取字体字典，按间接引用缓存
+func (d *Document) font(v any) *font {
+	ref, isRef := v.(objref)
+	if isRef {
+		if f, ok := d.fonts[ref]; ok {
+			return f
+		}
+	}
+	f := d.loadFont(d.resolve(v))
+	if isRef {
+		d.fonts[ref] = f
+	}
+	return f
+}
+
+func (d *Document) loadFont(v any) *font {
+	f := &font{encoding: standardEncoding, widths: map[int]float64{}, dw: 500}
+	fd, ok := v.(dict)
+	if !ok {
+		return f
+	}
+	f.dict = fd
+	subtype := d.resolve(fd[name("Subtype")])
+	if s, ok := d.resolve(fd[name("ToUnicode")]).(*stream); ok {
+		if data, err := d.decode(s); err == nil {
+			f.cmap = parseCMap(data)
+		}
+	}
+
+	if subtype == name("Type0") {
+		f.twoByte = true
+		f.dw = 1000
+		descendants, _ := d.resolve(fd[name("DescendantFonts")]).(array)
+		if len(descendants) > 0 {
+			if cid, ok := d.resolve(descendants[0]).(dict); ok {
+				d.cidWidths(f, cid)
+			}
+		}
+		return f
+	}
+
+	d.simpleEncoding(f, fd)
+	first, _ := d.resolve(fd[name("FirstChar")]).(int64)
+	widths, _ := d.resolve(fd[name("Widths")]).(array)
+	for i, w := range widths {
+		f.widths[int(first)+i] = number(d.resolve(w))
+	}
+	if subtype == name("Type3") {
+		// Type3 字体的宽度使用字形空间，按字体矩阵换算到文本空间的千分之一
+		if m, ok := d.resolve(fd[name("FontMatrix")]).(array); ok && len(m) > 0 {
+			scale := number(d.resolve(m[0])) * 1000
+			for c, w := range f.widths {
+				f.widths[c] = w * scale
+			}
+		}
+	}
+	return f
+}
+
+// simpleEncoding 确定简单字体的编码：基础编码、内嵌 Type1 字体的内置编码，再应用 Differences
+func (d *Document) simpleEncoding(f *font, fd dict) {
+	if fontFile := d.fontFile(fd); fontFile != nil {
+		if e, ok := builtinEncoding(fontFile); ok {
+			f.encoding = e
+		}
+	}
+	switch enc := d.resolve(fd[name("Encoding")]).(type) {
+	case name:
+		if e, ok := baseEncoding(enc); ok {
+			f.encoding, f.explicitEncoding = e, true
+		}
+	case dict:
+		if base, ok := d.resolve(enc[name("BaseEncoding")]).(name); ok {
+			if e, ok := baseEncoding(base); ok {
+				f.encoding, f.explicitEncoding = e, true
+			}
+		}
+		differences, _ := d.resolve(enc[name("Differences")]).(array)
+		c := 0
+		for _, v := range differences {
+			switch v := d.resolve(v).(type) {
+			case int64:
+				c = int(v)
+			case name:
+				if c < 0 || c > 255 {
+					continue
+				}
+				if f.differences == nil {
+					f.differences = map[int]string{}
+				}
+				f.differences[c] = string(v)
+				if s := glyphText(string(v)); s != "" {
+					r := []rune(s)
+					f.encoding[c] = r[0]
+					if len(r) > 1 {
+						// 连字等多字符的字形通过 cmap 输出
+						if f.cmap == nil {
+							f.cmap = &cmap{chars: map[code]string{}}
+						}
+						if _, ok := f.cmap.chars[code{c, 1}]; !ok {
+							f.cmap.chars[code{c, 1}] = s
+						}
+					}
+				} else {
+					f.encoding[c] = 0
+					if f.unknown == nil {
+						f.unknown = map[int]bool{}
+					}
+					f.unknown[c] = true
+				}
+				c++
+			}
+		}
+	}
+}
+
+// fontFile 返回简单字体内嵌的 Type1 字体程序
+func (d *Document) fontFile(fd dict) []byte {
+	desc, ok := d.resolve(fd[name("FontDescriptor")]).(dict)
+	if !ok {
+		return nil
+	}
+	s, ok := d.resolve(desc[name("FontFile")]).(*stream)
+	if !ok {
+		return nil
+	}
+	data, err := d.decode(s)
+	if err != nil {
+		return nil
+	}
+	// 内置编码在明文部分，不需要解密
+	if n, ok := d.resolve(s.hdr[name("Length1")]).(int64); ok && int(n) <= len(data) {
+		data = data[:n]
+	}
+	return data
+}
+
+var dupPattern = regexp.MustCompile(`dup (\d+) ?/(\S+) put`)
+
+// builtinEncoding 解析 Type1 字体程序中的内置编码，LaTeX 嵌入的 Computer Modern 字体通常使用
+func builtinEncoding(program []byte) (encoding, bool) {
+	if bytes.Contains(program, []byte("/Encoding StandardEncoding")) {
+		return standardEncoding, true
+	}
+	var e encoding
+	matches := dupPattern.FindAllSubmatch(program, -1)
+	for _, m := range matches {
+		c, err := strconv.Atoi(string(m[1]))
+		if err != nil || c > 255 {
+			continue
+		}
+		if s := glyphText(string(m[2])); s != "" {
+			e[c] = []rune(s)[0]
+		}
+	}
+	return e, len(matches) > 0
+}
+
+// cidWidths 读取 CID 字体的默认宽度 DW 和宽度数组 W
+func (d *Document) cidWidths(f *font, cid dict) {
+	if dw, ok := d.resolve(cid[name("DW")]).(int64); ok {
+		f.dw = float64(dw)
+	}
+	w, _ := d.resolve(cid[name("W")]).(array)
+	for i := 0; i < len(w); {
+		first, ok := d.resolve(w[i]).(int64)
+		if !ok || i+1 >= len(w) {
+			return
+		}
+		// 两种形式：c [w1 w2 ...] 或 cFirst cLast w
+		if list, ok := d.resolve(w[i+1]).(array); ok {
+			for j, v := range list {
+				f.widths[int(first)+j] = number(d.resolve(v))
+			}
+			i += 2
+			continue
+		}
+		if i+2 >= len(w) {
+			return
+		}
+		last, _ := d.resolve(w[i+1]).(int64)
+		width := number(d.resolve(w[i+2]))
+		for c := first; c <= last && c-first < 65536; c++ {
+			f.widths[int(c)] = width
+		}
+		i += 3
+	}
+}
+
+// codes 把字符串拆分为字符编码
+func (f *font) codes(s string) []code {
+	var codes []code
+	for i := 0; i < len(s); {
+		n := 1
+		if f.cmap != nil && len(f.cmap.codespace) > 0 {
+			n = f.cmap.codeLength(s[i:])
+		} else if f.twoByte {
+			n = 2
+		}
+		n = min(n, len(s)-i)
+		v := 0
+		for j := 0; j < n; j++ {
+			v = v<<8 | int(s[i+j])
+		}
+		codes = append(codes, code{v, n})
+		i += n
+	}
+	return codes
+}
+
+// text 返回字符编码对应的文本
+func (f *font) text(c code) string {
+	if f.cmap != nil {
+		if s, ok := f.cmap.chars[c]; ok {
+			return s
+		}
+	}
+	if f.twoByte || c.bytes != 1 {
+		// 没有 ToUnicode 的 CID 字体无法得到文本
+		return "�"
+	}
+	if r := f.encoding[c.value]; r != 0 {
+		return string(r)
+	}
+	if f.unknown[c.value] {
+		return "�"
+	}
+	return ""
+}
+
+// width 返回字形宽度，单位为文本空间的千分之一
+func (f *font) width(c code) float64 {
+	if w, ok := f.widths[c.value]; ok && w > 0 {
+		return w
+	}
+	return f.dw
+}
+
+// codeLength 按 codespacerange 确定下一个字符编码的字节数
+func (m *cmap) codeLength(s string) int {
+	for n := 1; n <= 4 && n <= len(s); n++ {
+		v := 0
+		for j := 0; j < n; j++ {
+			v = v<<8 | int(s[j])
+		}
+		for _, r := range m.codespace {
+			if r.bytes == n && v >= r.low && v <= r.high {
+				return n
+			}
+		}
+	}
+	return m.codespace[0].bytes
+}
+
+// parseCMap 解析 ToUnicode CMap 中的 codespacerange、bfchar 和 bfrange
+func parseCMap(data []byte) *cmap {
+	m := &cmap{chars: map[code]string{}}
+	l := &lexer{data: data}
+	var operands []any
+	for {
+		tok, err := l.token()
+		if err != nil {
+			break
+		}
+		kw, ok := tok.(keyword)
+		if !ok {
+			operands = append(operands, tok)
+			continue
+		}
+		switch kw {
+		case "begincodespacerange", "beginbfchar", "beginbfrange":
+			operands = operands[:0]
+			continue
+		case "[":
+			v, err := l.objectFrom(tok)
+			if err != nil {
+				return m
+			}
+			operands = append(operands, v)
+			continue
+		case "endcodespacerange":
+			for i := 0; i+1 < len(operands); i += 2 {
+				low, ok1 := operands[i].(string)
+				high, ok2 := operands[i+1].(string)
+				if ok1 && ok2 && len(low) > 0 {
+					m.codespace = append(m.codespace, codespace{len(low), bytesValue(low), bytesValue(high)})
+				}
+			}
+		case "endbfchar":
+			for i := 0; i+1 < len(operands); i += 2 {
+				src, ok1 := operands[i].(string)
+				dst, ok2 := operands[i+1].(string)
+				if ok1 && ok2 && len(src) > 0 {
+					m.chars[code{bytesValue(src), len(src)}] = utf16Text(dst)
+				}
+			}
+		case "endbfrange":
+			for i := 0; i+2 < len(operands); i += 3 {
+				low, ok1 := operands[i].(string)
+				high, ok2 := operands[i+1].(string)
+				if !ok1 || !ok2 || len(low) == 0 {
+					continue
+				}
+				lo, hi := bytesValue(low), bytesValue(high)
+				if hi-lo > 65535 {
+					continue
+				}
+				switch dst := operands[i+2].(type) {
+				case string:
+					// 目标的最后一个字节随编码递增
+					b := []byte(dst)
+					for c := lo; c <= hi && len(b) > 0; c++ {
+						m.chars[code{c, len(low)}] = utf16Text(string(b))
+						b = append([]byte(nil), b...)
+						b[len(b)-1]++
+					}
+				case array:
+					for j, v := range dst {
+						if s, ok := v.(string); ok && lo+j <= hi {
+							m.chars[code{lo + j, len(low)}] = utf16Text(s)
+						}
+					}
+				}
+			}
+		}
+		operands = operands[:0]
+	}
+	return m
+}
+
+func bytesValue(s string) int {
+	v := 0
+	for i := 0; i < len(s); i++ {
+		v = v<<8 | int(s[i])
+	}
+	return v
+}
+
+// utf16Text 把 UTF-16BE 字节串转换为文本
+func utf16Text(s string) string {
+	if len(s)%2 == 1 {
+		s += "\x00"
+	}
+	u := make([]uint16, len(s)/2)
+	for i := range u {
+		u[i] = uint16(s[2*i])<<8 | uint16(s[2*i+1])
+	}
+	return string(utf16.Decode(u))
+}
+
+// number 返回整数或实数的值
+func number(v any) float64 {
+	switch n := v.(type) {
+	case int64:
+		return float64(n)
+	case float64:
+		return n
+	}
+	return 0
+}
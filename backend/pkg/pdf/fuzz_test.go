@@ -0,0 +1,64 @@
+package pdf_test
+
+import (
+	"paper-translation/pkg/pdf"
+	"testing"
+)
+
+// fuzzSeeds 模糊测试的初始语料，覆盖交叉引用表、字体、图片和加密等路径
+func fuzzSeeds() [][]byte {
+	return [][]byte{
+		simplePDF(),
+		encryptedPDF(nil),
+		pagePDF("[0 0 100 50]", "/Rotate 90", "0 g 10 10 30 20 re f 0.5 g 60 10 20 20 re f"),
+		pagePDF("[0 0 8 2]", "/Resources << /XObject << /Im 5 0 R >> >>", "q 8 0 0 2 0 0 cm /Im Do Q",
+			streamObject("/Type /XObject /Subtype /Image /Width 8 /Height 2 /BitsPerComponent 1 /ColorSpace /DeviceGray "+
+				"/Filter /CCITTFaxDecode /DecodeParms << /K -1 /Columns 8 /Rows 2 >>", []byte{0x2f, 0x78})),
+		pagePDF("[0 0 100 100]", "/Resources << /Font << /F1 5 0 R >> >>", "BT /F1 20 Tf 10 10 Td (ab) Tj ET",
+			"<< /Type /Font /Subtype /Type3 /FontBBox [0 0 100 100] /FontMatrix [0.01 0 0 0.01 0 0] "+
+				"/CharProcs << /square 6 0 R >> /Encoding << /Differences [97 /square] >> /FirstChar 97 /LastChar 98 /Widths [150 150] >>",
+			streamObject("", []byte("100 0 0 0 100 100 d1 0 0 100 100 re f"))),
+	}
+}
+
+// fuzzPages 每个输入最多处理的页数，避免页数很多的输入拖慢模糊测试
+const fuzzPages = 4
+
+/**
+ * FuzzOpen 测试任意输入都不会让解析和文本提取崩溃或卡住，错误的输入只返回错误。
+ */
+func FuzzOpen(f *testing.F) {
+	for _, seed := range fuzzSeeds() {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		d, err := pdf.Open(data)
+		if err != nil {
+			return
+		}
+		for n := 1; n <= min(d.NumPages(), fuzzPages); n++ {
+			_, _ = d.PageText(n)
+		}
+	})
+}
+
+/**
+ * FuzzRenderPage 测试任意输入渲染页面时不会崩溃或卡住，成功时图片不为空。
+ */
+func FuzzRenderPage(f *testing.F) {
+	for _, seed := range fuzzSeeds() {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		d, err := pdf.Open(data)
+		if err != nil {
+			return
+		}
+		for n := 1; n <= min(d.NumPages(), fuzzPages); n++ {
+			img, err := d.RenderPage(n, 36)
+			if err == nil && img.Rect.Empty() {
+				t.Fatalf("page %d rendered an empty image", n)
+			}
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package pdf
+
+// glyphSource 字体程序中的字形轮廓
+type glyphSource interface {
+	// outline 返回字符编码对应的字形轮廓，坐标已经按字体矩阵换算到文本空间
+	outline(f *font, c code) (outline, bool)
+}
+
+// segment 轮廓中的一段，op 为 M（移动）、L（直线）、Q（二次曲线）、C（三次曲线）或 Z（闭合）
+type segment struct {
+	op  byte
+	pts [3]point
+}
+
+type outline []segment
+
+// pen 解释字形程序时构建轮廓，坐标按 m 换算
+type pen struct {
+	out  outline
+	m    matrix
+	x, y float64
+	open bool
+}
+
+func (p *pen) moveTo(x, y float64) {
+	p.closePath()
+	p.x, p.y = x, y
+	p.out = append(p.out, segment{op: 'M', pts: [3]point{p.m.apply(x, y)}})
+	p.open = true
+}
+
+func (p *pen) lineTo(x, y float64) {
+	if !p.open {
+		p.moveTo(p.x, p.y)
+	}
+	p.x, p.y = x, y
+	p.out = append(p.out, segment{op: 'L', pts: [3]point{p.m.apply(x, y)}})
+}
+
+func (p *pen) curveTo(x1, y1, x2, y2, x3, y3 float64) {
+	if !p.open {
+		p.moveTo(p.x, p.y)
+	}
+	p.x, p.y = x3, y3
+	p.out = append(p.out, segment{op: 'C', pts: [3]point{p.m.apply(x1, y1), p.m.apply(x2, y2), p.m.apply(x3, y3)}})
+}
+
+func (p *pen) closePath() {
+	if p.open {
+		p.out = append(p.out, segment{op: 'Z'})
+		p.open = false
+	}
+}
+
+// glyphIndex 查找简单字体中字符编码对应的字形：依次使用 Differences 中的名称、
+// 字体程序的内置编码（PDF 没有指定编码时）和编码对应的 Unicode 字符
+func glyphIndex(f *font, c int, names map[string]int, builtin map[int]int, byRune map[rune]int) (int, bool) {
+	if n, ok := f.differences[c]; ok {
+		if g, ok := names[n]; ok {
+			return g, true
+		}
+	}
+	if !f.explicitEncoding {
+		if g, ok := builtin[c]; ok {
+			return g, true
+		}
+	}
+	if c >= 0 && c < 256 && f.encoding[c] != 0 {
+		if g, ok := byRune[f.encoding[c]]; ok {
+			return g, true
+		}
+	}
+	return 0, false
+}
+
+// runeIndex 按字形名称建立 Unicode 字符到字形的映射
+func runeIndex(names map[string]int) map[rune]int {
+	byRune := make(map[rune]int, len(names))
+	for n, g := range names {
+		s := []rune(glyphText(n))
+		if len(s) != 1 {
+			continue
+		}
+		if old, ok := byRune[s[0]]; !ok || g < old {
+			byRune[s[0]] = g
+		}
+	}
+	return byRune
+}
+
+// outlines 返回字体内嵌的字形轮廓，没有内嵌字体或无法解析时返回 nil，结果缓存在字体中
+func (d *Document) outlines(f *font) glyphSource {
+	if !f.outlinesRead {
+		f.outlinesRead = true
+		f.outlines = d.loadOutlines(f)
+	}
+	return f.outlines
+}
+
+func (d *Document) loadOutlines(f *font) glyphSource {
+	fd := f.dict
+	if f.twoByte {
+		descendants, _ := d.resolve(fd[name("DescendantFonts")]).(array)
+		if len(descendants) == 0 {
+			return nil
+		}
+		fd, _ = d.resolve(descendants[0]).(dict)
+	}
+	desc, ok := d.resolve(fd[name("FontDescriptor")]).(dict)
+	if !ok {
+		return nil
+	}
+	for _, key := range []name{"FontFile", "FontFile2", "FontFile3"} {
+		s, ok := d.resolve(desc[key]).(*stream)
+		if !ok {
+			continue
+		}
+		data, err := d.decode(s)
+		if err != nil {
+			return nil
+		}
+		var src glyphSource
+		switch {
+		case isSFNT(data):
+			src, err = d.openType(f, fd, desc, data)
+		case key == "FontFile":
+			src, err = parseType1(data)
+		default:
+			src, err = parseCFF(data)
+		}
+		if err != nil {
+			return nil
+		}
+		return src
+	}
+	return nil
+}
+
+func isSFNT(data []byte) bool {
+	if len(data) < 4 {
+		return false
+	}
+	switch string(data[:4]) {
+	case "\x00\x01\x00\x00", "OTTO", "true", "ttcf":
+		return true
+	}
+	return false
+}
+
+// openType 解析 TrueType 或 OpenType 字体，OpenType 中的 CFF 表按 CFF 字体处理
+func (d *Document) openType(f *font, fd, desc dict, data []byte) (glyphSource, error) {
+	s, err := parseSFNT(data)
+	if err != nil {
+		return nil, err
+	}
+	if table, ok := s.tables["CFF "]; ok {
+		return parseCFF(table)
+	}
+	t, err := parseTrueType(data)
+	if err != nil {
+		return nil, err
+	}
+	flags, _ := d.resolve(desc[name("Flags")]).(int64)
+	t.symbolic = flags&4 != 0
+	t.cid = f.twoByte
+	if m, ok := d.resolve(fd[name("CIDToGIDMap")]).(*stream); ok {
+		if data, err := d.decode(m); err == nil {
+			t.cidToGID = data
+		}
+	}
+	return t, nil
+}
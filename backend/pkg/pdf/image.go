@@ -0,0 +1,375 @@
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math"
+)
+
+// colorSpace 颜色空间，渲染结果是灰度图，所有颜色都转换为亮度
+type colorSpace struct {
+	kind   name // DeviceGray、DeviceRGB、DeviceCMYK、Lab、Indexed、Separation、DeviceN 或 Pattern
+	n      int  // 每个颜色的分量数
+	base   *colorSpace
+	lookup []byte // Indexed 的颜色表
+	hival  int
+}
+
+var (
+	deviceGray = &colorSpace{kind: "DeviceGray", n: 1}
+	deviceRGB  = &colorSpace{kind: "DeviceRGB", n: 3}
+	deviceCMYK = &colorSpace{kind: "DeviceCMYK", n: 4}
+)
+
+// colorSpace 解析颜色空间，无法识别时按灰度处理
+func (d *Document) colorSpace(v any, resources dict) *colorSpace {
+	return d.colorSpaceDepth(v, resources, 0)
+}
+
+func (d *Document) colorSpaceDepth(v any, resources dict, depth int) *colorSpace {
+	if depth > 8 {
+		return deviceGray
+	}
+	switch v := d.resolve(v).(type) {
+	case name:
+		switch v {
+		case "DeviceGray", "G", "CalGray":
+			return deviceGray
+		case "DeviceRGB", "RGB", "CalRGB":
+			return deviceRGB
+		case "DeviceCMYK", "CMYK":
+			return deviceCMYK
+		case "Pattern":
+			return &colorSpace{kind: "Pattern", n: 1}
+		}
+		// 资源中定义的颜色空间
+		spaces, _ := d.resolve(resources[name("ColorSpace")]).(dict)
+		if cs, ok := spaces[v]; ok {
+			return d.colorSpaceDepth(cs, resources, depth+1)
+		}
+	case array:
+		if len(v) == 0 {
+			break
+		}
+		family, _ := d.resolve(v[0]).(name)
+		switch family {
+		case "ICCBased":
+			if len(v) > 1 {
+				if s, ok := d.resolve(v[1]).(*stream); ok {
+					switch n, _ := d.resolve(s.hdr[name("N")]).(int64); n {
+					case 3:
+						return deviceRGB
+					case 4:
+						return deviceCMYK
+					}
+				}
+			}
+			return deviceGray
+		case "CalGray":
+			return deviceGray
+		case "CalRGB":
+			return deviceRGB
+		case "Lab":
+			return &colorSpace{kind: "Lab", n: 3}
+		case "Indexed", "I":
+			if len(v) < 4 {
+				break
+			}
+			cs := &colorSpace{kind: "Indexed", n: 1, base: d.colorSpaceDepth(v[1], resources, depth+1)}
+			hival, _ := d.resolve(v[2]).(int64)
+			cs.hival = int(hival)
+			switch lookup := d.resolve(v[3]).(type) {
+			case string:
+				cs.lookup = []byte(lookup)
+			case *stream:
+				cs.lookup, _ = d.decode(lookup)
+			}
+			return cs
+		case "Separation":
+			return &colorSpace{kind: "Separation", n: 1}
+		case "DeviceN":
+			n := 1
+			if len(v) > 1 {
+				if names, ok := d.resolve(v[1]).(array); ok {
+					n = max(len(names), 1)
+				}
+			}
+			return &colorSpace{kind: "DeviceN", n: n}
+		case "Pattern":
+			return &colorSpace{kind: "Pattern", n: 1}
+		default:
+			return d.colorSpaceDepth(family, resources, depth+1)
+		}
+	}
+	return deviceGray
+}
+
+// gray 把颜色分量转换为 0 到 1 之间的亮度
+func (cs *colorSpace) gray(c []float64) float64 {
+	at := func(i int) float64 {
+		if i < len(c) {
+			return math.Max(0, math.Min(1, c[i]))
+		}
+		return 0
+	}
+	switch cs.kind {
+	case "DeviceRGB":
+		return 0.3*at(0) + 0.59*at(1) + 0.11*at(2)
+	case "DeviceCMYK":
+		k := at(3)
+		return 0.3*(1-at(0))*(1-k) + 0.59*(1-at(1))*(1-k) + 0.11*(1-at(2))*(1-k)
+	case "Lab":
+		if len(c) > 0 {
+			return math.Max(0, math.Min(1, c[0]/100))
+		}
+	case "Indexed":
+		if len(c) == 0 || cs.base == nil {
+			return 0
+		}
+		i := int(math.Max(0, math.Min(float64(cs.hival), c[0])))
+		n := cs.base.n
+		comps := make([]float64, n)
+		for j := range comps {
+			if k := i*n + j; k < len(cs.lookup) {
+				comps[j] = float64(cs.lookup[k]) / 255
+			}
+		}
+		return cs.base.gray(comps)
+	case "Separation":
+		// 色调值表示油墨的多少，1 为满色
+		return 1 - at(0)
+	case "DeviceN":
+		v := 0.0
+		for i := range c {
+			v = math.Max(v, at(i))
+		}
+		return 1 - v
+	}
+	return at(0)
+}
+
+// raster 解码后的图片，按行存储每个像素的亮度。图片蒙版中存储的是覆盖率，255 表示用填充颜色绘制
+type raster struct {
+	w, h int
+	pix  []uint8
+	mask bool
+}
+
+var errUnsupportedImage = errors.New("pdf: unsupported image")
+
+// maxImagePixels 图片像素数量的上限，防止损坏的文件占用过多内存
+const maxImagePixels = 1 << 26
+
+// inlineKeys 内联图片字典中的缩写
+var inlineKeys = map[name]name{
+	"W": "Width", "H": "Height", "BPC": "BitsPerComponent", "CS": "ColorSpace", "D": "Decode",
+	"DP": "DecodeParms", "F": "Filter", "IM": "ImageMask", "I": "Interpolate",
+}
+
+// loadImage 解码图片 XObject 或内联图片。JBIG2 和 JPEG 2000 不支持，调用方跳过这些图片
+func (d *Document) loadImage(s *stream, resources dict) (*raster, error) {
+	hdr := dict{}
+	for k, v := range s.hdr {
+		if full, ok := inlineKeys[k]; ok {
+			k = full
+		}
+		hdr[k] = v
+	}
+	s = &stream{hdr: hdr, data: s.data}
+
+	w, _ := d.resolve(hdr[name("Width")]).(int64)
+	h, _ := d.resolve(hdr[name("Height")]).(int64)
+	if w <= 0 || h <= 0 || w*h > maxImagePixels {
+		return nil, fmt.Errorf("pdf: invalid image size %dx%d", w, h)
+	}
+	isMask, _ := d.resolve(hdr[name("ImageMask")]).(bool)
+	bpc := int64(8)
+	if v, ok := d.resolve(hdr[name("BitsPerComponent")]).(int64); ok {
+		bpc = v
+	}
+	cs := deviceGray
+	if isMask {
+		bpc = 1
+	} else if v, ok := hdr[name("ColorSpace")]; ok {
+		cs = d.colorSpace(v, resources)
+	}
+
+	data, filter, param, err := d.decodeImage(s)
+	if err != nil {
+		return nil, err
+	}
+	img := &raster{w: int(w), h: int(h), mask: isMask}
+	switch filter {
+	case "DCTDecode":
+		return img, decodeJPEG(img, data, d.decodeArray(hdr, cs, 8))
+	case "CCITTFaxDecode":
+		p := ccittParamsFrom(param)
+		if p.rows == 0 {
+			p.rows = int(h)
+		}
+		data = decodeCCITT(data, p)
+		bpc, cs = 1, deviceGray
+	case "":
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedImage, filter)
+	}
+	if bpc != 1 && bpc != 2 && bpc != 4 && bpc != 8 && bpc != 16 {
+		return nil, fmt.Errorf("%w: %d bits per component", errUnsupportedImage, bpc)
+	}
+	decodeSamples(img, data, cs, int(bpc), d.decodeArray(hdr, cs, int(bpc)))
+	return img, nil
+}
+
+// decodeArray 返回把采样值映射到颜色分量的 Decode 数组，图片蒙版只有一对
+func (d *Document) decodeArray(hdr dict, cs *colorSpace, bpc int) []float64 {
+	n := cs.n
+	if isMask, _ := d.resolve(hdr[name("ImageMask")]).(bool); isMask {
+		n = 1
+	}
+	if a, ok := d.resolve(hdr[name("Decode")]).(array); ok && len(a) >= 2*n {
+		out := make([]float64, 2*n)
+		for i := range out {
+			out[i] = number(d.resolve(a[i]))
+		}
+		return out
+	}
+	out := make([]float64, 2*n)
+	for i := 0; i < n; i++ {
+		out[2*i+1] = 1
+		if cs.kind == "Indexed" {
+			out[2*i+1] = float64(int(1)<<bpc - 1)
+		}
+	}
+	return out
+}
+
+// decodeSamples 把按位打包的采样值转换为亮度
+func decodeSamples(img *raster, data []byte, cs *colorSpace, bpc int, decode []float64) {
+	n := cs.n
+	if img.mask {
+		n = 1
+	}
+	img.pix = make([]uint8, img.w*img.h)
+	rowBytes := (img.w*n*bpc + 7) / 8
+	maxValue := float64(int(1)<<bpc - 1)
+
+	// 单分量时采样值只有有限的几种，预先计算亮度
+	var table []uint8
+	if n == 1 && bpc <= 8 {
+		table = make([]uint8, 1<<bpc)
+		for v := range table {
+			c := decode[0] + float64(v)*(decode[1]-decode[0])/maxValue
+			if img.mask {
+				// 图片蒙版中值为 0 的采样绘制填充颜色
+				if c < 0.5 {
+					table[v] = 255
+				}
+				continue
+			}
+			table[v] = uint8(cs.gray([]float64{c})*255 + 0.5)
+		}
+	}
+
+	comps := make([]float64, n)
+	for y := 0; y < img.h; y++ {
+		row := y * rowBytes
+		if row >= len(data) {
+			// 数据不完整时剩余部分为白色
+			for i := y * img.w; i < len(img.pix); i++ {
+				img.pix[i] = 255
+				if img.mask {
+					img.pix[i] = 0
+				}
+			}
+			return
+		}
+		bit := row * 8
+		for x := 0; x < img.w; x++ {
+			for i := 0; i < n; i++ {
+				v := sample(data, bit, bpc)
+				bit += bpc
+				if table != nil {
+					img.pix[y*img.w+x] = table[v]
+					continue
+				}
+				comps[i] = decode[2*i] + float64(v)*(decode[2*i+1]-decode[2*i])/maxValue
+			}
+			if table == nil {
+				img.pix[y*img.w+x] = uint8(cs.gray(comps)*255 + 0.5)
+			}
+		}
+	}
+}
+
+// sample 读取从第 bit 位开始的 bpc 位
+func sample(data []byte, bit, bpc int) int {
+	switch bpc {
+	case 8:
+		if bit/8 < len(data) {
+			return int(data[bit/8])
+		}
+		return 0
+	case 16:
+		if bit/8+1 < len(data) {
+			return int(data[bit/8])<<8 | int(data[bit/8+1])
+		}
+		return 0
+	}
+	if bit/8 >= len(data) {
+		return 0
+	}
+	return int(data[bit/8]>>(8-bpc-bit%8)) & (1<<bpc - 1)
+}
+
+// decodeJPEG 解码 JPEG 图片，YCbCr 图片直接使用亮度通道
+func decodeJPEG(img *raster, data []byte, decode []float64) error {
+	src, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	b := src.Bounds()
+	img.w, img.h = b.Dx(), b.Dy()
+	img.pix = make([]uint8, img.w*img.h)
+	invert := len(decode) >= 2 && decode[0] > decode[1]
+	switch src := src.(type) {
+	case *image.Gray:
+		for y := 0; y < img.h; y++ {
+			copy(img.pix[y*img.w:(y+1)*img.w], src.Pix[y*src.Stride:])
+		}
+	case *image.YCbCr:
+		for y := 0; y < img.h; y++ {
+			copy(img.pix[y*img.w:(y+1)*img.w], src.Y[y*src.YStride:])
+		}
+	case *image.CMYK:
+		// Adobe 生成的 CMYK JPEG 通常是反相存储的，此时 Decode 为 [1 0 ...]，按相反的方向处理
+		for y := 0; y < img.h; y++ {
+			for x := 0; x < img.w; x++ {
+				i := src.PixOffset(x+b.Min.X, y+b.Min.Y)
+				c := []float64{float64(src.Pix[i]) / 255, float64(src.Pix[i+1]) / 255, float64(src.Pix[i+2]) / 255, float64(src.Pix[i+3]) / 255}
+				if invert {
+					for j := range c {
+						c[j] = 1 - c[j]
+					}
+				}
+				img.pix[y*img.w+x] = uint8(deviceCMYK.gray(c)*255 + 0.5)
+			}
+		}
+		return nil
+	default:
+		for y := 0; y < img.h; y++ {
+			for x := 0; x < img.w; x++ {
+				img.pix[y*img.w+x] = color.GrayModel.Convert(src.At(x+b.Min.X, y+b.Min.Y)).(color.Gray).Y
+			}
+		}
+	}
+	if invert {
+		for i, v := range img.pix {
+			img.pix[i] = 255 - v
+		}
+	}
+	return nil
+}
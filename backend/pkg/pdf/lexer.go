@@ -0,0 +1,357 @@
+package pdf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// PDF 对象在 Go 中的表示：
+// 布尔为 bool，整数为 int64，实数为 float64，字符串为 string（原始字节），名称为 name，
+// 数组为 array，字典为 dict，流为 *stream，间接引用为 objref，null 为 nil
+type (
+	name    string
+	keyword string
+	array   []any
+	dict    map[name]any
+	objref  struct{ id, gen int }
+	stream  struct {
+		hdr  dict
+		data []byte // 未解码的原始数据
+	}
+)
+
+var errSyntax = errors.New("pdf: syntax error")
+
+// lexer 按 PDF 语法从字节流中读取记号和对象
+type lexer struct {
+	data []byte
+	pos  int
+}
+
+func isWhite(c byte) bool {
+	return c == 0 || c == '\t' || c == '\n' || c == '\f' || c == '\r' || c == ' '
+}
+
+func isDelim(c byte) bool {
+	return bytes.IndexByte([]byte("()<>[]{}/%"), c) >= 0
+}
+
+// skipSpace 跳过空白和注释
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		if isWhite(c) {
+			l.pos++
+			continue
+		}
+		if c == '%' {
+			for l.pos < len(l.data) && l.data[l.pos] != '\n' && l.data[l.pos] != '\r' {
+				l.pos++
+			}
+			continue
+		}
+		return
+	}
+}
+
+// token 读取下一个记号，数组和字典的括号作为 keyword 返回
+func (l *lexer) token() (any, error) {
+	l.skipSpace()
+	if l.pos >= len(l.data) {
+		return nil, io.EOF
+	}
+	c := l.data[l.pos]
+	switch {
+	case c == '/':
+		return l.name(), nil
+	case c == '(':
+		return l.literal()
+	case c == '<':
+		if l.pos+1 < len(l.data) && l.data[l.pos+1] == '<' {
+			l.pos += 2
+			return keyword("<<"), nil
+		}
+		return l.hex()
+	case c == '>':
+		if l.pos+1 < len(l.data) && l.data[l.pos+1] == '>' {
+			l.pos += 2
+			return keyword(">>"), nil
+		}
+		l.pos++
+		return nil, errSyntax
+	case c == '[' || c == ']' || c == '{' || c == '}':
+		l.pos++
+		return keyword(c), nil
+	case c == ')':
+		l.pos++
+		return nil, errSyntax
+	}
+
+	start := l.pos
+	for l.pos < len(l.data) && !isWhite(l.data[l.pos]) && !isDelim(l.data[l.pos]) {
+		l.pos++
+	}
+	word := string(l.data[start:l.pos])
+	if n, err := strconv.ParseInt(word, 10, 64); err == nil {
+		return n, nil
+	}
+	if len(word) > 0 && (word[0] == '-' || word[0] == '+' || word[0] == '.' || (word[0] >= '0' && word[0] <= '9')) {
+		if f, err := strconv.ParseFloat(word, 64); err == nil {
+			return f, nil
+		}
+		// 不规范的数字，如 --5 或 1.2.3，按 0 处理
+		return int64(0), nil
+	}
+	return keyword(word), nil
+}
+
+// name 读取名称，处理 #xx 转义
+func (l *lexer) name() name {
+	l.pos++
+	var buf []byte
+	for l.pos < len(l.data) && !isWhite(l.data[l.pos]) && !isDelim(l.data[l.pos]) {
+		c := l.data[l.pos]
+		if c == '#' && l.pos+2 < len(l.data) {
+			if v, err := strconv.ParseUint(string(l.data[l.pos+1:l.pos+3]), 16, 8); err == nil {
+				buf = append(buf, byte(v))
+				l.pos += 3
+				continue
+			}
+		}
+		buf = append(buf, c)
+		l.pos++
+	}
+	return name(buf)
+}
+
+// literal 读取括号字符串，处理转义和嵌套的括号
+func (l *lexer) literal() (string, error) {
+	l.pos++
+	var buf []byte
+	depth := 1
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return string(buf), nil
+			}
+		case '\r':
+			// 行尾统一为 \n
+			if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+				l.pos++
+			}
+			c = '\n'
+		case '\\':
+			if l.pos >= len(l.data) {
+				return "", errSyntax
+			}
+			c = l.data[l.pos]
+			l.pos++
+			switch c {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r':
+				// 反斜杠续行
+				if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+					l.pos++
+				}
+				continue
+			case '\n':
+				continue
+			default:
+				if c >= '0' && c <= '7' {
+					v := int(c - '0')
+					for i := 0; i < 2 && l.pos < len(l.data) && l.data[l.pos] >= '0' && l.data[l.pos] <= '7'; i++ {
+						v = v*8 + int(l.data[l.pos]-'0')
+						l.pos++
+					}
+					c = byte(v)
+				}
+			}
+		}
+		buf = append(buf, c)
+	}
+	return "", errSyntax
+}
+
+// hex 读取十六进制字符串，奇数个数字时最后补 0
+func (l *lexer) hex() (string, error) {
+	l.pos++
+	var buf []byte
+	var digits []byte
+	for l.pos < len(l.data) {
+		c := l.data[l.pos]
+		l.pos++
+		if c == '>' {
+			if len(digits)%2 == 1 {
+				digits = append(digits, '0')
+			}
+			for i := 0; i < len(digits); i += 2 {
+				v, _ := strconv.ParseUint(string(digits[i:i+2]), 16, 8)
+				buf = append(buf, byte(v))
+			}
+			return string(buf), nil
+		}
+		if isWhite(c) {
+			continue
+		}
+		if _, err := strconv.ParseUint(string(c), 16, 8); err != nil {
+			return "", errSyntax
+		}
+		digits = append(digits, c)
+	}
+	return "", errSyntax
+}
+
+// object 读取一个完整的对象，整数后紧跟“gen R”时读取为间接引用
+func (l *lexer) object() (any, error) {
+	tok, err := l.token()
+	if err != nil {
+		return nil, err
+	}
+	return l.objectFrom(tok)
+}
+
+func (l *lexer) objectFrom(tok any) (any, error) {
+	switch t := tok.(type) {
+	case keyword:
+		switch t {
+		case "<<":
+			d := dict{}
+			for {
+				tok, err := l.token()
+				if err != nil {
+					return nil, err
+				}
+				if tok == keyword(">>") {
+					return d, nil
+				}
+				key, ok := tok.(name)
+				if !ok {
+					// 跳过不规范的键
+					continue
+				}
+				v, err := l.object()
+				if err != nil {
+					return nil, err
+				}
+				if kw, ok := v.(keyword); ok && kw == ">>" {
+					// 缺少值的键
+					return d, nil
+				}
+				d[key] = v
+			}
+		case "[":
+			a := array{}
+			for {
+				tok, err := l.token()
+				if err != nil {
+					return nil, err
+				}
+				if tok == keyword("]") {
+					return a, nil
+				}
+				v, err := l.objectFrom(tok)
+				if err != nil {
+					return nil, err
+				}
+				a = append(a, v)
+			}
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+		return t, nil
+	case int64:
+		save := l.pos
+		gen, err := l.token()
+		if g, ok := gen.(int64); ok && err == nil {
+			if r, err := l.token(); err == nil && r == keyword("R") {
+				return objref{int(t), int(g)}, nil
+			}
+		}
+		l.pos = save
+		return t, nil
+	}
+	return tok, nil
+}
+
+// indirect 读取 pos 处的间接对象“id gen obj ... endobj”，字典后紧跟 stream 时读取为流。
+// length 用于解析流长度中的间接引用
+func (l *lexer) indirect(length func(v any) int) (objref, any, error) {
+	var ref objref
+	id, err := l.token()
+	if err != nil {
+		return ref, nil, err
+	}
+	gen, err := l.token()
+	if err != nil {
+		return ref, nil, err
+	}
+	kw, err := l.token()
+	if err != nil {
+		return ref, nil, err
+	}
+	i, ok1 := id.(int64)
+	g, ok2 := gen.(int64)
+	if !ok1 || !ok2 || kw != keyword("obj") {
+		return ref, nil, fmt.Errorf("pdf: expected object at offset %d", l.pos)
+	}
+	ref = objref{int(i), int(g)}
+
+	v, err := l.object()
+	if err != nil {
+		return ref, nil, err
+	}
+	d, ok := v.(dict)
+	if !ok {
+		return ref, v, nil
+	}
+	save := l.pos
+	if tok, err := l.token(); err != nil || tok != keyword("stream") {
+		l.pos = save
+		return ref, d, nil
+	}
+	// stream 关键字后是 CRLF 或 LF
+	if l.pos < len(l.data) && l.data[l.pos] == '\r' {
+		l.pos++
+	}
+	if l.pos < len(l.data) && l.data[l.pos] == '\n' {
+		l.pos++
+	}
+	start := l.pos
+	n := length(d[name("Length")])
+	end := start + n
+	if n < 0 || end > len(l.data) || !bytes.HasPrefix(bytes.TrimLeft(l.data[end:], "\r\n \t"), []byte("endstream")) {
+		// 长度缺失或不正确时找 endstream
+		idx := bytes.Index(l.data[start:], []byte("endstream"))
+		if idx < 0 {
+			return ref, nil, errSyntax
+		}
+		end = start + idx
+		for end > start && (l.data[end-1] == '\n' || l.data[end-1] == '\r') {
+			end--
+		}
+	}
+	l.pos = end
+	return ref, &stream{hdr: d, data: l.data[start:end]}, nil
+}
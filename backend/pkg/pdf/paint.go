@@ -0,0 +1,626 @@
+package pdf
+
+import (
+	"bytes"
+	"image"
+	"io"
+	"math"
+)
+
+// paintState 渲染用的图形状态，颜色都转换为灰度
+type paintState struct {
+	gstate
+	fillCS, strokeCS *colorSpace
+	fill, stroke     uint8
+	// fillNone、strokeNone 使用图案的颜色，图案通常是背景或渐变，不绘制
+	fillNone, strokeNone bool
+	lineWidth            float64
+	// clip 裁剪区域的覆盖率，nil 表示不裁剪
+	clip *image.Alpha
+	// render 文本渲染模式 Tr
+	render int
+}
+
+// painter 解释内容流，把路径、文字和图片画到灰度图上。着色（sh）和图案不绘制，
+// 透明度按不透明处理，扫描件和论文的页面通常不受影响
+type painter struct {
+	doc   *Document
+	img   *image.Gray
+	state paintState
+	stack []paintState
+	path  path
+	// clipRule W 或 W* 设置的裁剪，在下一个绘制路径的操作符之后生效
+	clipRule *fillRule
+	tm, tlm  matrix
+	// uncolored Type3 字形中使用了 d1，字形中设置颜色的操作符无效
+	uncolored bool
+}
+
+// paintPage 把页面画到 img 上，device 为默认用户空间到设备空间的矩阵
+func (d *Document) paintPage(p page, img *image.Gray, device matrix) error {
+	data, err := d.contents(p)
+	if err != nil {
+		return err
+	}
+	pt := &painter{
+		doc: d,
+		img: img,
+		state: paintState{
+			gstate:    gstate{ctm: device, scale: 100},
+			fillCS:    deviceGray,
+			strokeCS:  deviceGray,
+			lineWidth: 1,
+		},
+	}
+	pt.run(data, p.resources, 0)
+	return nil
+}
+
+// run 解释内容流中的操作符
+func (pt *painter) run(data []byte, resources dict, depth int) {
+	l := &lexer{data: data}
+	var operands []any
+	for {
+		pos := l.pos
+		v, err := l.object()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			if l.pos <= pos {
+				l.pos = pos + 1
+			}
+			operands = operands[:0]
+			continue
+		}
+		op, ok := v.(keyword)
+		if !ok {
+			operands = append(operands, v)
+			continue
+		}
+		if op == "BI" {
+			pt.inlineImage(l, resources)
+		} else {
+			pt.operator(string(op), operands, resources, depth)
+		}
+		operands = operands[:0]
+	}
+}
+
+func (pt *painter) operator(op string, args []any, resources dict, depth int) {
+	num := func(i int) float64 {
+		if i < len(args) {
+			return number(args[i])
+		}
+		return 0
+	}
+	s := &pt.state
+	switch op {
+	case "q":
+		pt.stack = append(pt.stack, pt.state)
+	case "Q":
+		if n := len(pt.stack); n > 0 {
+			pt.state = pt.stack[n-1]
+			pt.stack = pt.stack[:n-1]
+		}
+	case "cm":
+		if len(args) == 6 {
+			s.ctm = matrix{num(0), num(1), num(2), num(3), num(4), num(5)}.mul(s.ctm)
+		}
+	case "w":
+		s.lineWidth = num(0)
+
+	// 路径
+	case "m":
+		pt.path.moveTo(s.ctm.apply(num(0), num(1)))
+	case "l":
+		pt.path.lineTo(s.ctm.apply(num(0), num(1)))
+	case "c":
+		pt.path.curveTo(s.ctm.apply(num(0), num(1)), s.ctm.apply(num(2), num(3)), s.ctm.apply(num(4), num(5)))
+	case "v":
+		if cur, ok := pt.path.current(); ok {
+			pt.path.curveTo(cur, s.ctm.apply(num(0), num(1)), s.ctm.apply(num(2), num(3)))
+		}
+	case "y":
+		end := s.ctm.apply(num(2), num(3))
+		pt.path.curveTo(s.ctm.apply(num(0), num(1)), end, end)
+	case "h":
+		pt.path.close()
+	case "re":
+		x, y, w, h := num(0), num(1), num(2), num(3)
+		pt.path.moveTo(s.ctm.apply(x, y))
+		pt.path.lineTo(s.ctm.apply(x+w, y))
+		pt.path.lineTo(s.ctm.apply(x+w, y+h))
+		pt.path.lineTo(s.ctm.apply(x, y+h))
+		pt.path.close()
+	case "f", "F":
+		pt.paintPath(true, false, nonZero)
+	case "f*":
+		pt.paintPath(true, false, evenOdd)
+	case "S":
+		pt.paintPath(false, true, nonZero)
+	case "s":
+		pt.path.close()
+		pt.paintPath(false, true, nonZero)
+	case "B":
+		pt.paintPath(true, true, nonZero)
+	case "B*":
+		pt.paintPath(true, true, evenOdd)
+	case "b":
+		pt.path.close()
+		pt.paintPath(true, true, nonZero)
+	case "b*":
+		pt.path.close()
+		pt.paintPath(true, true, evenOdd)
+	case "n":
+		pt.paintPath(false, false, nonZero)
+	case "W":
+		rule := nonZero
+		pt.clipRule = &rule
+	case "W*":
+		rule := evenOdd
+		pt.clipRule = &rule
+
+	// 颜色
+	case "g":
+		pt.setColor(true, deviceGray, args)
+	case "G":
+		pt.setColor(false, deviceGray, args)
+	case "rg":
+		pt.setColor(true, deviceRGB, args)
+	case "RG":
+		pt.setColor(false, deviceRGB, args)
+	case "k":
+		pt.setColor(true, deviceCMYK, args)
+	case "K":
+		pt.setColor(false, deviceCMYK, args)
+	case "cs", "CS":
+		if len(args) == 1 {
+			cs := pt.doc.colorSpace(args[0], resources)
+			// 切换颜色空间后颜色为初始值：黑色，Indexed 为第 0 个颜色
+			initial := make([]any, cs.n)
+			for i := range initial {
+				initial[i] = int64(0)
+			}
+			if cs.kind == "DeviceCMYK" {
+				initial[3] = int64(1)
+			}
+			if cs.kind == "Separation" || cs.kind == "DeviceN" {
+				for i := range initial {
+					initial[i] = int64(1)
+				}
+			}
+			pt.setColor(op == "cs", cs, initial)
+		}
+	case "sc", "scn":
+		pt.setColor(true, s.fillCS, args)
+	case "SC", "SCN":
+		pt.setColor(false, s.strokeCS, args)
+
+	// 文本
+	case "BT":
+		pt.tm, pt.tlm = identity, identity
+	case "Tf":
+		if len(args) == 2 {
+			fonts, _ := pt.doc.resolve(resources[name("Font")]).(dict)
+			if n, ok := args[0].(name); ok {
+				s.font = pt.doc.font(fonts[n])
+			}
+			s.size = num(1)
+		}
+	case "Td":
+		pt.moveLine(num(0), num(1))
+	case "TD":
+		s.leading = -num(1)
+		pt.moveLine(num(0), num(1))
+	case "Tm":
+		if len(args) == 6 {
+			pt.tm = matrix{num(0), num(1), num(2), num(3), num(4), num(5)}
+			pt.tlm = pt.tm
+		}
+	case "T*":
+		pt.moveLine(0, -s.leading)
+	case "TL":
+		s.leading = num(0)
+	case "Tc":
+		s.charSpace = num(0)
+	case "Tw":
+		s.wordSpace = num(0)
+	case "Tz":
+		s.scale = num(0)
+	case "Ts":
+		s.rise = num(0)
+	case "Tr":
+		s.render = int(num(0))
+	case "Tj":
+		if len(args) == 1 {
+			pt.show(args[0], resources, depth)
+		}
+	case "'":
+		pt.moveLine(0, -s.leading)
+		if len(args) == 1 {
+			pt.show(args[0], resources, depth)
+		}
+	case "\"":
+		if len(args) == 3 {
+			s.wordSpace, s.charSpace = num(0), num(1)
+			pt.moveLine(0, -s.leading)
+			pt.show(args[2], resources, depth)
+		}
+	case "TJ":
+		if len(args) == 1 {
+			items, _ := args[0].(array)
+			for _, item := range items {
+				if _, ok := item.(string); ok {
+					pt.show(item, resources, depth)
+					continue
+				}
+				tx := -number(item) / 1000 * s.size * s.scale / 100
+				pt.tm = translate(tx, 0).mul(pt.tm)
+			}
+		}
+	case "d1":
+		pt.uncolored = true
+
+	case "Do":
+		if len(args) == 1 && depth < maxFormDepth {
+			pt.xobject(args[0], resources, depth)
+		}
+	}
+}
+
+// setColor 设置填充或描边颜色，最后一个操作数是名称时表示图案
+func (pt *painter) setColor(fill bool, cs *colorSpace, args []any) {
+	if pt.uncolored {
+		return
+	}
+	pattern := cs.kind == "Pattern"
+	comps := make([]float64, 0, len(args))
+	for _, a := range args {
+		switch v := a.(type) {
+		case int64, float64:
+			comps = append(comps, number(v))
+		case name:
+			pattern = true
+		}
+	}
+	value := uint8(math.Round(cs.gray(comps) * 255))
+	s := &pt.state
+	if fill {
+		s.fillCS, s.fill, s.fillNone = cs, value, pattern
+	} else {
+		s.strokeCS, s.stroke, s.strokeNone = cs, value, pattern
+	}
+}
+
+// paintPath 填充或描边当前路径，然后应用等待中的裁剪并清空路径
+func (pt *painter) paintPath(fill, stroke bool, rule fillRule) {
+	s := &pt.state
+	bounds := pt.img.Rect
+	if fill && !s.fillNone && !pt.path.empty() {
+		composite(pt.img, rasterize(&pt.path, rule, bounds), s.clip, s.fill)
+	}
+	if stroke && !s.strokeNone && len(pt.path.subpaths) > 0 {
+		// 线宽按变换矩阵的平均缩放比例换算到设备空间
+		width := s.lineWidth * math.Sqrt(math.Abs(s.ctm[0]*s.ctm[3]-s.ctm[1]*s.ctm[2]))
+		composite(pt.img, rasterize(strokePath(&pt.path, width), nonZero, bounds), s.clip, s.stroke)
+	}
+	if pt.clipRule != nil {
+		clip := rasterize(&pt.path, *pt.clipRule, bounds)
+		if clip == nil {
+			clip = image.NewAlpha(image.Rectangle{})
+		}
+		s.clip = intersectClip(s.clip, clip)
+		pt.clipRule = nil
+	}
+	pt.path = path{}
+}
+
+func (pt *painter) moveLine(tx, ty float64) {
+	pt.tlm = translate(tx, ty).mul(pt.tlm)
+	pt.tm = pt.tlm
+}
+
+// show 绘制字符串中的字形并移动文本矩阵。使用内嵌字体的轮廓，没有内嵌字体时不绘制
+func (pt *painter) show(arg any, resources dict, depth int) {
+	str, ok := arg.(string)
+	s := &pt.state
+	if !ok || s.font == nil {
+		return
+	}
+	f := s.font
+	type3 := pt.doc.resolve(f.dict[name("Subtype")]) == name("Type3")
+	var src glyphSource
+	if !type3 {
+		src = pt.doc.outlines(f)
+	}
+	// 渲染模式 3 和 7 不可见，4 到 6 的裁剪效果忽略
+	visible := s.render != 3 && s.render != 7
+	fill := s.render%4 == 0 || s.render%4 == 2
+	stroke := s.render%4 == 1 || s.render%4 == 2
+
+	var glyphs path
+	for _, c := range f.codes(str) {
+		trm := matrix{s.size * s.scale / 100, 0, 0, s.size, 0, s.rise}.mul(pt.tm).mul(s.ctm)
+		switch {
+		case !visible:
+		case type3:
+			pt.type3Glyph(f, c, trm, resources, depth)
+		case src != nil:
+			if o, ok := pt.doc.glyph(f, src, c); ok {
+				addOutline(&glyphs, o, trm)
+			}
+		}
+		tx := f.width(c)/1000*s.size + s.charSpace
+		if c.bytes == 1 && c.value == ' ' {
+			tx += s.wordSpace
+		}
+		pt.tm = translate(tx*s.scale/100, 0).mul(pt.tm)
+	}
+	if glyphs.empty() {
+		return
+	}
+	bounds := pt.img.Rect
+	if fill && !s.fillNone {
+		composite(pt.img, rasterize(&glyphs, nonZero, bounds), s.clip, s.fill)
+	}
+	if stroke && !s.strokeNone {
+		width := s.lineWidth * math.Sqrt(math.Abs(s.ctm[0]*s.ctm[3]-s.ctm[1]*s.ctm[2]))
+		composite(pt.img, rasterize(strokePath(&glyphs, width), nonZero, bounds), s.clip, s.stroke)
+	}
+}
+
+// glyph 返回字形轮廓，按字体缓存
+func (d *Document) glyph(f *font, src glyphSource, c code) (outline, bool) {
+	if o, ok := f.glyphs[c]; ok {
+		return o, o != nil
+	}
+	o, ok := src.outline(f, c)
+	if f.glyphs == nil {
+		f.glyphs = map[code]outline{}
+	}
+	if !ok {
+		o = nil
+	}
+	f.glyphs[c] = o
+	return o, ok
+}
+
+// addOutline 把轮廓按 m 变换后加入路径
+func addOutline(p *path, o outline, m matrix) {
+	for _, seg := range o {
+		switch seg.op {
+		case 'M':
+			p.moveTo(m.apply(seg.pts[0].x, seg.pts[0].y))
+		case 'L':
+			p.lineTo(m.apply(seg.pts[0].x, seg.pts[0].y))
+		case 'Q':
+			// 二次曲线转换为三次曲线
+			cur, _ := p.current()
+			c := m.apply(seg.pts[0].x, seg.pts[0].y)
+			end := m.apply(seg.pts[1].x, seg.pts[1].y)
+			p.curveTo(
+				point{cur.x + 2*(c.x-cur.x)/3, cur.y + 2*(c.y-cur.y)/3},
+				point{end.x + 2*(c.x-end.x)/3, end.y + 2*(c.y-end.y)/3},
+				end,
+			)
+		case 'C':
+			p.curveTo(m.apply(seg.pts[0].x, seg.pts[0].y), m.apply(seg.pts[1].x, seg.pts[1].y), m.apply(seg.pts[2].x, seg.pts[2].y))
+		case 'Z':
+			p.close()
+		}
+	}
+}
+
+// type3Glyph 执行 Type3 字体中字形的内容流，字形空间按 FontMatrix 换算到文本空间
+func (pt *painter) type3Glyph(f *font, c code, trm matrix, resources dict, depth int) {
+	if depth >= maxFormDepth {
+		return
+	}
+	glyphName := f.differences[c.value]
+	procs, _ := pt.doc.resolve(f.dict[name("CharProcs")]).(dict)
+	proc, ok := pt.doc.resolve(procs[name(glyphName)]).(*stream)
+	if !ok {
+		return
+	}
+	data, err := pt.doc.decode(proc)
+	if err != nil {
+		return
+	}
+	fm := matrix{0.001, 0, 0, 0.001, 0, 0}
+	if m, ok := pt.doc.resolve(f.dict[name("FontMatrix")]).(array); ok && len(m) == 6 {
+		for i := range fm {
+			fm[i] = number(pt.doc.resolve(m[i]))
+		}
+	}
+	if r, ok := pt.doc.resolve(f.dict[name("Resources")]).(dict); ok {
+		resources = r
+	}
+
+	saved, stack, tm, tlm, uncolored, p := pt.state, len(pt.stack), pt.tm, pt.tlm, pt.uncolored, pt.path
+	pt.state.ctm = fm.mul(trm)
+	pt.path = path{}
+	pt.run(data, resources, depth+1)
+	pt.stack = pt.stack[:min(stack, len(pt.stack))]
+	pt.state, pt.tm, pt.tlm, pt.uncolored, pt.path = saved, tm, tlm, uncolored, p
+}
+
+// xobject 绘制表单或图片 XObject
+func (pt *painter) xobject(arg any, resources dict, depth int) {
+	n, ok := arg.(name)
+	if !ok {
+		return
+	}
+	xobjects, _ := pt.doc.resolve(resources[name("XObject")]).(dict)
+	s, ok := pt.doc.resolve(xobjects[n]).(*stream)
+	if !ok {
+		return
+	}
+	switch pt.doc.resolve(s.hdr[name("Subtype")]) {
+	case name("Image"):
+		pt.image(s, resources)
+	case name("Form"):
+		pt.form(s, resources, depth)
+	}
+}
+
+func (pt *painter) form(s *stream, resources dict, depth int) {
+	data, err := pt.doc.decode(s)
+	if err != nil {
+		return
+	}
+	if r, ok := pt.doc.resolve(s.hdr[name("Resources")]).(dict); ok {
+		resources = r
+	}
+	saved, stack, tm, tlm, p := pt.state, len(pt.stack), pt.tm, pt.tlm, pt.path
+	if m, ok := pt.doc.resolve(s.hdr[name("Matrix")]).(array); ok && len(m) == 6 {
+		var fm matrix
+		for i := range fm {
+			fm[i] = number(pt.doc.resolve(m[i]))
+		}
+		pt.state.ctm = fm.mul(pt.state.ctm)
+	}
+	// 表单按 BBox 裁剪
+	if box, ok := pt.doc.resolve(s.hdr[name("BBox")]).(array); ok && len(box) == 4 {
+		x0, y0 := number(pt.doc.resolve(box[0])), number(pt.doc.resolve(box[1]))
+		x1, y1 := number(pt.doc.resolve(box[2])), number(pt.doc.resolve(box[3]))
+		pt.path = path{}
+		pt.operator("re", []any{x0, y0, x1 - x0, y1 - y0}, resources, depth)
+		pt.operator("W", nil, resources, depth)
+		pt.operator("n", nil, resources, depth)
+	}
+	pt.path = path{}
+	pt.run(data, resources, depth+1)
+	pt.stack = pt.stack[:min(stack, len(pt.stack))]
+	pt.state, pt.tm, pt.tlm, pt.path = saved, tm, tlm, p
+}
+
+// inlineImage 读取 BI 和 ID 之间的字典和 ID 之后的数据并绘制
+func (pt *painter) inlineImage(l *lexer, resources dict) {
+	hdr := dict{}
+	for {
+		v, err := l.object()
+		if err != nil {
+			return
+		}
+		if v == keyword("ID") {
+			break
+		}
+		k, ok := v.(name)
+		if !ok {
+			continue
+		}
+		if hdr[k], err = l.object(); err != nil {
+			return
+		}
+	}
+	// ID 之后有一个空白字符
+	start := l.pos + 1
+	end := -1
+	for pos := start; pos+2 <= len(l.data); pos++ {
+		if l.data[pos] == 'E' && l.data[pos+1] == 'I' && isWhite(l.data[pos-1]) &&
+			(pos+2 == len(l.data) || isWhite(l.data[pos+2]) || isDelim(l.data[pos+2])) {
+			end = pos
+			break
+		}
+	}
+	if end < 0 || start > end {
+		l.pos = len(l.data)
+		return
+	}
+	l.pos = end + 2
+	pt.image(&stream{hdr: hdr, data: bytes.TrimRight(l.data[start:end], "\r\n")}, resources)
+}
+
+// image 把图片画到单位正方形经 CTM 变换后的区域。每个设备像素反向映射到图片上取样，
+// 图片分辨率高于设备时每个像素取多个样本平均
+func (pt *painter) image(s *stream, resources dict) {
+	st := &pt.state
+	if st.clip != nil && st.clip.Rect.Empty() {
+		return
+	}
+	img, err := pt.doc.loadImage(s, resources)
+	if err != nil {
+		return
+	}
+	if img.mask && st.fillNone {
+		return
+	}
+	var smask *raster
+	if !img.mask {
+		if m, ok := pt.doc.resolve(s.hdr[name("SMask")]).(*stream); ok {
+			if r, err := pt.doc.loadImage(m, resources); err == nil {
+				smask = r
+			}
+		}
+	}
+	inv, ok := st.ctm.invert()
+	if !ok {
+		return
+	}
+
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, c := range []point{st.ctm.apply(0, 0), st.ctm.apply(1, 0), st.ctm.apply(0, 1), st.ctm.apply(1, 1)} {
+		minX, maxX = math.Min(minX, c.x), math.Max(maxX, c.x)
+		minY, maxY = math.Min(minY, c.y), math.Max(maxY, c.y)
+	}
+	r := image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX)), int(math.Ceil(maxY))).Intersect(pt.img.Rect)
+	if st.clip != nil {
+		r = r.Intersect(st.clip.Rect)
+	}
+	if r.Empty() {
+		return
+	}
+	// 每个设备像素在图片上覆盖的采样数决定超采样的倍数
+	area := math.Abs(st.ctm[0]*st.ctm[3] - st.ctm[1]*st.ctm[2])
+	k := 1
+	if area > 0 {
+		k = int(math.Ceil(math.Sqrt(float64(img.w*img.h) / area)))
+		k = max(1, min(k, 4))
+	}
+
+	sampleAt := func(src *raster, u, v float64) uint8 {
+		x := min(int(u*float64(src.w)), src.w-1)
+		y := min(int((1-v)*float64(src.h)), src.h-1)
+		return src.pix[y*src.w+x]
+	}
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			var sum, alpha, count int
+			for sy := 0; sy < k; sy++ {
+				for sx := 0; sx < k; sx++ {
+					count++
+					p := inv.apply(float64(x)+(float64(sx)+0.5)/float64(k), float64(y)+(float64(sy)+0.5)/float64(k))
+					if p.x < 0 || p.x >= 1 || p.y <= 0 || p.y > 1 {
+						continue
+					}
+					a := 255
+					if smask != nil {
+						a = int(sampleAt(smask, p.x, p.y))
+					}
+					if img.mask {
+						a = a * int(sampleAt(img, p.x, p.y)) / 255
+						sum += a * int(st.fill)
+					} else {
+						sum += a * int(sampleAt(img, p.x, p.y))
+					}
+					alpha += a
+				}
+			}
+			if alpha == 0 {
+				continue
+			}
+			// sum/alpha 为样本的平均灰度，alpha/count 为覆盖率
+			value := uint32((sum + alpha/2) / alpha)
+			a := uint32(alpha / count)
+			if img.mask {
+				value = uint32(st.fill)
+			}
+			if st.clip != nil {
+				a = a * uint32(st.clip.Pix[st.clip.PixOffset(x, y)]) / 255
+			}
+			i := pt.img.PixOffset(x, y)
+			pt.img.Pix[i] = uint8((uint32(pt.img.Pix[i])*(255-a) + value*a + 127) / 255)
+		}
+	}
+}
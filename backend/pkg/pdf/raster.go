@@ -0,0 +1,314 @@
+package pdf
+
+import (
+	"image"
+	"math"
+	"sort"
+)
+
+// point 设备空间中的点，单位为像素
+type point struct{ x, y float64 }
+
+// apply 用矩阵变换点
+func (m matrix) apply(x, y float64) point {
+	return point{m[0]*x + m[2]*y + m[4], m[1]*x + m[3]*y + m[5]}
+}
+
+// invert 返回逆矩阵，不可逆时返回 false
+func (m matrix) invert() (matrix, bool) {
+	det := m[0]*m[3] - m[1]*m[2]
+	if det == 0 || math.IsNaN(det) || math.IsInf(det, 0) {
+		return matrix{}, false
+	}
+	return matrix{
+		m[3] / det, -m[1] / det,
+		-m[2] / det, m[0] / det,
+		(m[2]*m[5] - m[3]*m[4]) / det, (m[1]*m[4] - m[0]*m[5]) / det,
+	}, true
+}
+
+// path 设备空间中的路径，曲线在构建时展开为折线
+type path struct {
+	subpaths [][]point
+	closed   []bool
+}
+
+func (p *path) moveTo(pt point) {
+	p.subpaths = append(p.subpaths, []point{pt})
+	p.closed = append(p.closed, false)
+}
+
+func (p *path) lineTo(pt point) {
+	if len(p.subpaths) == 0 {
+		p.moveTo(pt)
+		return
+	}
+	last := &p.subpaths[len(p.subpaths)-1]
+	*last = append(*last, pt)
+}
+
+// curveTo 把三次贝塞尔曲线展开为折线，段数按控制多边形的长度估计，误差约为四分之一像素
+func (p *path) curveTo(c1, c2, end point) {
+	start, ok := p.current()
+	if !ok {
+		p.moveTo(start)
+	}
+	length := dist(start, c1) + dist(c1, c2) + dist(c2, end)
+	n := int(math.Ceil(math.Sqrt(length) * 1.5))
+	n = max(1, min(n, 256))
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		u := 1 - t
+		a, b, c, d := u*u*u, 3*u*u*t, 3*u*t*t, t*t*t
+		p.lineTo(point{
+			a*start.x + b*c1.x + c*c2.x + d*end.x,
+			a*start.y + b*c1.y + c*c2.y + d*end.y,
+		})
+	}
+}
+
+func (p *path) current() (point, bool) {
+	if len(p.subpaths) == 0 {
+		return point{}, false
+	}
+	last := p.subpaths[len(p.subpaths)-1]
+	return last[len(last)-1], true
+}
+
+// close 闭合当前子路径，之后的线段从子路径的起点开始
+func (p *path) close() {
+	if len(p.subpaths) == 0 {
+		return
+	}
+	p.closed[len(p.closed)-1] = true
+	start := p.subpaths[len(p.subpaths)-1][0]
+	p.moveTo(start)
+}
+
+func (p *path) empty() bool {
+	for _, sub := range p.subpaths {
+		if len(sub) > 1 {
+			return false
+		}
+	}
+	return true
+}
+
+func dist(a, b point) float64 {
+	return math.Hypot(a.x-b.x, a.y-b.y)
+}
+
+// fillRule 填充规则
+type fillRule int
+
+const (
+	nonZero fillRule = iota
+	evenOdd
+)
+
+// subsamples 每个像素在垂直方向上的采样线数
+const subsamples = 4
+
+// crossing 采样线与边的交点
+type crossing struct {
+	x   float64
+	dir int
+}
+
+// rasterize 计算路径在 bounds 内每个像素上的覆盖率，填充时所有子路径都视为闭合。
+// 水平方向按精确的区间长度累计，垂直方向每个像素取多条采样线，可以正确处理两种填充规则
+func rasterize(p *path, rule fillRule, bounds image.Rectangle) *image.Alpha {
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, sub := range p.subpaths {
+		for _, pt := range sub {
+			minX, maxX = math.Min(minX, pt.x), math.Max(maxX, pt.x)
+			minY, maxY = math.Min(minY, pt.y), math.Max(maxY, pt.y)
+		}
+	}
+	if math.IsInf(minX, 0) || math.IsNaN(minX+minY+maxX+maxY) {
+		return nil
+	}
+	r := image.Rect(int(math.Floor(minX)), int(math.Floor(minY)), int(math.Ceil(maxX))+1, int(math.Ceil(maxY))+1).Intersect(bounds)
+	if r.Empty() {
+		return nil
+	}
+
+	lines := make([][]crossing, r.Dy()*subsamples)
+	addEdge := func(a, b point) {
+		if a.y == b.y {
+			return
+		}
+		dir := 1
+		if a.y > b.y {
+			a, b, dir = b, a, -1
+		}
+		// 采样线 y = r.Min.Y + (i+0.5)/subsamples
+		first := int(math.Ceil((a.y-float64(r.Min.Y))*subsamples - 0.5))
+		last := int(math.Ceil((b.y-float64(r.Min.Y))*subsamples-0.5)) - 1
+		first, last = max(first, 0), min(last, len(lines)-1)
+		slope := (b.x - a.x) / (b.y - a.y)
+		for i := first; i <= last; i++ {
+			y := float64(r.Min.Y) + (float64(i)+0.5)/subsamples
+			lines[i] = append(lines[i], crossing{a.x + (y-a.y)*slope, dir})
+		}
+	}
+	for _, sub := range p.subpaths {
+		if len(sub) < 2 {
+			continue
+		}
+		for i := 1; i < len(sub); i++ {
+			addEdge(sub[i-1], sub[i])
+		}
+		addEdge(sub[len(sub)-1], sub[0])
+	}
+
+	mask := image.NewAlpha(r)
+	acc := make([]float64, r.Dx()+1)
+	x0, x1 := float64(r.Min.X), float64(r.Max.X)
+	for row := 0; row < r.Dy(); row++ {
+		for i := range acc {
+			acc[i] = 0
+		}
+		filled := false
+		for k := 0; k < subsamples; k++ {
+			xs := lines[row*subsamples+k]
+			if len(xs) < 2 {
+				continue
+			}
+			sort.Slice(xs, func(i, j int) bool { return xs[i].x < xs[j].x })
+			winding := 0
+			for i := 0; i+1 < len(xs); i++ {
+				winding += xs[i].dir
+				inside := winding != 0
+				if rule == evenOdd {
+					inside = (i+1)%2 == 1
+				}
+				if !inside {
+					continue
+				}
+				a, b := math.Max(xs[i].x, x0), math.Min(xs[i+1].x, x1)
+				if a >= b {
+					continue
+				}
+				filled = true
+				a, b = a-x0, b-x0
+				ia, ib := int(a), int(b)
+				if ia == ib {
+					acc[ia] += b - a
+					continue
+				}
+				acc[ia] += float64(ia+1) - a
+				for j := ia + 1; j < ib; j++ {
+					acc[j]++
+				}
+				acc[ib] += b - float64(ib)
+			}
+		}
+		if !filled {
+			continue
+		}
+		offset := row * mask.Stride
+		for i := 0; i < r.Dx(); i++ {
+			mask.Pix[offset+i] = uint8(math.Min(acc[i]/subsamples, 1)*255 + 0.5)
+		}
+	}
+	return mask
+}
+
+// strokePath 把路径的每条线段扩展为宽度为 width 的四边形，在连接处加上八边形近似圆角，
+// 所有多边形方向一致，用非零规则填充即可得到它们的并集。虚线按实线处理
+func strokePath(p *path, width float64) *path {
+	half := math.Max(width, 1) / 2
+	out := &path{}
+	polygon := func(pts ...point) {
+		// 统一为顺时针方向
+		area := 0.0
+		for i := range pts {
+			j := (i + 1) % len(pts)
+			area += pts[i].x*pts[j].y - pts[j].x*pts[i].y
+		}
+		if area < 0 {
+			for i, j := 0, len(pts)-1; i < j; i, j = i+1, j-1 {
+				pts[i], pts[j] = pts[j], pts[i]
+			}
+		}
+		out.subpaths = append(out.subpaths, pts)
+		out.closed = append(out.closed, true)
+	}
+	joint := func(c point) {
+		if half < 1 {
+			// 细线的连接处不需要圆角
+			return
+		}
+		pts := make([]point, 8)
+		for i := range pts {
+			a := float64(i) * math.Pi / 4
+			pts[i] = point{c.x + half*math.Cos(a), c.y + half*math.Sin(a)}
+		}
+		polygon(pts...)
+	}
+	for i, sub := range p.subpaths {
+		pts := sub
+		if p.closed[i] && len(sub) > 1 {
+			pts = append(append([]point(nil), sub...), sub[0])
+		}
+		if len(pts) == 1 {
+			continue
+		}
+		for j := 1; j < len(pts); j++ {
+			a, b := pts[j-1], pts[j]
+			l := dist(a, b)
+			if l == 0 {
+				continue
+			}
+			nx, ny := -(b.y-a.y)/l*half, (b.x-a.x)/l*half
+			polygon(point{a.x + nx, a.y + ny}, point{b.x + nx, b.y + ny}, point{b.x - nx, b.y - ny}, point{a.x - nx, a.y - ny})
+			if j > 1 || p.closed[i] {
+				joint(a)
+			}
+		}
+	}
+	return out
+}
+
+// composite 用覆盖率把灰度 value 画到 dst 上，clip 不为空时再乘以裁剪区域的覆盖率
+func composite(dst *image.Gray, mask, clip *image.Alpha, value uint8) {
+	if mask == nil {
+		return
+	}
+	r := mask.Rect.Intersect(dst.Rect)
+	if clip != nil {
+		r = r.Intersect(clip.Rect)
+	}
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			a := uint32(mask.Pix[mask.PixOffset(x, y)])
+			if clip != nil {
+				a = a * uint32(clip.Pix[clip.PixOffset(x, y)]) / 255
+			}
+			if a == 0 {
+				continue
+			}
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8((uint32(dst.Pix[i])*(255-a) + uint32(value)*a + 127) / 255)
+		}
+	}
+}
+
+// intersectClip 求两个裁剪区域的交集，nil 表示不裁剪
+func intersectClip(a, b *image.Alpha) *image.Alpha {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	r := a.Rect.Intersect(b.Rect)
+	out := image.NewAlpha(r)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			out.Pix[out.PixOffset(x, y)] = uint8(uint32(a.Pix[a.PixOffset(x, y)]) * uint32(b.Pix[b.PixOffset(x, y)]) / 255)
+		}
+	}
+	return out
+}
@@ -0,0 +1,239 @@
+package pdf
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"math"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// DefaultDPI 默认的渲染分辨率
+const DefaultDPI = 150
+
+// maxPageSize 渲染结果每条边的最大像素数，超大的页面按比例降低分辨率
+const maxPageSize = 8000
+
+// RenderOptions 渲染参数
+type RenderOptions struct {
+	DPI   int   // 分辨率，为 0 时使用渲染器的默认值
+	Pages []int // 需要渲染的页码，从 1 开始，为空时渲染所有页面
+}
+
+// RenderedPage 渲染完成的一页
+type RenderedPage struct {
+	Page  int    // 页码，从 1 开始
+	Image []byte // JPEG 格式的灰度图
+	Err   error  // 这一页渲染失败的原因，其他页面不受影响
+}
+
+// Renderer PDF 渲染器接口，把页面转换为图片交给 OCR
+type Renderer interface {
+
+	// Render 渲染 PDF 文件中的页面，每渲染完一页就按页码顺序发送到返回的通道，全部发送后关闭通道
+	// @param ctx - 取消后停止渲染并关闭通道
+	// @param inputFile - PDF 文件路径
+	// @param options - 分辨率和页码
+	// @return 渲染结果的通道, error 文件无法解析或页码超出范围
+	Render(ctx context.Context, inputFile string, options RenderOptions) (<-chan RenderedPage, error)
+}
+
+// NativeRenderer 纯 Go 实现的渲染器，不依赖 ImageMagick 和 Ghostscript。
+// 多个页面并行渲染，每个协程使用自己的 Document
+type NativeRenderer struct {
+	dpi     int
+	workers int
+}
+
+// NewNativeRenderer 创建渲染器，dpi 为默认分辨率
+func NewNativeRenderer(dpi int) *NativeRenderer {
+	if dpi <= 0 {
+		dpi = DefaultDPI
+	}
+	return &NativeRenderer{dpi: dpi, workers: runtime.NumCPU()}
+}
+
+func (r *NativeRenderer) Render(ctx context.Context, inputFile string, options RenderOptions) (<-chan RenderedPage, error) {
+	data, err := os.ReadFile(inputFile)
+	if err != nil {
+		return nil, err
+	}
+	d, err := Open(data)
+	if err != nil {
+		return nil, err
+	}
+	pages, err := pageList(options.Pages, d.NumPages())
+	if err != nil {
+		return nil, err
+	}
+	dpi := options.DPI
+	if dpi <= 0 {
+		dpi = r.dpi
+	}
+
+	out := make(chan RenderedPage)
+	go r.render(ctx, data, pages, dpi, out)
+	return out, nil
+}
+
+// render 把页面分给多个协程渲染，按页码顺序发送结果。
+// 已渲染但还没有发送的页面最多为协程数的两倍，避免消费方较慢时占用过多内存
+func (r *NativeRenderer) render(ctx context.Context, data []byte, pages []int, dpi int, out chan<- RenderedPage) {
+	workers := max(1, min(r.workers, len(pages)))
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer close(out)
+	defer wg.Wait()
+	defer cancel()
+
+	results := make([]chan RenderedPage, len(pages))
+	for i := range results {
+		results[i] = make(chan RenderedPage, 1)
+	}
+	jobs := make(chan int)
+	window := make(chan struct{}, 2*workers)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d, err := Open(data)
+			for i := range jobs {
+				if err != nil {
+					results[i] <- RenderedPage{Page: pages[i], Err: err}
+					continue
+				}
+				results[i] <- d.renderJPEG(pages[i], dpi)
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for i := range pages {
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for i := range pages {
+		var page RenderedPage
+		select {
+		case page = <-results[i]:
+		case <-ctx.Done():
+			return
+		}
+		select {
+		case out <- page:
+		case <-ctx.Done():
+			return
+		}
+		<-window
+	}
+}
+
+// pageList 检查页码并去重排序，为空时返回所有页码
+func pageList(pages []int, total int) ([]int, error) {
+	if len(pages) == 0 {
+		all := make([]int, total)
+		for i := range all {
+			all[i] = i + 1
+		}
+		return all, nil
+	}
+	seen := make(map[int]bool, len(pages))
+	list := make([]int, 0, len(pages))
+	for _, p := range pages {
+		if p < 1 || p > total {
+			return nil, fmt.Errorf("pdf: page %d out of range 1-%d", p, total)
+		}
+		if !seen[p] {
+			seen[p] = true
+			list = append(list, p)
+		}
+	}
+	sort.Ints(list)
+	return list, nil
+}
+
+func (d *Document) renderJPEG(n, dpi int) RenderedPage {
+	img, err := d.RenderPage(n, dpi)
+	if err != nil {
+		return RenderedPage{Page: n, Err: err}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90}); err != nil {
+		return RenderedPage{Page: n, Err: err}
+	}
+	return RenderedPage{Page: n, Image: buf.Bytes()}
+}
+
+// RenderPage 把第 n 页（从 1 开始）渲染为灰度图，页面大小取 CropBox，并按 Rotate 旋转
+func (d *Document) RenderPage(n, dpi int) (img *image.Gray, err error) {
+	if n < 1 || n > len(d.pages) {
+		return nil, fmt.Errorf("pdf: page %d out of range", n)
+	}
+	if dpi <= 0 {
+		dpi = DefaultDPI
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("pdf: render page %d: %v", n, r)
+		}
+	}()
+	p := d.pages[n-1]
+	box := p.cropBox
+	if len(box) != 4 {
+		box = p.mediaBox
+	}
+	x0, y0, x1, y1 := 0.0, 0.0, 612.0, 792.0
+	if len(box) == 4 {
+		x0, y0 = number(d.resolve(box[0])), number(d.resolve(box[1]))
+		x1, y1 = number(d.resolve(box[2])), number(d.resolve(box[3]))
+		x0, x1 = math.Min(x0, x1), math.Max(x0, x1)
+		y0, y1 = math.Min(y0, y1), math.Max(y0, y1)
+	}
+	w, h := x1-x0, y1-y0
+	if w <= 0 || h <= 0 {
+		return nil, fmt.Errorf("pdf: page %d has an empty page box", n)
+	}
+
+	// 先把裁剪框左下角移到原点，再顺时针旋转，最后缩放并翻转 y 轴
+	device := translate(-x0, -y0)
+	switch (p.rotate%360 + 360) % 360 {
+	case 90:
+		device = device.mul(matrix{0, -1, 1, 0, 0, w})
+		w, h = h, w
+	case 180:
+		device = device.mul(matrix{-1, 0, 0, -1, w, h})
+	case 270:
+		device = device.mul(matrix{0, 1, -1, 0, h, 0})
+		w, h = h, w
+	}
+	scale := float64(dpi) / 72
+	if math.Max(w, h)*scale > maxPageSize {
+		scale = maxPageSize / math.Max(w, h)
+	}
+	device = device.mul(matrix{scale, 0, 0, -scale, 0, h * scale})
+
+	img = image.NewGray(image.Rect(0, 0, max(1, int(math.Ceil(w*scale))), max(1, int(math.Ceil(h*scale)))))
+	for i := range img.Pix {
+		img.Pix[i] = 255
+	}
+	if err := d.paintPage(p, img, device); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
@@ -0,0 +1,134 @@
+package pdf_test
+
+import (
+	"bytes"
+	"context"
+	"image/jpeg"
+	"os"
+	"paper-translation/pkg/pdf"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pagePDF 单页文档，extra 为页面字典中额外的条目
+func pagePDF(box, extra, content string, objects ...string) []byte {
+	return buildPDF(append([]string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox " + box + " /Contents 4 0 R " + extra + " >>",
+		streamObject("", []byte(content)),
+	}, objects...)...)
+}
+
+/**
+ * TestRenderPage 测试页面大小、旋转和路径填充。
+ */
+func TestRenderPage(t *testing.T) {
+	d, err := pdf.Open(pagePDF("[0 0 100 50]", "", "0 g 10 10 30 20 re f 0.5 g 60 10 20 20 re f"))
+	assert.Nil(t, err)
+	img, err := d.RenderPage(1, 72)
+	assert.Nil(t, err)
+	assert.Equal(t, 100, img.Rect.Dx())
+	assert.Equal(t, 50, img.Rect.Dy())
+	// y 轴向下，矩形 (10,10)-(40,30) 在图片的 20 到 40 行
+	assert.Equal(t, uint8(0), img.GrayAt(20, 30).Y)
+	assert.Equal(t, uint8(128), img.GrayAt(70, 30).Y)
+	assert.Equal(t, uint8(255), img.GrayAt(20, 10).Y)
+	assert.Equal(t, uint8(255), img.GrayAt(50, 30).Y)
+
+	img, err = d.RenderPage(1, 144)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, img.Rect.Dx())
+
+	// 顺时针旋转 90 度后左下角的矩形到了左上角
+	d, err = pdf.Open(pagePDF("[0 0 100 50]", "/Rotate 90", "0 g 0 0 10 10 re f"))
+	assert.Nil(t, err)
+	img, err = d.RenderPage(1, 72)
+	assert.Nil(t, err)
+	assert.Equal(t, 50, img.Rect.Dx())
+	assert.Equal(t, 100, img.Rect.Dy())
+	assert.Equal(t, uint8(0), img.GrayAt(5, 5).Y)
+	assert.Equal(t, uint8(255), img.GrayAt(5, 95).Y)
+
+	_, err = d.RenderPage(2, 72)
+	assert.NotNil(t, err)
+}
+
+/**
+ * TestRenderImage 测试 CCITT G4 编码的图片 XObject。
+ */
+func TestRenderImage(t *testing.T) {
+	ccitt := string([]byte{0x2f, 0x78})
+	d, err := pdf.Open(pagePDF("[0 0 8 2]", "/Resources << /XObject << /Im 5 0 R >> >>", "q 8 0 0 2 0 0 cm /Im Do Q",
+		streamObject("/Type /XObject /Subtype /Image /Width 8 /Height 2 /BitsPerComponent 1 /ColorSpace /DeviceGray "+
+			"/Filter /CCITTFaxDecode /DecodeParms << /K -1 /Columns 8 /Rows 2 >>", []byte(ccitt))))
+	assert.Nil(t, err)
+	img, err := d.RenderPage(1, 72)
+	assert.Nil(t, err)
+	for y := 0; y < 2; y++ {
+		var row []uint8
+		for x := 0; x < 8; x++ {
+			row = append(row, img.GrayAt(x, y).Y)
+		}
+		assert.Equal(t, []uint8{255, 255, 0, 0, 0, 255, 255, 255}, row)
+	}
+}
+
+/**
+ * TestRenderType3 测试 Type3 字体的字形按 FontMatrix 和字号绘制。
+ */
+func TestRenderType3(t *testing.T) {
+	d, err := pdf.Open(pagePDF("[0 0 100 100]", "/Resources << /Font << /F1 5 0 R >> >>", "BT /F1 20 Tf 10 10 Td (ab) Tj ET",
+		"<< /Type /Font /Subtype /Type3 /FontBBox [0 0 100 100] /FontMatrix [0.01 0 0 0.01 0 0] "+
+			"/CharProcs << /square 6 0 R >> /Encoding << /Differences [97 /square] >> /FirstChar 97 /LastChar 98 /Widths [150 150] >>",
+		streamObject("", []byte("100 0 0 0 100 100 d1 0 0 100 100 re f"))))
+	assert.Nil(t, err)
+	img, err := d.RenderPage(1, 72)
+	assert.Nil(t, err)
+	// 字号 20 的方块位于 (10,10)-(30,30)，b 没有字形
+	assert.Equal(t, uint8(0), img.GrayAt(20, 80).Y)
+	assert.Equal(t, uint8(255), img.GrayAt(45, 80).Y)
+	assert.Equal(t, uint8(255), img.GrayAt(20, 60).Y)
+}
+
+/**
+ * TestRenderer 测试按页码顺序逐页返回渲染结果，页码去重，超出范围时返回错误。
+ */
+func TestRenderer(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "test.pdf")
+	assert.Nil(t, os.WriteFile(name, simplePDF(), 0644))
+
+	r := pdf.NewNativeRenderer(36)
+	pages, err := r.Render(context.Background(), name, pdf.RenderOptions{Pages: []int{2, 1, 2}})
+	assert.Nil(t, err)
+	var got []int
+	for page := range pages {
+		assert.Nil(t, page.Err)
+		img, err := jpeg.Decode(bytes.NewReader(page.Image))
+		assert.Nil(t, err)
+		assert.Equal(t, 306, img.Bounds().Dx())
+		got = append(got, page.Page)
+	}
+	assert.Equal(t, []int{1, 2}, got)
+
+	pages, err = r.Render(context.Background(), name, pdf.RenderOptions{DPI: 72})
+	assert.Nil(t, err)
+	got = nil
+	for page := range pages {
+		got = append(got, page.Page)
+	}
+	assert.Equal(t, []int{1, 2}, got)
+
+	_, err = r.Render(context.Background(), name, pdf.RenderOptions{Pages: []int{3}})
+	assert.NotNil(t, err)
+
+	// 取消后通道关闭
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	pages, err = r.Render(ctx, name, pdf.RenderOptions{})
+	assert.Nil(t, err)
+	for range pages {
+	}
+}
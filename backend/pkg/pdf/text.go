@@ -0,0 +1,347 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// maxFormDepth 表单 XObject 嵌套的最大深度，防止循环引用
+const maxFormDepth = 8
+
+// matrix 变换矩阵 [a b c d e f]
+type matrix [6]float64
+
+var identity = matrix{1, 0, 0, 1, 0, 0}
+
+// mul 返回 m × n
+func (m matrix) mul(n matrix) matrix {
+	return matrix{
+		m[0]*n[0] + m[1]*n[2],
+		m[0]*n[1] + m[1]*n[3],
+		m[2]*n[0] + m[3]*n[2],
+		m[2]*n[1] + m[3]*n[3],
+		m[4]*n[0] + m[5]*n[2] + n[4],
+		m[4]*n[1] + m[5]*n[3] + n[5],
+	}
+}
+
+func translate(x, y float64) matrix {
+	return matrix{1, 0, 0, 1, x, y}
+}
+
+// gstate 与文本相关的图形状态
+type gstate struct {
+	ctm       matrix
+	font      *font
+	size      float64
+	charSpace float64
+	wordSpace float64
+	scale     float64
+	leading   float64
+	rise      float64
+}
+
+// extractor 解释内容流，按绘制顺序输出文本，根据字形位置插入空格和换行
+type extractor struct {
+	doc   *Document
+	out   strings.Builder
+	state gstate
+	stack []gstate
+	tm    matrix
+	tlm   matrix
+	// 上一个字形结束的位置和字号，用于判断是否换行或插入空格
+	started      bool
+	lastX, lastY float64
+	lastSize     float64
+}
+
+// PageText 返回第 n 页（从 1 开始）文本层中的文本
+func (d *Document) PageText(n int) (text string, err error) {
+	if n < 1 || n > len(d.pages) {
+		return "", fmt.Errorf("pdf: page %d out of range", n)
+	}
+	// 损坏的文件可能导致意料之外的越界，作为错误返回，由调用方改用 OCR
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("pdf: extract page %d: %v", n, r)
+		}
+	}()
+	p := d.pages[n-1]
+	data, err := d.contents(p)
+	if err != nil {
+		return "", err
+	}
+	e := &extractor{doc: d, state: gstate{ctm: identity, scale: 100}}
+	e.run(data, p.resources, 0)
+	return e.text(), nil
+}
+
+// ExtractText 读取 PDF 文件每一页文本层中的文本，无法提取的页面为空字符串
+func ExtractText(name string) (texts []string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			texts, err = nil, fmt.Errorf("pdf: extract text: %v", r)
+		}
+	}()
+	d, err := OpenFile(name)
+	if err != nil {
+		return nil, err
+	}
+	texts = make([]string, d.NumPages())
+	for i := range texts {
+		texts[i], _ = d.PageText(i + 1)
+	}
+	return texts, nil
+}
+
+// Usable 判断页面文本是否可以代替 OCR：至少有一定数量的字母或数字，
+// 并且无法映射的字形、控制字符和私用区字符很少。扫描件通常没有文本层，
+// 缺少 ToUnicode 的字体则会产生大量无法映射的字符
+func Usable(text string) bool {
+	letters, bad, total := 0, 0, 0
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		total++
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			letters++
+		case r == unicode.ReplacementChar || unicode.IsControl(r) || unicode.Is(unicode.Co, r):
+			bad++
+		}
+	}
+	return letters >= 32 && bad*10 < total
+}
+
+func (e *extractor) text() string {
+	lines := strings.Split(e.out.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// run 解释内容流中的操作符
+func (e *extractor) run(data []byte, resources dict, depth int) {
+	l := &lexer{data: data}
+	var operands []any
+	for {
+		pos := l.pos
+		v, err := l.object()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			// 跳过无法解析的记号
+			if l.pos <= pos {
+				l.pos = pos + 1
+			}
+			operands = operands[:0]
+			continue
+		}
+		op, ok := v.(keyword)
+		if !ok {
+			operands = append(operands, v)
+			continue
+		}
+		if op == "BI" {
+			skipInlineImage(l)
+		} else {
+			e.operator(string(op), operands, resources, depth)
+		}
+		operands = operands[:0]
+	}
+}
+
+// skipInlineImage 跳过内联图片的数据，数据以空白后的 EI 结束
+func skipInlineImage(l *lexer) {
+	idx := bytes.Index(l.data[l.pos:], []byte("ID"))
+	if idx < 0 {
+		l.pos = len(l.data)
+		return
+	}
+	for pos := l.pos + idx + 2; pos+2 <= len(l.data); pos++ {
+		if l.data[pos] == 'E' && l.data[pos+1] == 'I' && isWhite(l.data[pos-1]) &&
+			(pos+2 == len(l.data) || isWhite(l.data[pos+2]) || isDelim(l.data[pos+2])) {
+			l.pos = pos + 2
+			return
+		}
+	}
+	l.pos = len(l.data)
+}
+
+func (e *extractor) operator(op string, args []any, resources dict, depth int) {
+	num := func(i int) float64 {
+		if i < len(args) {
+			return number(args[i])
+		}
+		return 0
+	}
+	s := &e.state
+	switch op {
+	case "q":
+		e.stack = append(e.stack, e.state)
+	case "Q":
+		if n := len(e.stack); n > 0 {
+			e.state = e.stack[n-1]
+			e.stack = e.stack[:n-1]
+		}
+	case "cm":
+		if len(args) == 6 {
+			s.ctm = matrix{num(0), num(1), num(2), num(3), num(4), num(5)}.mul(s.ctm)
+		}
+	case "BT":
+		e.tm, e.tlm = identity, identity
+	case "Tf":
+		if len(args) == 2 {
+			fonts, _ := e.doc.resolve(resources[name("Font")]).(dict)
+			if n, ok := args[0].(name); ok {
+				s.font = e.doc.font(fonts[n])
+			}
+			s.size = num(1)
+		}
+	case "Td":
+		e.moveLine(num(0), num(1))
+	case "TD":
+		s.leading = -num(1)
+		e.moveLine(num(0), num(1))
+	case "Tm":
+		if len(args) == 6 {
+			e.tm = matrix{num(0), num(1), num(2), num(3), num(4), num(5)}
+			e.tlm = e.tm
+		}
+	case "T*":
+		e.moveLine(0, -s.leading)
+	case "TL":
+		s.leading = num(0)
+	case "Tc":
+		s.charSpace = num(0)
+	case "Tw":
+		s.wordSpace = num(0)
+	case "Tz":
+		s.scale = num(0)
+	case "Ts":
+		s.rise = num(0)
+	case "Tj":
+		if len(args) == 1 {
+			e.show(args[0])
+		}
+	case "'":
+		e.moveLine(0, -s.leading)
+		if len(args) == 1 {
+			e.show(args[0])
+		}
+	case "\"":
+		if len(args) == 3 {
+			s.wordSpace, s.charSpace = num(0), num(1)
+			e.moveLine(0, -s.leading)
+			e.show(args[2])
+		}
+	case "TJ":
+		if len(args) == 1 {
+			items, _ := args[0].(array)
+			for _, item := range items {
+				if _, ok := item.(string); ok {
+					e.show(item)
+					continue
+				}
+				// 数字表示水平位移，单位为千分之一文本空间
+				tx := -number(item) / 1000 * s.size * s.scale / 100
+				e.tm = translate(tx, 0).mul(e.tm)
+			}
+		}
+	case "Do":
+		if len(args) == 1 && depth < maxFormDepth {
+			e.form(args[0], resources, depth)
+		}
+	}
+}
+
+func (e *extractor) moveLine(tx, ty float64) {
+	e.tlm = translate(tx, ty).mul(e.tlm)
+	e.tm = e.tlm
+}
+
+// form 绘制表单 XObject，图片等其他 XObject 忽略
+func (e *extractor) form(arg any, resources dict, depth int) {
+	n, ok := arg.(name)
+	if !ok {
+		return
+	}
+	xobjects, _ := e.doc.resolve(resources[name("XObject")]).(dict)
+	s, ok := e.doc.resolve(xobjects[n]).(*stream)
+	if !ok || e.doc.resolve(s.hdr[name("Subtype")]) != name("Form") {
+		return
+	}
+	data, err := e.doc.decode(s)
+	if err != nil {
+		return
+	}
+	formResources := resources
+	if r, ok := e.doc.resolve(s.hdr[name("Resources")]).(dict); ok {
+		formResources = r
+	}
+
+	saved, tm, tlm := e.state, e.tm, e.tlm
+	if m, ok := e.doc.resolve(s.hdr[name("Matrix")]).(array); ok && len(m) == 6 {
+		var fm matrix
+		for i := range fm {
+			fm[i] = number(e.doc.resolve(m[i]))
+		}
+		e.state.ctm = fm.mul(e.state.ctm)
+	}
+	stack := len(e.stack)
+	e.run(data, formResources, depth+1)
+	e.stack = e.stack[:min(stack, len(e.stack))]
+	e.state, e.tm, e.tlm = saved, tm, tlm
+}
+
+// show 输出字符串中的字形并移动文本矩阵
+func (e *extractor) show(arg any) {
+	str, ok := arg.(string)
+	s := &e.state
+	if !ok || s.font == nil {
+		return
+	}
+	trm := translate(0, s.rise).mul(e.tm).mul(s.ctm)
+	x, y := trm[4], trm[5]
+	size := math.Abs(s.size * math.Hypot(trm[2], trm[3]))
+	if size == 0 {
+		size = 1
+	}
+	e.separate(x, y, size)
+
+	for _, c := range s.font.codes(str) {
+		e.out.WriteString(s.font.text(c))
+		tx := s.font.width(c)/1000*s.size + s.charSpace
+		if c.bytes == 1 && c.value == ' ' {
+			tx += s.wordSpace
+		}
+		e.tm = translate(tx*s.scale/100, 0).mul(e.tm)
+	}
+
+	end := translate(0, s.rise).mul(e.tm).mul(s.ctm)
+	e.started = true
+	e.lastX, e.lastY, e.lastSize = end[4], end[5], size
+}
+
+// separate 根据新字符串的起点和上一个字形的终点插入换行或空格
+func (e *extractor) separate(x, y, size float64) {
+	if !e.started {
+		return
+	}
+	ref := max(size, e.lastSize)
+	switch {
+	case math.Abs(y-e.lastY) > ref/2:
+		e.out.WriteByte('\n')
+	case x-e.lastX > ref*0.15 || e.lastX-x > ref:
+		if out := e.out.String(); out != "" && !strings.HasSuffix(out, " ") && !strings.HasSuffix(out, "\n") {
+			e.out.WriteByte(' ')
+		}
+	}
+}
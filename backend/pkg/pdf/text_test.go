@@ -0,0 +1,225 @@
+package pdf_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"paper-translation/pkg/pdf"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// buildPDF 按顺序写入编号从 1 开始的对象，并生成交叉引用表和文件尾
+func buildPDF(objects ...string) []byte {
+	return buildPDFTrailer("", objects...)
+}
+
+// buildPDFTrailer 与 buildPDF 相同，trailer 为文件尾字典中额外的条目
+func buildPDFTrailer(trailer string, objects ...string) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R %s>>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, trailer, xref)
+	return b.Bytes()
+}
+
+// streamObject 返回流对象的文本
+func streamObject(hdr string, data []byte) string {
+	return fmt.Sprintf("<< %s /Length %d >>\nstream\n%s\nendstream", hdr, len(data), data)
+}
+
+func deflate(data []byte) []byte {
+	var b bytes.Buffer
+	w := zlib.NewWriter(&b)
+	_, _ = w.Write(data)
+	_ = w.Close()
+	return b.Bytes()
+}
+
+// simplePDF 两页文档，使用 WinAnsi 编码的标准字体和带连字的自定义编码
+func simplePDF() []byte {
+	page1 := []byte(`BT /F1 12 Tf 72 720 Td [(Hello)-333(w)20(orld)] TJ 0 -14 Td (Caf\351 \(au lait\)) Tj ET
+BT /F2 10 Tf 1 0 0 1 72 600 Tm (\014nal) Tj ET
+BI /W 2 /H 2 /BPC 8 /CS /G ID ` + "\x00EI\xff\x00" + ` EI`)
+	page2 := []byte(`BT /F1 12 Tf 72 720 Td (Second page) Tj ET`)
+	return buildPDF(
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /Resources << /Font << /F1 5 0 R /F2 6 0 R >> >> >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Contents 7 0 R >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Contents 8 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /CMR10 /Encoding << /Differences [12 /fi] >> >>",
+		streamObject("", page1),
+		streamObject("", page2),
+	)
+}
+
+/**
+ * TestPageText 测试从交叉引用表读取页面，并按字形位置插入空格和换行。
+ */
+func TestPageText(t *testing.T) {
+	d, err := pdf.Open(simplePDF())
+	assert.Nil(t, err)
+	assert.Equal(t, 2, d.NumPages())
+
+	text, err := d.PageText(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "Hello world\nCafé (au lait)\nfinal", text)
+
+	text, err = d.PageText(2)
+	assert.Nil(t, err)
+	assert.Equal(t, "Second page", text)
+
+	_, err = d.PageText(3)
+	assert.NotNil(t, err)
+}
+
+/**
+ * TestObjectStreams 测试交叉引用流、对象流、压缩的内容流和带 ToUnicode 的 Type0 字体。
+ */
+func TestObjectStreams(t *testing.T) {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.5\n")
+	offsets := map[int]int{}
+
+	// 对象 1 到 4 保存在对象流 6 中
+	var header, body string
+	for i, obj := range []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type0 /BaseFont /SimSun /Encoding /Identity-H /DescendantFonts [<< /Subtype /CIDFontType2 /DW 1000 >>] /ToUnicode 7 0 R >>",
+	} {
+		header += fmt.Sprintf("%d %d ", i+1, len(body))
+		body += obj + "\n"
+	}
+
+	offsets[5] = b.Len()
+	content := deflate([]byte("BT /F1 10 Tf 100 700 Td <0001000200030004> Tj 0 -20 Td <0003> Tj ET"))
+	fmt.Fprintf(&b, "5 0 obj\n%s\nendobj\n", streamObject("/Filter /FlateDecode", content))
+	offsets[6] = b.Len()
+	objStm := deflate([]byte(header + body))
+	fmt.Fprintf(&b, "6 0 obj\n%s\nendobj\n", streamObject(fmt.Sprintf("/Type /ObjStm /N 4 /First %d /Filter /FlateDecode", len(header)), objStm))
+	offsets[7] = b.Len()
+	cmap := []byte(`/CIDInit /ProcSet findresource begin
+12 dict begin
+begincmap
+1 begincodespacerange <0000> <FFFF> endcodespacerange
+2 beginbfchar <0001> <8BBA> <0002> <6587> endbfchar
+1 beginbfrange <0003> <0004> [<7FFB> <8BD1>] endbfrange
+endcmap`)
+	fmt.Fprintf(&b, "7 0 obj\n%s\nendobj\n", streamObject("", cmap))
+	offsets[8] = b.Len()
+
+	// 交叉引用流使用 PNG Up 预测器
+	var rows, prev []byte
+	prev = make([]byte, 7)
+	for id := 0; id <= 8; id++ {
+		row := make([]byte, 7)
+		switch {
+		case id == 0:
+		case id <= 4:
+			row[0] = 2
+			binary.BigEndian.PutUint32(row[1:5], 6)
+			binary.BigEndian.PutUint16(row[5:], uint16(id-1))
+		default:
+			row[0] = 1
+			binary.BigEndian.PutUint32(row[1:5], uint32(offsets[id]))
+		}
+		rows = append(rows, 2)
+		for i := range row {
+			rows = append(rows, row[i]-prev[i])
+		}
+		prev = row
+	}
+	fmt.Fprintf(&b, "8 0 obj\n%s\nendobj\n", streamObject(
+		"/Type /XRef /Size 9 /Root 1 0 R /W [1 4 2] /Filter /FlateDecode /DecodeParms << /Predictor 12 /Columns 7 >>",
+		deflate(rows)))
+	fmt.Fprintf(&b, "startxref\n%d\n%%%%EOF\n", offsets[8])
+
+	d, err := pdf.Open(b.Bytes())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, d.NumPages())
+	text, err := d.PageText(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "论文翻译\n翻", text)
+}
+
+/**
+ * TestRebuildXref 测试交叉引用表损坏时扫描文件重建。
+ */
+func TestRebuildXref(t *testing.T) {
+	data := simplePDF()
+	idx := bytes.LastIndex(data, []byte("startxref"))
+	data = append(data[:idx:idx], []byte("startxref\n123456\n%%EOF\n")...)
+
+	d, err := pdf.Open(data)
+	assert.Nil(t, err)
+	text, err := d.PageText(2)
+	assert.Nil(t, err)
+	assert.Equal(t, "Second page", text)
+
+	_, err = pdf.Open([]byte("not a pdf"))
+	assert.NotNil(t, err)
+}
+
+/**
+ * TestExtractText 测试从文件读取每一页的文本层。
+ */
+func TestExtractText(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "paper.pdf")
+	assert.Nil(t, os.WriteFile(name, simplePDF(), 0o644))
+
+	texts, err := pdf.ExtractText(name)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"Hello world\nCafé (au lait)\nfinal", "Second page"}, texts)
+}
+
+/**
+ * TestCorrupted 测试截断或损坏的文件在打开和提取文本时返回错误而不是 panic。
+ */
+func TestCorrupted(t *testing.T) {
+	data := simplePDF()
+	name := filepath.Join(t.TempDir(), "paper.pdf")
+	for i := 8; i < len(data); i += 7 {
+		corrupted := append([]byte{}, data...)
+		corrupted[i] = '0'
+		for _, b := range [][]byte{data[:i], corrupted} {
+			assert.NotPanics(t, func() {
+				if d, err := pdf.Open(b); err == nil {
+					for n := 1; n <= d.NumPages(); n++ {
+						_, _ = d.PageText(n)
+					}
+				}
+			})
+			assert.Nil(t, os.WriteFile(name, b, 0o644))
+			assert.NotPanics(t, func() { _, _ = pdf.ExtractText(name) })
+		}
+	}
+}
+
+/**
+ * TestUsable 测试判断文本层是否可以代替 OCR。
+ */
+func TestUsable(t *testing.T) {
+	sentence := "We evaluate the proposed model on three public benchmarks."
+	assert.True(t, pdf.Usable(sentence))
+	assert.False(t, pdf.Usable("Figure 1"))
+	assert.False(t, pdf.Usable(""))
+	assert.False(t, pdf.Usable(sentence+strings.Repeat("�", 20)))
+	assert.False(t, pdf.Usable(sentence+strings.Repeat("", 20)))
+}
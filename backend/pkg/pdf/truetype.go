@@ -0,0 +1,432 @@
+package pdf
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var errFont = errors.New("pdf: invalid font program")
+
+// sfnt TrueType 或 OpenType 字体文件中的表
+type sfnt struct {
+	data   []byte
+	tables map[string][]byte
+}
+
+func parseSFNT(data []byte) (*sfnt, error) {
+	if len(data) < 12 {
+		return nil, errFont
+	}
+	// TrueType 集合取第一个字体
+	if string(data[:4]) == "ttcf" {
+		if len(data) < 16 {
+			return nil, errFont
+		}
+		offset := int(binary.BigEndian.Uint32(data[12:]))
+		if offset+12 > len(data) {
+			return nil, errFont
+		}
+		data = data[offset:]
+	}
+	n := int(binary.BigEndian.Uint16(data[4:]))
+	f := &sfnt{data: data, tables: map[string][]byte{}}
+	for i := 0; i < n; i++ {
+		rec := 12 + 16*i
+		if rec+16 > len(data) {
+			return nil, errFont
+		}
+		tag := string(data[rec : rec+4])
+		offset := int(binary.BigEndian.Uint32(data[rec+8:]))
+		length := int(binary.BigEndian.Uint32(data[rec+12:]))
+		// 子集化的字体常常写错表的长度，截断到文件末尾
+		if offset < 0 || offset > len(data) {
+			continue
+		}
+		f.tables[tag] = data[offset:min(offset+length, len(data))]
+	}
+	return f, nil
+}
+
+// trueType TrueType 字形轮廓
+type trueType struct {
+	glyf, loca []byte
+	longLoca   bool
+	numGlyphs  int
+	scale      float64
+	// cmaps 字体中的 cmap 子表，键为平台和编码
+	cmaps map[[2]int]map[int]int
+	// cidToGID CIDFontType2 的 CID 到字形的映射，为空时两者相同
+	cidToGID []byte
+	cid      bool
+	symbolic bool
+}
+
+func parseTrueType(data []byte) (*trueType, error) {
+	f, err := parseSFNT(data)
+	if err != nil {
+		return nil, err
+	}
+	head, maxp := f.tables["head"], f.tables["maxp"]
+	t := &trueType{glyf: f.tables["glyf"], loca: f.tables["loca"], scale: 1.0 / 1000, cmaps: map[[2]int]map[int]int{}}
+	if len(head) >= 54 {
+		if upem := binary.BigEndian.Uint16(head[18:]); upem > 0 {
+			t.scale = 1 / float64(upem)
+		}
+		t.longLoca = binary.BigEndian.Uint16(head[50:]) != 0
+	}
+	if len(maxp) >= 6 {
+		t.numGlyphs = int(binary.BigEndian.Uint16(maxp[4:]))
+	}
+	if t.glyf == nil || t.loca == nil {
+		return nil, errFont
+	}
+	t.parseCmap(f.tables["cmap"])
+	return t, nil
+}
+
+// parseCmap 读取 cmap 表中格式为 0、4、6、12 的子表
+func (t *trueType) parseCmap(data []byte) {
+	if len(data) < 4 {
+		return
+	}
+	n := int(binary.BigEndian.Uint16(data[2:]))
+	for i := 0; i < n; i++ {
+		rec := 4 + 8*i
+		if rec+8 > len(data) {
+			return
+		}
+		platform := int(binary.BigEndian.Uint16(data[rec:]))
+		encoding := int(binary.BigEndian.Uint16(data[rec+2:]))
+		offset := int(binary.BigEndian.Uint32(data[rec+4:]))
+		if offset+4 > len(data) {
+			continue
+		}
+		if m := parseCmapSubtable(data[offset:]); m != nil {
+			t.cmaps[[2]int{platform, encoding}] = m
+		}
+	}
+}
+
+func parseCmapSubtable(data []byte) map[int]int {
+	u16 := func(i int) int {
+		if i+2 > len(data) {
+			return 0
+		}
+		return int(binary.BigEndian.Uint16(data[i:]))
+	}
+	u32 := func(i int) int {
+		if i+4 > len(data) {
+			return 0
+		}
+		return int(binary.BigEndian.Uint32(data[i:]))
+	}
+	m := map[int]int{}
+	switch u16(0) {
+	case 0:
+		for c := 0; c < 256 && 6+c < len(data); c++ {
+			if gid := int(data[6+c]); gid != 0 {
+				m[c] = gid
+			}
+		}
+	case 4:
+		segs := u16(6) / 2
+		ends, starts, deltas, offsets := 14, 16+2*segs, 16+4*segs, 16+6*segs
+		for s := 0; s < segs; s++ {
+			end, start := u16(ends+2*s), u16(starts+2*s)
+			delta, rangeOffset := u16(deltas+2*s), u16(offsets+2*s)
+			for c := start; c <= end && c != 0xffff; c++ {
+				gid := 0
+				if rangeOffset == 0 {
+					gid = (c + delta) & 0xffff
+				} else {
+					g := u16(offsets + 2*s + rangeOffset + 2*(c-start))
+					if g != 0 {
+						gid = (g + delta) & 0xffff
+					}
+				}
+				if gid != 0 {
+					m[c] = gid
+				}
+			}
+		}
+	case 6:
+		first, count := u16(6), u16(8)
+		for i := 0; i < count; i++ {
+			if gid := u16(10 + 2*i); gid != 0 {
+				m[first+i] = gid
+			}
+		}
+	case 12:
+		groups := u32(12)
+		for g := 0; g < groups && g < 1<<16; g++ {
+			start, end, gid := u32(16+12*g), u32(20+12*g), u32(24+12*g)
+			for c := start; c <= end && c-start < 1<<16; c++ {
+				m[c] = gid + c - start
+			}
+		}
+	default:
+		return nil
+	}
+	return m
+}
+
+// gid 返回字符编码对应的字形编号
+func (t *trueType) gid(f *font, c code) int {
+	if t.cid {
+		// 只支持 Identity 编码，CID 等于字符编码
+		cid := c.value
+		if len(t.cidToGID) > 0 {
+			if 2*cid+1 < len(t.cidToGID) {
+				return int(t.cidToGID[2*cid])<<8 | int(t.cidToGID[2*cid+1])
+			}
+			return 0
+		}
+		return cid
+	}
+	// 符号字体按编码直接查找 (3,0) 子表，编码可能加上了 0xF000 等偏移
+	if m, ok := t.cmaps[[2]int{3, 0}]; ok && (t.symbolic || t.cmaps[[2]int{3, 1}] == nil) {
+		for _, base := range []int{0, 0xf000, 0xf100, 0xf200} {
+			if gid, ok := m[base+c.value]; ok {
+				return gid
+			}
+		}
+	}
+	if m, ok := t.cmaps[[2]int{3, 1}]; ok {
+		r := rune(0)
+		if n := f.differences[c.value]; n != "" {
+			if s := []rune(glyphText(n)); len(s) > 0 {
+				r = s[0]
+			}
+		} else if c.value < 256 {
+			r = f.encoding[c.value]
+		}
+		if gid, ok := m[int(r)]; ok && r != 0 {
+			return gid
+		}
+	}
+	if m, ok := t.cmaps[[2]int{1, 0}]; ok {
+		if gid, ok := m[c.value]; ok {
+			return gid
+		}
+	}
+	// 一些生成器在子集字体中直接用字形编号作为编码
+	return c.value
+}
+
+func (t *trueType) outline(f *font, c code) (outline, bool) {
+	var out outline
+	t.glyph(t.gid(f, c), matrix{t.scale, 0, 0, t.scale, 0, 0}, &out, 0)
+	return out, len(out) > 0
+}
+
+// glyph 读取字形轮廓并按 m 变换，组合字形递归读取各部分
+func (t *trueType) glyph(gid int, m matrix, out *outline, depth int) {
+	if depth > 8 || gid < 0 || (t.numGlyphs > 0 && gid >= t.numGlyphs) {
+		return
+	}
+	var start, end int
+	if t.longLoca {
+		if 4*gid+8 > len(t.loca) {
+			return
+		}
+		start = int(binary.BigEndian.Uint32(t.loca[4*gid:]))
+		end = int(binary.BigEndian.Uint32(t.loca[4*gid+4:]))
+	} else {
+		if 2*gid+4 > len(t.loca) {
+			return
+		}
+		start = 2 * int(binary.BigEndian.Uint16(t.loca[2*gid:]))
+		end = 2 * int(binary.BigEndian.Uint16(t.loca[2*gid+2:]))
+	}
+	if start >= end || end > len(t.glyf) || end-start < 10 {
+		return
+	}
+	g := t.glyf[start:end]
+	contours := int(int16(binary.BigEndian.Uint16(g)))
+	if contours < 0 {
+		t.composite(g[10:], m, out, depth)
+		return
+	}
+	simpleGlyph(g, contours, m, out)
+}
+
+// simpleGlyph 解析简单字形：轮廓终点、指令、标志和坐标，相邻的两个控制点之间隐含一个曲线上的点
+func simpleGlyph(g []byte, contours int, m matrix, out *outline) {
+	pos := 10
+	if pos+2*contours+2 > len(g) {
+		return
+	}
+	ends := make([]int, contours)
+	for i := range ends {
+		ends[i] = int(binary.BigEndian.Uint16(g[pos+2*i:]))
+	}
+	pos += 2 * contours
+	if contours == 0 {
+		return
+	}
+	n := ends[contours-1] + 1
+	pos += 2 + int(binary.BigEndian.Uint16(g[pos:]))
+
+	flags := make([]byte, 0, n)
+	for len(flags) < n && pos < len(g) {
+		f := g[pos]
+		pos++
+		flags = append(flags, f)
+		if f&8 != 0 && pos < len(g) {
+			for r := int(g[pos]); r > 0 && len(flags) < n; r-- {
+				flags = append(flags, f)
+			}
+			pos++
+		}
+	}
+	if len(flags) < n {
+		return
+	}
+	coords := func(short, same byte) []int {
+		vs := make([]int, n)
+		v := 0
+		for i, f := range flags {
+			switch {
+			case f&short != 0:
+				if pos >= len(g) {
+					return nil
+				}
+				d := int(g[pos])
+				pos++
+				if f&same == 0 {
+					d = -d
+				}
+				v += d
+			case f&same == 0:
+				if pos+2 > len(g) {
+					return nil
+				}
+				v += int(int16(binary.BigEndian.Uint16(g[pos:])))
+				pos += 2
+			}
+			vs[i] = v
+		}
+		return vs
+	}
+	xs := coords(2, 16)
+	ys := coords(4, 32)
+	if xs == nil || ys == nil {
+		return
+	}
+
+	start := 0
+	for _, end := range ends {
+		if end >= n || end < start {
+			return
+		}
+		pts := make([]point, 0, end-start+1)
+		on := make([]bool, 0, end-start+1)
+		for i := start; i <= end; i++ {
+			pts = append(pts, m.apply(float64(xs[i]), float64(ys[i])))
+			on = append(on, flags[i]&1 != 0)
+		}
+		start = end + 1
+		quadContour(pts, on, out)
+	}
+}
+
+// quadContour 把一个由曲线上的点和控制点组成的二次曲线轮廓加入 out
+func quadContour(pts []point, on []bool, out *outline) {
+	n := len(pts)
+	if n == 0 {
+		return
+	}
+	mid := func(a, b point) point { return point{(a.x + b.x) / 2, (a.y + b.y) / 2} }
+	// 找一个曲线上的点作为起点，全是控制点时用前两个点的中点
+	first := -1
+	for i := range on {
+		if on[i] {
+			first = i
+			break
+		}
+	}
+	var startPt point
+	if first < 0 {
+		startPt, first = mid(pts[0], pts[1%n]), 0
+	} else {
+		startPt = pts[first]
+	}
+	*out = append(*out, segment{op: 'M', pts: [3]point{startPt}})
+	var ctrl *point
+	for k := 1; k <= n; k++ {
+		i := (first + k) % n
+		p := pts[i]
+		if on[i] || k == n && on[first] {
+			if k == n {
+				p = startPt
+			}
+			if ctrl != nil {
+				*out = append(*out, segment{op: 'Q', pts: [3]point{*ctrl, p}})
+				ctrl = nil
+			} else {
+				*out = append(*out, segment{op: 'L', pts: [3]point{p}})
+			}
+			continue
+		}
+		if ctrl != nil {
+			m := mid(*ctrl, p)
+			*out = append(*out, segment{op: 'Q', pts: [3]point{*ctrl, m}})
+		}
+		c := p
+		ctrl = &c
+	}
+	if ctrl != nil {
+		*out = append(*out, segment{op: 'Q', pts: [3]point{*ctrl, startPt}})
+	}
+	*out = append(*out, segment{op: 'Z'})
+}
+
+// composite 解析组合字形，每个部分带有偏移和可选的缩放
+func (t *trueType) composite(g []byte, m matrix, out *outline, depth int) {
+	pos := 0
+	for pos+4 <= len(g) {
+		flags := binary.BigEndian.Uint16(g[pos:])
+		gid := int(binary.BigEndian.Uint16(g[pos+2:]))
+		pos += 4
+		var dx, dy float64
+		if flags&1 != 0 {
+			if pos+4 > len(g) {
+				return
+			}
+			dx, dy = float64(int16(binary.BigEndian.Uint16(g[pos:]))), float64(int16(binary.BigEndian.Uint16(g[pos+2:])))
+			pos += 4
+		} else {
+			if pos+2 > len(g) {
+				return
+			}
+			dx, dy = float64(int8(g[pos])), float64(int8(g[pos+1]))
+			pos += 2
+		}
+		f2dot14 := func() float64 {
+			if pos+2 > len(g) {
+				return 0
+			}
+			v := float64(int16(binary.BigEndian.Uint16(g[pos:]))) / 16384
+			pos += 2
+			return v
+		}
+		a, b, c, d := 1.0, 0.0, 0.0, 1.0
+		switch {
+		case flags&8 != 0:
+			a = f2dot14()
+			d = a
+		case flags&0x40 != 0:
+			a, d = f2dot14(), f2dot14()
+		case flags&0x80 != 0:
+			a, b, c, d = f2dot14(), f2dot14(), f2dot14(), f2dot14()
+		}
+		// 参数不是偏移而是点的编号时（flags&2 为 0）忽略，这种字形很少见
+		if flags&2 == 0 {
+			dx, dy = 0, 0
+		}
+		t.glyph(gid, matrix{a, b, c, d, dx, dy}.mul(m), out, depth+1)
+		if flags&0x20 == 0 {
+			return
+		}
+	}
+}
@@ -0,0 +1,403 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/hex"
+	"regexp"
+	"strconv"
+)
+
+// type1 Type 1 字体程序（FontFile），字形程序在 eexec 加密的部分
+type type1 struct {
+	charStrings [][]byte
+	subrs       [][]byte
+	names       map[string]int
+	byRune      map[rune]int
+	builtin     map[int]int
+	matrix      matrix
+}
+
+var (
+	fontMatrixPattern = regexp.MustCompile(`/FontMatrix\s*\[([^\]]*)\]`)
+	lenIVPattern      = regexp.MustCompile(`/lenIV\s+(-?\d+)`)
+	// binaryPattern 加密数据的前缀：名称或编号、长度和 RD 操作符
+	binaryPattern = regexp.MustCompile(`(?:dup\s+(\d+)|/(\S+))\s+(\d+)\s+(?:RD|-\|)\s`)
+)
+
+func parseType1(data []byte) (*type1, error) {
+	data = stripPFB(data)
+	start := bytes.Index(data, []byte("eexec"))
+	if start < 0 {
+		return nil, errFont
+	}
+	clear := data[:start]
+	encrypted := data[start+len("eexec"):]
+	for len(encrypted) > 0 && (encrypted[0] == '\r' || encrypted[0] == '\n' || encrypted[0] == ' ' || encrypted[0] == '\t') {
+		encrypted = encrypted[1:]
+	}
+	// 加密部分也可能是十六进制文本
+	if len(encrypted) >= 4 && isHex(encrypted[:4]) {
+		encrypted = hexBytes(encrypted)
+	}
+	private := decrypt(encrypted, 55665, 4)
+
+	t := &type1{names: map[string]int{}, matrix: matrix{0.001, 0, 0, 0.001, 0, 0}}
+	if m := fontMatrixPattern.FindSubmatch(clear); m != nil {
+		var v []float64
+		for _, f := range bytes.Fields(m[1]) {
+			n, err := strconv.ParseFloat(string(f), 64)
+			if err != nil {
+				break
+			}
+			v = append(v, n)
+		}
+		t.matrix = cffMatrix(v, t.matrix)
+	}
+	lenIV := 4
+	if m := lenIVPattern.FindSubmatch(private); m != nil {
+		lenIV, _ = strconv.Atoi(string(m[1]))
+	}
+
+	charStrings := bytes.Index(private, []byte("/CharStrings"))
+	if charStrings < 0 {
+		return nil, errFont
+	}
+	// Subrs 在 CharStrings 之前，按 dup 编号读取；CharStrings 按名称读取
+	for _, part := range []struct {
+		data  []byte
+		subrs bool
+	}{{private[:charStrings], true}, {private[charStrings:], false}} {
+		pos := 0
+		for {
+			loc := binaryPattern.FindSubmatchIndex(part.data[pos:])
+			if loc == nil {
+				break
+			}
+			n, _ := strconv.Atoi(string(part.data[pos+loc[6] : pos+loc[7]]))
+			begin := pos + loc[1]
+			if n < 0 || begin+n > len(part.data) {
+				break
+			}
+			cs := decrypt(part.data[begin:begin+n], 4330, lenIV)
+			switch {
+			case part.subrs && loc[2] >= 0:
+				i, _ := strconv.Atoi(string(part.data[pos+loc[2] : pos+loc[3]]))
+				if i >= 0 && i < 1<<16 {
+					for len(t.subrs) <= i {
+						t.subrs = append(t.subrs, nil)
+					}
+					t.subrs[i] = cs
+				}
+			case !part.subrs && loc[4] >= 0:
+				t.names[string(part.data[pos+loc[4]:pos+loc[5]])] = len(t.charStrings)
+				t.charStrings = append(t.charStrings, cs)
+			}
+			pos = begin + n
+		}
+	}
+	if len(t.charStrings) == 0 {
+		return nil, errFont
+	}
+	t.byRune = runeIndex(t.names)
+	t.builtin = map[int]int{}
+	for _, m := range dupPattern.FindAllSubmatch(clear, -1) {
+		c, err := strconv.Atoi(string(m[1]))
+		if g, ok := t.names[string(m[2])]; ok && err == nil {
+			t.builtin[c] = g
+		}
+	}
+	return t, nil
+}
+
+// stripPFB 去掉 PFB 格式的段头
+func stripPFB(data []byte) []byte {
+	if len(data) < 6 || data[0] != 0x80 {
+		return data
+	}
+	var out []byte
+	for len(data) >= 6 && data[0] == 0x80 && data[1] != 3 {
+		n := int(data[2]) | int(data[3])<<8 | int(data[4])<<16 | int(data[5])<<24
+		data = data[6:]
+		if n < 0 || n > len(data) {
+			n = len(data)
+		}
+		out = append(out, data[:n]...)
+		data = data[n:]
+	}
+	return out
+}
+
+func isHex(b []byte) bool {
+	for _, c := range b {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
+	return true
+}
+
+func hexBytes(b []byte) []byte {
+	digits := make([]byte, 0, len(b))
+	for _, c := range b {
+		if isHex([]byte{c}) {
+			digits = append(digits, c)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = digits[:len(digits)-1]
+	}
+	out := make([]byte, len(digits)/2)
+	hex.Decode(out, digits)
+	return out
+}
+
+// decrypt Type 1 字体的加密算法，skip 为开头需要丢弃的随机字节数，为负数表示没有加密
+func decrypt(data []byte, r uint16, skip int) []byte {
+	if skip < 0 {
+		return data
+	}
+	out := make([]byte, len(data))
+	for i, c := range data {
+		out[i] = c ^ byte(r>>8)
+		r = (uint16(c)+r)*52845 + 22719
+	}
+	if skip > len(out) {
+		return nil
+	}
+	return out[skip:]
+}
+
+func (t *type1) outline(f *font, c code) (outline, bool) {
+	g, ok := glyphIndex(f, c.value, t.names, t.builtin, t.byRune)
+	if !ok {
+		return nil, false
+	}
+	p := &pen{m: t.matrix}
+	r := &type1Runner{t: t, p: p}
+	r.run(t.charStrings[g], 0)
+	p.closePath()
+	return p.out, len(p.out) > 0
+}
+
+// type1Runner Type 1 字形程序的解释器，flex 和提示替换通过 OtherSubrs 0 到 3 实现
+type type1Runner struct {
+	t     *type1
+	p     *pen
+	stack []float64
+	// ps callothersubr 返回给 pop 的值
+	ps     []float64
+	flex   []point
+	flexOn bool
+	ended  bool
+}
+
+func (r *type1Runner) run(cs []byte, depth int) {
+	if depth > 10 {
+		r.ended = true
+		return
+	}
+	p := r.p
+	for i := 0; i < len(cs) && !r.ended; {
+		b := int(cs[i])
+		i++
+		switch {
+		case b >= 32 && b <= 246:
+			r.stack = append(r.stack, float64(b-139))
+			continue
+		case b >= 247 && b <= 250 && i < len(cs):
+			r.stack = append(r.stack, float64((b-247)*256+int(cs[i])+108))
+			i++
+			continue
+		case b >= 251 && b <= 254 && i < len(cs):
+			r.stack = append(r.stack, float64(-(b-251)*256-int(cs[i])-108))
+			i++
+			continue
+		case b == 255 && i+3 < len(cs):
+			r.stack = append(r.stack, float64(int32(uint32(cs[i])<<24|uint32(cs[i+1])<<16|uint32(cs[i+2])<<8|uint32(cs[i+3]))))
+			i += 4
+			continue
+		}
+		s := r.stack
+		switch b {
+		case 13: // hsbw
+			if len(s) >= 2 {
+				p.x, p.y = s[0], 0
+			}
+		case 9: // closepath
+			p.closePath()
+		case 21: // rmoveto
+			if len(s) >= 2 {
+				r.moveTo(p.x+s[0], p.y+s[1])
+			}
+		case 22: // hmoveto
+			if len(s) >= 1 {
+				r.moveTo(p.x+s[0], p.y)
+			}
+		case 4: // vmoveto
+			if len(s) >= 1 {
+				r.moveTo(p.x, p.y+s[0])
+			}
+		case 5: // rlineto
+			if len(s) >= 2 {
+				p.lineTo(p.x+s[0], p.y+s[1])
+			}
+		case 6: // hlineto
+			if len(s) >= 1 {
+				p.lineTo(p.x+s[0], p.y)
+			}
+		case 7: // vlineto
+			if len(s) >= 1 {
+				p.lineTo(p.x, p.y+s[0])
+			}
+		case 8: // rrcurveto
+			if len(s) >= 6 {
+				r.curve(s[0], s[1], s[2], s[3], s[4], s[5])
+			}
+		case 30: // vhcurveto
+			if len(s) >= 4 {
+				r.curve(0, s[0], s[1], s[2], s[3], 0)
+			}
+		case 31: // hvcurveto
+			if len(s) >= 4 {
+				r.curve(s[0], 0, s[1], s[2], 0, s[3])
+			}
+		case 10: // callsubr
+			if len(s) == 0 {
+				return
+			}
+			n := int(s[len(s)-1])
+			r.stack = s[:len(s)-1]
+			if n >= 0 && n < len(r.t.subrs) {
+				r.run(r.t.subrs[n], depth+1)
+			}
+			continue
+		case 11: // return
+			return
+		case 14: // endchar
+			p.closePath()
+			r.ended = true
+			return
+		case 12:
+			if i >= len(cs) {
+				return
+			}
+			op := cs[i]
+			i++
+			if r.escape(op, depth) {
+				continue
+			}
+		}
+		r.stack = r.stack[:0]
+	}
+}
+
+// escape 处理两字节的操作符，返回 true 表示栈需要保留
+func (r *type1Runner) escape(op byte, depth int) bool {
+	s := r.stack
+	p := r.p
+	switch op {
+	case 6: // seac
+		if len(s) >= 5 {
+			r.seac(s[0], s[1], s[2], int(s[3]), int(s[4]), depth)
+		}
+	case 7: // sbw
+		if len(s) >= 4 {
+			p.x, p.y = s[0], s[1]
+		}
+	case 12: // div
+		if len(s) >= 2 {
+			a, b := s[len(s)-2], s[len(s)-1]
+			if b != 0 {
+				a /= b
+			}
+			r.stack = append(s[:len(s)-2], a)
+		}
+		return true
+	case 16: // callothersubr
+		if len(s) < 2 {
+			return false
+		}
+		n, count := int(s[len(s)-1]), int(s[len(s)-2])
+		s = s[:len(s)-2]
+		count = max(0, min(count, len(s)))
+		args := s[len(s)-count:]
+		r.stack = s[:len(s)-count]
+		r.ps = r.ps[:0]
+		switch n {
+		case 0:
+			// 结束 flex：参考点之后的六个点组成两段曲线
+			if len(r.flex) >= 7 {
+				f := r.flex
+				p.curveTo(f[1].x, f[1].y, f[2].x, f[2].y, f[3].x, f[3].y)
+				p.curveTo(f[4].x, f[4].y, f[5].x, f[5].y, f[6].x, f[6].y)
+			}
+			r.flexOn, r.flex = false, nil
+			r.ps = append(r.ps, p.y, p.x)
+		case 1:
+			r.flexOn, r.flex = true, nil
+		case 2:
+		default:
+			for j := len(args) - 1; j >= 0; j-- {
+				r.ps = append(r.ps, args[j])
+			}
+		}
+		return true
+	case 17: // pop
+		if len(r.ps) > 0 {
+			r.stack = append(r.stack, r.ps[len(r.ps)-1])
+			r.ps = r.ps[:len(r.ps)-1]
+		}
+		return true
+	case 33: // setcurrentpoint
+		if len(s) >= 2 {
+			p.x, p.y = s[0], s[1]
+		}
+	}
+	return false
+}
+
+// moveTo flex 进行中时只记录点，不移动画笔
+func (r *type1Runner) moveTo(x, y float64) {
+	if r.flexOn {
+		r.p.x, r.p.y = x, y
+		r.flex = append(r.flex, point{x, y})
+		return
+	}
+	r.p.moveTo(x, y)
+}
+
+func (r *type1Runner) curve(dx1, dy1, dx2, dy2, dx3, dy3 float64) {
+	p := r.p
+	x1, y1 := p.x+dx1, p.y+dy1
+	x2, y2 := x1+dx2, y1+dy2
+	p.curveTo(x1, y1, x2, y2, x2+dx3, y2+dy3)
+}
+
+// seac 组合基础字形和重音符号，重音符号的原点在 (adx-asb, ady)
+func (r *type1Runner) seac(asb, adx, ady float64, base, accent, depth int) {
+	lookup := func(c int) ([]byte, bool) {
+		if c < 0 || c > 255 {
+			return nil, false
+		}
+		g, ok := r.t.byRune[standardEncoding[c]]
+		if !ok {
+			return nil, false
+		}
+		return r.t.charStrings[g], true
+	}
+	p := r.p
+	if cs, ok := lookup(base); ok {
+		sub := &type1Runner{t: r.t, p: p}
+		sub.run(cs, depth+1)
+		p.closePath()
+	}
+	if cs, ok := lookup(accent); ok {
+		m := p.m
+		p.m = translate(adx-asb, ady).mul(m)
+		sub := &type1Runner{t: r.t, p: p}
+		sub.run(cs, depth+1)
+		p.closePath()
+		p.m = m
+	}
+	r.ended = true
+}
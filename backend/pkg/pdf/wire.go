@@ -0,0 +1,13 @@
+package pdf
+
+import "go-micro.dev/v4/config"
+
+/**
+ * NewRenderer 根据配置创建PDF渲染器
+ * @param config - 配置管理器
+ * @return 渲染器
+ */
+func NewRenderer(config config.Config) Renderer {
+	dpi := config.Get("ocr", "render", "dpi").Int(DefaultDPI) // 获取渲染分辨率
+	return NewNativeRenderer(dpi)
+}
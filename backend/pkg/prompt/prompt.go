@@ -0,0 +1,51 @@
+package prompt
+
+import (
+	"bytes"
+	"paper-translation/pkg/glossary"
+	"strings"
+	"text/template"
+)
+
+// Vars 渲染提示词模板时可以使用的变量
+type Vars struct {
+	SourceLanguage string          // 原文语言，未知时为空
+	TargetLanguage string          // 目标语言
+	Title          string          // 文档标题，未知时为空
+	Previous       string          // 上一段原文的结尾，用于保持上下文连贯，第一段为空
+	Glossary       []glossary.Term // 本段原文中出现的术语
+	Text           string          // 待翻译文本
+}
+
+// Prompt 一个版本的提示词模板，使用 text/template 语法。
+// 同一名称的模板可以有多个版本，修改模板时应该新增版本而不是修改已有版本，翻译记忆按版本区分。
+type Prompt struct {
+	Name    string
+	Version string
+	Text    string
+	tmpl    *template.Template
+}
+
+// New 解析提示词模板
+func New(name, version, text string) (*Prompt, error) {
+	tmpl, err := template.New(name + "/" + version).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return &Prompt{Name: name, Version: version, Text: text, tmpl: tmpl}, nil
+}
+
+// Key 返回模板的名称和版本，记录在译文和翻译记忆中
+func (p *Prompt) Key() string {
+	return p.Name + "/" + p.Version
+}
+
+// Render 渲染提示词
+func (p *Prompt) Render(vars Vars) (string, error) {
+	var buf bytes.Buffer
+	err := p.tmpl.Execute(&buf, vars)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
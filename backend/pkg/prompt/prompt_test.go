@@ -0,0 +1,53 @@
+package prompt_test
+
+import (
+	"paper-translation/pkg/glossary"
+	"paper-translation/pkg/prompt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const text = `Translate{{with .SourceLanguage}} from {{.}}{{end}} into {{.TargetLanguage}}.
+{{- if .Glossary}}
+Glossary:
+{{- range .Glossary}}
+{{.Source}} => {{.Target}}
+{{- end}}
+{{- end}}
+{{.Text}}`
+
+/**
+ * TestRender 测试渲染可选变量和术语表。
+ */
+func TestRender(t *testing.T) {
+	p, err := prompt.New("default", "v2", text)
+	assert.Nil(t, err)
+	assert.Equal(t, "default/v2", p.Key())
+
+	s, err := p.Render(prompt.Vars{TargetLanguage: "汉语", Text: "Hello"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Translate into 汉语.\nHello", s)
+
+	s, err = p.Render(prompt.Vars{
+		SourceLanguage: "en",
+		TargetLanguage: "汉语",
+		Glossary:       []glossary.Term{{Source: "attention", Target: "注意力"}},
+		Text:           "Attention is all you need.",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Translate from en into 汉语.\nGlossary:\nattention => 注意力\nAttention is all you need.", s)
+}
+
+/**
+ * TestNewInvalid 测试模板语法错误和引用不存在的变量。
+ */
+func TestNewInvalid(t *testing.T) {
+	_, err := prompt.New("broken", "v1", "{{if .Text}}")
+	assert.NotNil(t, err)
+
+	p, err := prompt.New("unknown", "v1", "{{.Author}}")
+	assert.Nil(t, err)
+	_, err = p.Render(prompt.Vars{})
+	assert.NotNil(t, err)
+}
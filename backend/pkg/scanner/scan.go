@@ -105,7 +105,7 @@ func scanSentences(data []byte, atEOF bool) (advance int, token []byte, err erro
 		var r rune
 		r, width = utf8.DecodeRune(data[i:])
 
-		if isEndOfSentence(r, data[start:i], data[i:]) {
+		if isEndOfSentence(r, data[start:i], data[i+width:]) {
 			return i + width, data[start : i+width], nil
 		}
 	}
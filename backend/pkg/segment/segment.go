@@ -0,0 +1,116 @@
+package segment
+
+import (
+	"paper-translation/pkg/scanner"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// paragraphSeparator 段落之间的分隔：至少两个换行，中间可以夹杂空白
+var paragraphSeparator = regexp.MustCompile(`\n[ \t\r\f\v]*\n\s*`)
+
+// Chunk 切分出的一块待翻译文本。
+// 原文首尾的空白单独保存，翻译后原样拼回，保证译文的段落结构和原文一致。
+type Chunk struct {
+	Leading  string // 原文开头的空白
+	Text     string // 去掉首尾空白的原文
+	Trailing string // 原文结尾的空白，段落之间的换行保存在这里
+	Tokens   int    // 估算的 token 数
+}
+
+// String 返回块的原文，所有块的原文按顺序拼接等于切分前的文本
+func (c Chunk) String() string {
+	return c.Leading + c.Text + c.Trailing
+}
+
+// Segmenter 按段落和句子把长文本切分成不超过 token 预算的块。
+// 优先在段落边界切分，多个短段落可以合并成一块；单个段落超出预算时在句子边界切分，
+// 单个句子超出预算时单独成块。
+type Segmenter struct {
+	budget int              // 每块的 token 上限
+	count  func(string) int // 估算文本的 token 数
+}
+
+// NewSegmenter 创建切分器，count 用于估算文本的 token 数
+func NewSegmenter(budget int, count func(string) int) *Segmenter {
+	return &Segmenter{budget: budget, count: count}
+}
+
+// Split 切分文本，空文本返回 nil
+func (t *Segmenter) Split(text string) []Chunk {
+	var chunks []Chunk
+	var buf strings.Builder
+	var tokens int
+	flush := func() {
+		if buf.Len() > 0 {
+			chunks = append(chunks, newChunk(buf.String(), tokens))
+			buf.Reset()
+			tokens = 0
+		}
+	}
+	add := func(s string, n int) {
+		if buf.Len() > 0 && tokens+n > t.budget {
+			flush()
+		}
+		buf.WriteString(s)
+		tokens += n
+	}
+
+	for _, paragraph := range Paragraphs(text) {
+		n := t.count(paragraph)
+		if n <= t.budget {
+			add(paragraph, n)
+			continue
+		}
+		// 段落超出预算，在句子边界切分，并且不和下一个段落合并
+		for _, sentence := range Sentences(paragraph) {
+			add(sentence, t.count(sentence))
+		}
+		flush()
+	}
+	flush()
+	return chunks
+}
+
+// Paragraphs 把文本切分为段落，段落之间的分隔保留在前一个段落的末尾
+func Paragraphs(text string) []string {
+	var paragraphs []string
+	var start int
+	for _, loc := range paragraphSeparator.FindAllStringIndex(text, -1) {
+		paragraphs = append(paragraphs, text[start:loc[1]])
+		start = loc[1]
+	}
+	if start < len(text) {
+		paragraphs = append(paragraphs, text[start:])
+	}
+	return paragraphs
+}
+
+// Sentences 使用 scanner.ScanSentences 把文本切分为句子。
+// 与直接使用 bufio.Scanner 不同，句末标点和句子之间的空白都保留在返回的句子中。
+func Sentences(text string) []string {
+	var sentences []string
+	data := []byte(text)
+	for len(data) > 0 {
+		advance, _, _ := scanner.ScanSentences(data, true)
+		if advance <= 0 {
+			advance = len(data)
+		}
+		// 句子后面的空白归入当前句子，下一个句子从非空白字符开始
+		for advance < len(data) && data[advance] < utf8.RuneSelf && unicode.IsSpace(rune(data[advance])) {
+			advance++
+		}
+		sentences = append(sentences, string(data[:advance]))
+		data = data[advance:]
+	}
+	return sentences
+}
+
+func newChunk(s string, tokens int) Chunk {
+	text := strings.TrimLeftFunc(s, unicode.IsSpace)
+	leading := s[:len(s)-len(text)]
+	trimmed := strings.TrimRightFunc(text, unicode.IsSpace)
+	return Chunk{Leading: leading, Text: trimmed, Trailing: text[len(trimmed):], Tokens: tokens}
+}
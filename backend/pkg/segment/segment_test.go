@@ -0,0 +1,54 @@
+package segment_test
+
+import (
+	"paper-translation/pkg/segment"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/**
+ * TestSentences 测试切分句子时保留标点和空白，并且不在缩写和小数处切分。
+ */
+func TestSentences(t *testing.T) {
+	sentences := segment.Sentences("Dr. Smith paid 3.5 dollars.  你好。我是谁?Yes!\nEnd")
+	assert.Equal(t, []string{"Dr. Smith paid 3.5 dollars.  ", "你好。", "我是谁?", "Yes!\n", "End"}, sentences)
+}
+
+/**
+ * TestSegmenter 测试按段落和 token 预算切分文本。
+ */
+func TestSegmenter(t *testing.T) {
+	text := "\n  First paragraph. Still first.\n\nSecond one.\n \n\nThird has three sentences. Two. Three.\n"
+	// 按字符数估算 token，便于计算预算
+	segmenter := segment.NewSegmenter(35, utf8.RuneCountInString)
+	chunks := segmenter.Split(text)
+
+	var texts []string
+	var joined strings.Builder
+	for _, chunk := range chunks {
+		assert.LessOrEqual(t, chunk.Tokens, 35)
+		texts = append(texts, chunk.Text)
+		joined.WriteString(chunk.String())
+	}
+	// 拼接后与原文完全一致
+	assert.Equal(t, text, joined.String())
+	assert.Equal(t, []string{
+		"First paragraph. Still first.",
+		"Second one.",
+		"Third has three sentences.",
+		"Two. Three.",
+	}, texts)
+	assert.Equal(t, "\n  ", chunks[0].Leading)
+	assert.Equal(t, "\n\n", chunks[0].Trailing)
+	assert.Equal(t, "\n \n\n", chunks[1].Trailing)
+
+	// 预算足够时多个段落合并成一块
+	chunks = segment.NewSegmenter(1000, utf8.RuneCountInString).Split(text)
+	assert.Len(t, chunks, 1)
+	assert.Equal(t, text, chunks[0].String())
+
+	assert.Empty(t, segmenter.Split(""))
+}
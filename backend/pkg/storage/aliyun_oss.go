@@ -0,0 +1,127 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aliyun/aliyun-oss-go-sdk/oss" // 阿里云OSS SDK
+)
+
+// AliYunObjectStore 基于阿里云 OSS 的对象存储实现。
+type AliYunObjectStore struct {
+	client *oss.Client
+}
+
+// NewAliYunObjectStore 使用已有的 OSS 客户端创建对象存储。
+func NewAliYunObjectStore(client *oss.Client) *AliYunObjectStore {
+	return &AliYunObjectStore{client: client}
+}
+
+// Put 上传对象。
+func (t *AliYunObjectStore) Put(ctx context.Context, bucket, key string, reader io.Reader) error {
+	bkt, err := t.client.Bucket(bucket)
+	if err != nil {
+		return err
+	}
+	return bkt.PutObject(key, reader)
+}
+
+// Get 下载对象。
+func (t *AliYunObjectStore) Get(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	bkt, err := t.client.Bucket(bucket)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := bkt.GetObject(key)
+	if err != nil {
+		return nil, t.convertError(err)
+	}
+	return reader, nil
+}
+
+// Compose 通过追加上传把 srcs 依次追加到 dst。
+func (t *AliYunObjectStore) Compose(ctx context.Context, bucket, dst string, srcs []string) error {
+	bkt, err := t.client.Bucket(bucket)
+	if err != nil {
+		return err
+	}
+
+	var appendPosition = int64(0)
+	for _, src := range srcs {
+		obj, err := bkt.GetObject(src)
+		if err != nil {
+			return t.convertError(err)
+		}
+		nextPosition, err := bkt.AppendObject(dst, obj, appendPosition)
+		_ = obj.Close()
+		if err != nil {
+			return err
+		}
+		appendPosition = nextPosition
+	}
+	return nil
+}
+
+// List 分页列出前缀下的全部对象。
+func (t *AliYunObjectStore) List(ctx context.Context, bucket, prefix string) ([]string, error) {
+	bkt, err := t.client.Bucket(bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys = make([]string, 0)
+	var token = ""
+	for {
+		result, err := bkt.ListObjectsV2(oss.Prefix(prefix), oss.ContinuationToken(token))
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range result.Objects {
+			keys = append(keys, object.Key)
+		}
+		if !result.IsTruncated {
+			return keys, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// Copy 复制对象。
+func (t *AliYunObjectStore) Copy(ctx context.Context, bucket, src, dst string) error {
+	bkt, err := t.client.Bucket(bucket)
+	if err != nil {
+		return err
+	}
+	_, err = bkt.CopyObject(src, dst)
+	return t.convertError(err)
+}
+
+// SignURL 生成带签名的下载链接。
+func (t *AliYunObjectStore) SignURL(ctx context.Context, bucket, key string, expires time.Duration) (string, error) {
+	bkt, err := t.client.Bucket(bucket)
+	if err != nil {
+		return "", err
+	}
+	return bkt.SignURL(key, http.MethodGet, int64(expires/time.Second))
+}
+
+// Delete 删除对象。
+func (t *AliYunObjectStore) Delete(ctx context.Context, bucket, key string) error {
+	bkt, err := t.client.Bucket(bucket)
+	if err != nil {
+		return err
+	}
+	return bkt.DeleteObject(key)
+}
+
+// convertError 把 OSS 的 NoSuchKey 转换为 ErrObjectNotExist。
+func (t *AliYunObjectStore) convertError(err error) error {
+	var serviceErr oss.ServiceError
+	if errors.As(err, &serviceErr) && serviceErr.StatusCode == http.StatusNotFound {
+		return ErrObjectNotExist
+	}
+	return err
+}
@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LocalObjectStore 基于本地磁盘的对象存储实现，对象保存在 root/bucket/key。
+// 下载链接由 URLSigner 签发，指向前端网关的 /v1/storage 路由。
+type LocalObjectStore struct {
+	root   string
+	signer *URLSigner
+}
+
+// NewLocalObjectStore 创建一个以 root 为根目录的本地对象存储。
+func NewLocalObjectStore(root string, signer *URLSigner) *LocalObjectStore {
+	return &LocalObjectStore{root: root, signer: signer}
+}
+
+// Put 写入对象，先写临时文件再重命名，避免读到写了一半的对象。
+func (t *LocalObjectStore) Put(ctx context.Context, bucket, key string, reader io.Reader) error {
+	path, err := t.path(bucket, key)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, reader)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Get 打开对象文件。
+func (t *LocalObjectStore) Get(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	path, err := t.path(bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrObjectNotExist
+	}
+	return f, err
+}
+
+// Compose 依次读取 srcs 写入 dst。
+func (t *LocalObjectStore) Compose(ctx context.Context, bucket, dst string, srcs []string) error {
+	var readers = make([]io.Reader, 0, len(srcs))
+	for _, src := range srcs {
+		reader, err := t.Get(ctx, bucket, src)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		readers = append(readers, reader)
+	}
+	return t.Put(ctx, bucket, dst, io.MultiReader(readers...))
+}
+
+// List 遍历 bucket 目录，返回以 prefix 开头的对象 key。
+func (t *LocalObjectStore) List(ctx context.Context, bucket, prefix string) ([]string, error) {
+	dir, err := t.path(bucket, "")
+	if err != nil {
+		return nil, err
+	}
+
+	var keys = make([]string, 0)
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	sort.Strings(keys)
+	return keys, err
+}
+
+// Copy 复制对象文件。
+func (t *LocalObjectStore) Copy(ctx context.Context, bucket, src, dst string) error {
+	reader, err := t.Get(ctx, bucket, src)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return t.Put(ctx, bucket, dst, reader)
+}
+
+// SignURL 生成由前端网关校验并转发的 HMAC 签名链接。
+func (t *LocalObjectStore) SignURL(ctx context.Context, bucket, key string, expires time.Duration) (string, error) {
+	if _, err := t.path(bucket, key); err != nil {
+		return "", err
+	}
+	return t.signer.Sign(bucket, key, expires), nil
+}
+
+// Delete 删除对象文件。
+func (t *LocalObjectStore) Delete(ctx context.Context, bucket, key string) error {
+	path, err := t.path(bucket, key)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// path 把 bucket + key 映射到磁盘路径，并拒绝跳出 bucket 目录的 key。
+func (t *LocalObjectStore) path(bucket, key string) (string, error) {
+	if bucket == "" || strings.ContainsAny(bucket, `/\`) || bucket == "." || bucket == ".." {
+		return "", fmt.Errorf("invalid bucket: %q", bucket)
+	}
+	dir := filepath.Join(t.root, bucket)
+	path := filepath.Join(dir, filepath.FromSlash(key))
+	if path != dir && !strings.HasPrefix(path, dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid object key: %q", key)
+	}
+	return path, nil
+}
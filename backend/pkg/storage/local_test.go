@@ -0,0 +1,84 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"paper-translation/pkg/storage"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/**
+ * TestLocalObjectStore 测试本地对象存储的上传、列举、拼接、复制和删除。
+ */
+func TestLocalObjectStore(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewLocalObjectStore(t.TempDir(), storage.NewHMACURLSigner("http://localhost", "secret"))
+
+	// 上传分块，故意打乱顺序
+	for _, kv := range [][2]string{{"chunks/abc/1", "world"}, {"chunks/abc/0", "hello "}, {"other/x", "x"}} {
+		assert.NoError(t, store.Put(ctx, "forwork", kv[0], strings.NewReader(kv[1])))
+	}
+
+	keys, err := store.List(ctx, "forwork", "chunks/abc/")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"chunks/abc/0", "chunks/abc/1"}, keys)
+
+	// 拼接分块
+	assert.NoError(t, store.Compose(ctx, "forwork", "files/a.pdf", keys))
+	assert.Equal(t, "hello world", read(t, store, "files/a.pdf"))
+
+	// 复制备份
+	assert.NoError(t, store.Copy(ctx, "forwork", "files/a.pdf", "buckup/files/a.pdf"))
+	assert.Equal(t, "hello world", read(t, store, "buckup/files/a.pdf"))
+
+	// 删除后读取返回 ErrObjectNotExist，重复删除不报错
+	assert.NoError(t, store.Delete(ctx, "forwork", "files/a.pdf"))
+	assert.NoError(t, store.Delete(ctx, "forwork", "files/a.pdf"))
+	_, err = store.Get(ctx, "forwork", "files/a.pdf")
+	assert.ErrorIs(t, err, storage.ErrObjectNotExist)
+
+	// 不允许跳出 bucket 目录
+	assert.Error(t, store.Put(ctx, "forwork", "../escape", strings.NewReader("x")))
+	assert.Error(t, store.Put(ctx, "../forwork", "x", strings.NewReader("x")))
+}
+
+/**
+ * TestURLSigner 测试签名链接的生成与校验。
+ */
+func TestURLSigner(t *testing.T) {
+	signer := storage.NewHMACURLSigner("http://localhost/", "secret")
+	store := storage.NewLocalObjectStore(t.TempDir(), signer)
+
+	signed, err := store.SignURL(context.Background(), "forwork", "files/a b.pdf", time.Minute)
+	assert.NoError(t, err)
+
+	u, err := url.Parse(signed)
+	assert.NoError(t, err)
+	assert.Equal(t, "/v1/storage/forwork/files/a b.pdf", u.Path)
+
+	expires, signature := u.Query().Get("expires"), u.Query().Get("signature")
+	assert.NoError(t, signer.Verify("forwork", "files/a b.pdf", expires, signature))
+
+	// 篡改 key 或使用其他密钥都无法通过校验
+	assert.ErrorIs(t, signer.Verify("forwork", "files/b.pdf", expires, signature), storage.ErrSignatureInvalid)
+	other := storage.NewHMACURLSigner("http://localhost", "other")
+	assert.ErrorIs(t, other.Verify("forwork", "files/a b.pdf", expires, signature), storage.ErrSignatureInvalid)
+
+	// 过期链接
+	expired, _ := url.Parse(signer.Sign("forwork", "files/a b.pdf", -time.Minute))
+	assert.ErrorIs(t, signer.Verify("forwork", "files/a b.pdf", expired.Query().Get("expires"), expired.Query().Get("signature")), storage.ErrURLExpired)
+}
+
+func read(t *testing.T, store storage.ObjectStore, key string) string {
+	reader, err := store.Get(context.Background(), "forwork", key)
+	assert.NoError(t, err)
+	defer reader.Close()
+	data, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	return string(data)
+}
@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"paper-translation/pkg/errutil" // 错误处理
+	"time"
+
+	"github.com/minio/minio-go/v7"                 // S3 兼容存储 SDK
+	"github.com/minio/minio-go/v7/pkg/credentials" // S3 认证
+)
+
+// s3PartSize 长度未知时分片上传的分片大小，不设置的话 SDK 会按 5TB 上限估算出很大的分片缓冲。
+const s3PartSize = 16 << 20
+
+// S3ObjectStore 基于 S3 协议的对象存储实现，可对接 AWS S3、MinIO、Ceph RGW 等。
+type S3ObjectStore struct {
+	client *minio.Client
+}
+
+// NewS3ObjectStore 使用已有的 S3 客户端创建对象存储。
+func NewS3ObjectStore(client *minio.Client) *S3ObjectStore {
+	return &S3ObjectStore{client: client}
+}
+
+/**
+* 获取S3客户端
+* @param endpoint - 服务地址，不带协议头
+* @param region - 区域
+* @param keyID - Access Key
+* @param secret - Secret Key
+* @param secure - 是否使用 https
+* @param pathStyle - 是否使用 path-style 访问
+* @return S3客户端
+ */
+func MustGetS3Client(endpoint, region, keyID, secret string, secure, pathStyle bool) *minio.Client {
+	lookup := minio.BucketLookupAuto
+	if pathStyle {
+		lookup = minio.BucketLookupPath
+	}
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(keyID, secret, ""),
+		Secure:       secure,
+		Region:       region,
+		BucketLookup: lookup,
+	})
+	errutil.PanicIfErr(err) // 错误处理
+	return client
+}
+
+// Put 上传对象，长度未知时由 SDK 自动走分片上传。
+func (t *S3ObjectStore) Put(ctx context.Context, bucket, key string, reader io.Reader) error {
+	_, err := t.client.PutObject(ctx, bucket, key, reader, -1, minio.PutObjectOptions{PartSize: s3PartSize})
+	return err
+}
+
+// Get 下载对象。
+func (t *S3ObjectStore) Get(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+	// GetObject 是惰性的，先 Stat 一次以便及时发现对象不存在
+	_, err := t.client.StatObject(ctx, bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, t.convertError(err)
+	}
+	return t.client.GetObject(ctx, bucket, key, minio.GetObjectOptions{})
+}
+
+// Compose 把 srcs 串联成一个流重新上传。
+// S3 的服务端拼接要求除最后一块外每块不小于 5MB，前端分块大小不受此约束，所以这里走流式拼接。
+func (t *S3ObjectStore) Compose(ctx context.Context, bucket, dst string, srcs []string) error {
+	var readers = make([]io.Reader, 0, len(srcs))
+	for _, src := range srcs {
+		reader, err := t.Get(ctx, bucket, src)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		readers = append(readers, reader)
+	}
+	return t.Put(ctx, bucket, dst, io.MultiReader(readers...))
+}
+
+// List 列出前缀下的全部对象。
+func (t *S3ObjectStore) List(ctx context.Context, bucket, prefix string) ([]string, error) {
+	var keys = make([]string, 0)
+	for object := range t.client.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, object.Err
+		}
+		keys = append(keys, object.Key)
+	}
+	return keys, nil
+}
+
+// Copy 服务端复制对象。
+func (t *S3ObjectStore) Copy(ctx context.Context, bucket, src, dst string) error {
+	_, err := t.client.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: bucket, Object: dst},
+		minio.CopySrcOptions{Bucket: bucket, Object: src},
+	)
+	return t.convertError(err)
+}
+
+// SignURL 生成预签名下载链接。
+func (t *S3ObjectStore) SignURL(ctx context.Context, bucket, key string, expires time.Duration) (string, error) {
+	u, err := t.client.PresignedGetObject(ctx, bucket, key, expires, nil)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// Delete 删除对象。
+func (t *S3ObjectStore) Delete(ctx context.Context, bucket, key string) error {
+	return t.client.RemoveObject(ctx, bucket, key, minio.RemoveObjectOptions{})
+}
+
+// convertError 把 NoSuchKey 转换为 ErrObjectNotExist。
+func (t *S3ObjectStore) convertError(err error) error {
+	if err != nil && minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrObjectNotExist
+	}
+	return err
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DownloadPath 是前端网关上签名下载路由的前缀。
+const DownloadPath = "/v1/storage"
+
+var (
+	ErrURLExpired       = errors.New("signed url expired")
+	ErrSignatureInvalid = errors.New("signed url signature invalid")
+)
+
+var (
+	processSecret     []byte
+	processSecretOnce sync.Once
+)
+
+// URLSigner 使用 HMAC-SHA256 为对象生成带过期时间的下载链接，并校验链接。
+type URLSigner struct {
+	baseURL string
+	secret  []byte
+}
+
+// NewHMACURLSigner 创建签名器。
+//
+// 参数:
+// - baseURL string: 前端网关的外部访问地址，例如 http://localhost。
+// - secret string: 签名密钥，为空时随机生成，此时只有同一进程签发的链接能通过校验。
+func NewHMACURLSigner(baseURL, secret string) *URLSigner {
+	key := []byte(secret)
+	if secret == "" {
+		// 同一进程内的多个签名器共用一个随机密钥
+		processSecretOnce.Do(func() {
+			processSecret = make([]byte, 32)
+			_, _ = rand.Read(processSecret)
+		})
+		key = processSecret
+	}
+	return &URLSigner{baseURL: strings.TrimRight(baseURL, "/"), secret: key}
+}
+
+// Sign 生成 {baseURL}/v1/storage/{bucket}/{key}?expires=..&signature=.. 形式的下载链接。
+func (t *URLSigner) Sign(bucket, key string, expires time.Duration) string {
+	expiresAt := strconv.FormatInt(time.Now().Add(expires).Unix(), 10)
+
+	var segments = []string{url.PathEscape(bucket)}
+	for _, segment := range strings.Split(key, "/") {
+		segments = append(segments, url.PathEscape(segment))
+	}
+
+	query := url.Values{}
+	query.Set("expires", expiresAt)
+	query.Set("signature", t.signature(bucket, key, expiresAt))
+	return fmt.Sprintf("%s%s/%s?%s", t.baseURL, DownloadPath, strings.Join(segments, "/"), query.Encode())
+}
+
+// Verify 校验下载链接中的过期时间和签名。
+func (t *URLSigner) Verify(bucket, key, expiresAt, signature string) error {
+	expires, err := strconv.ParseInt(expiresAt, 10, 64)
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+	if time.Now().Unix() > expires {
+		return ErrURLExpired
+	}
+	if !hmac.Equal([]byte(signature), []byte(t.signature(bucket, key, expiresAt))) {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
+
+// signature 计算 bucket、key、过期时间的 HMAC。
+func (t *URLSigner) signature(bucket, key, expiresAt string) string {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(bucket + "\n" + key + "\n" + expiresAt))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,36 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrObjectNotExist 表示对象不存在。
+var ErrObjectNotExist = errors.New("object not exist")
+
+// ObjectStore 对象存储接口，屏蔽阿里云 OSS、本地磁盘、S3 兼容存储之间的差异。
+// 所有方法都以 bucket + key 定位一个对象。
+type ObjectStore interface {
+	// Put 写入对象，已存在则覆盖。
+	Put(ctx context.Context, bucket, key string, reader io.Reader) error
+
+	// Get 读取对象，调用方负责关闭返回的 io.ReadCloser。
+	Get(ctx context.Context, bucket, key string) (io.ReadCloser, error)
+
+	// Compose 按 srcs 的顺序把多个对象拼接成 dst。
+	Compose(ctx context.Context, bucket, dst string, srcs []string) error
+
+	// List 列出以 prefix 开头的全部对象 key。
+	List(ctx context.Context, bucket, prefix string) ([]string, error)
+
+	// Copy 在同一个 bucket 内复制对象。
+	Copy(ctx context.Context, bucket, src, dst string) error
+
+	// SignURL 生成一个在 expires 内有效的对象下载链接。
+	SignURL(ctx context.Context, bucket, key string, expires time.Duration) (string, error)
+
+	// Delete 删除对象，对象不存在时不报错。
+	Delete(ctx context.Context, bucket, key string) error
+}
@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"fmt"
+	"log"
+	"paper-translation/pkg/oss"
+
+	"go-micro.dev/v4/config" // 配置管理器
+)
+
+// 支持的存储驱动
+const (
+	DriverAliYun = "aliyun"
+	DriverLocal  = "local"
+	DriverS3     = "s3"
+)
+
+/**
+* 根据配置中的 storage.driver 创建对象存储
+* @param config - 配置管理器
+* @return 对象存储
+ */
+func NewObjectStore(config config.Config) ObjectStore {
+
+	driver := config.Get("storage", "driver").String(DriverAliYun) // 获取存储驱动
+
+	switch driver {
+	case DriverAliYun:
+		return NewAliYunObjectStore(oss.NewAliYunOSS(config))
+	case DriverLocal:
+		root := config.Get("storage", "local", "root").String("./data") // 获取本地存储根目录
+		return NewLocalObjectStore(root, NewURLSigner(config))
+	case DriverS3:
+		return NewS3ObjectStore(MustGetS3Client(
+			config.Get("storage", "s3", "endpoint").String("localhost:9000"), // 获取endpoint
+			config.Get("storage", "s3", "region").String("us-east-1"),        // 获取region
+			config.Get("storage", "s3", "key_id").String("default"),          // 获取key id
+			config.Get("storage", "s3", "secret").String("default"),          // 获取secret
+			config.Get("storage", "s3", "secure").Bool(true),                 // 是否使用https
+			config.Get("storage", "s3", "path_style").Bool(false),            // 是否使用path-style
+		))
+	default:
+		panic(fmt.Sprintf("unknown storage driver: %s", driver))
+	}
+}
+
+/**
+* 从配置中创建下载链接签名器，签发方和前端网关需要配置相同的密钥
+* @param config - 配置管理器
+* @return 下载链接签名器
+ */
+func NewURLSigner(config config.Config) *URLSigner {
+
+	baseURL := config.Get("storage", "local", "base_url").String("http://localhost") // 获取前端网关地址
+	secret := config.Get("storage", "local", "secret").String("")                    // 获取签名密钥
+
+	if secret == "" {
+		log.Printf("storage.local.secret is empty, signed urls only work inside this process")
+	}
+	return NewHMACURLSigner(baseURL, secret)
+}
@@ -0,0 +1,282 @@
+package tmx
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Version 支持的 TMX 版本
+const Version = "1.4"
+
+// AllLanguages srclang 为 *all* 时表示任意语言都可以作为原文
+const AllLanguages = "*all*"
+
+// dateLayout TMX 使用的 ISO 8601 UTC 时间格式
+const dateLayout = "20060102T150405Z"
+
+// Header TMX 文件头
+type Header struct {
+	CreationTool        string // 生成文件的工具
+	CreationToolVersion string // 工具版本
+	SrcLang             string // 原文语言，BCP-47 标签或 *all*
+	AdminLang           string // 管理语言，默认为 en
+}
+
+// Variant 翻译单元中一种语言的文本
+type Variant struct {
+	Lang string // BCP-47 语言标签
+	Text string // 纯文本，不包含内联标记
+}
+
+// Unit 翻译单元，包含同一段文本的多种语言版本
+type Unit struct {
+	Props        map[string]string // 自定义属性，对应 <prop type="...">
+	CreationDate time.Time
+	ChangeDate   time.Time
+	Variants     []Variant
+}
+
+type xmlHeader struct {
+	CreationTool        string `xml:"creationtool,attr"`
+	CreationToolVersion string `xml:"creationtoolversion,attr"`
+	SegType             string `xml:"segtype,attr"`
+	OTmf                string `xml:"o-tmf,attr"`
+	AdminLang           string `xml:"adminlang,attr"`
+	SrcLang             string `xml:"srclang,attr"`
+	DataType            string `xml:"datatype,attr"`
+}
+
+type xmlProp struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type xmlTuv struct {
+	Lang string `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Seg  xmlSeg `xml:"seg"`
+}
+
+type xmlTu struct {
+	XMLName      xml.Name  `xml:"tu"`
+	CreationDate string    `xml:"creationdate,attr,omitempty"`
+	ChangeDate   string    `xml:"changedate,attr,omitempty"`
+	Props        []xmlProp `xml:"prop"`
+	Tuvs         []xmlTuv  `xml:"tuv"`
+}
+
+// xmlSeg <seg> 的文本。解析时忽略 bpt、ept、it、ph、ut 等内联标记中的原始格式代码，只保留文本
+type xmlSeg string
+
+// inlineCodes 内容为原始格式代码的内联标记
+var inlineCodes = map[string]bool{"bpt": true, "ept": true, "it": true, "ph": true, "ut": true}
+
+func (t *xmlSeg) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var buf strings.Builder
+	var skip int // 位于内联格式代码中的层数
+	for depth := 1; depth > 0; {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch e := token.(type) {
+		case xml.StartElement:
+			depth++
+			if skip > 0 || inlineCodes[e.Name.Local] {
+				skip++
+			}
+		case xml.EndElement:
+			depth--
+			if skip > 0 {
+				skip--
+			}
+		case xml.CharData:
+			if skip == 0 {
+				buf.Write(e)
+			}
+		}
+	}
+	*t = xmlSeg(buf.String())
+	return nil
+}
+
+// Encoder 以流的方式写出 TMX 文件，写完所有翻译单元后需要调用 Close
+type Encoder struct {
+	w      io.Writer
+	e      *xml.Encoder
+	header Header
+	opened bool
+}
+
+func NewEncoder(w io.Writer, header Header) *Encoder {
+	if header.AdminLang == "" {
+		header.AdminLang = "en"
+	}
+	if header.SrcLang == "" {
+		header.SrcLang = AllLanguages
+	}
+	e := xml.NewEncoder(w)
+	e.Indent("", "  ")
+	return &Encoder{w: w, e: e, header: header}
+}
+
+// Encode 写出一个翻译单元
+func (t *Encoder) Encode(unit *Unit) error {
+	err := t.open()
+	if err != nil {
+		return err
+	}
+	tu := xmlTu{CreationDate: formatDate(unit.CreationDate), ChangeDate: formatDate(unit.ChangeDate)}
+	for key, value := range unit.Props {
+		tu.Props = append(tu.Props, xmlProp{Type: key, Value: value})
+	}
+	sort.Slice(tu.Props, func(i, j int) bool { return tu.Props[i].Type < tu.Props[j].Type })
+	for _, v := range unit.Variants {
+		tu.Tuvs = append(tu.Tuvs, xmlTuv{Lang: v.Lang, Seg: xmlSeg(v.Text)})
+	}
+	return t.e.Encode(tu)
+}
+
+// Close 写出文件尾，没有翻译单元时也会生成合法的空文件
+func (t *Encoder) Close() error {
+	err := t.open()
+	if err != nil {
+		return err
+	}
+	for _, name := range []string{"body", "tmx"} {
+		err = t.e.EncodeToken(xml.EndElement{Name: xml.Name{Local: name}})
+		if err != nil {
+			return err
+		}
+	}
+	err = t.e.Flush()
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(t.w, "\n")
+	return err
+}
+
+// open 写出 XML 声明、<tmx>、<header> 和 <body>
+func (t *Encoder) open() error {
+	if t.opened {
+		return nil
+	}
+	t.opened = true
+	_, err := io.WriteString(t.w, xml.Header)
+	if err != nil {
+		return err
+	}
+	err = t.e.EncodeToken(xml.StartElement{
+		Name: xml.Name{Local: "tmx"},
+		Attr: []xml.Attr{{Name: xml.Name{Local: "version"}, Value: Version}},
+	})
+	if err != nil {
+		return err
+	}
+	err = t.e.EncodeElement(xmlHeader{
+		CreationTool:        t.header.CreationTool,
+		CreationToolVersion: t.header.CreationToolVersion,
+		SegType:             "block",
+		OTmf:                t.header.CreationTool,
+		AdminLang:           t.header.AdminLang,
+		SrcLang:             t.header.SrcLang,
+		DataType:            "plaintext",
+	}, xml.StartElement{Name: xml.Name{Local: "header"}})
+	if err != nil {
+		return err
+	}
+	return t.e.EncodeToken(xml.StartElement{Name: xml.Name{Local: "body"}})
+}
+
+// Decoder 以流的方式读取 TMX 文件，不会把整个文件读入内存
+type Decoder struct {
+	d      *xml.Decoder
+	header *Header
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{d: xml.NewDecoder(r)}
+}
+
+// Header 返回文件头，在第一次调用 Next 之后才有值
+func (t *Decoder) Header() *Header {
+	return t.header
+}
+
+// Next 读取下一个翻译单元，读完时返回 io.EOF
+func (t *Decoder) Next() (*Unit, error) {
+	for {
+		token, err := t.d.Token()
+		if err != nil {
+			return nil, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "tmx":
+			if version := attr(start, "version"); version != "" && !strings.HasPrefix(version, "1.") {
+				return nil, errors.New("unsupported tmx version " + version)
+			}
+		case "header":
+			t.header = &Header{
+				CreationTool:        attr(start, "creationtool"),
+				CreationToolVersion: attr(start, "creationtoolversion"),
+				SrcLang:             attr(start, "srclang"),
+				AdminLang:           attr(start, "adminlang"),
+			}
+			// header 中可能包含 note、prop 等子元素
+			err = t.d.Skip()
+			if err != nil {
+				return nil, err
+			}
+		case "tu":
+			var tu xmlTu
+			err = t.d.DecodeElement(&tu, &start)
+			if err != nil {
+				return nil, err
+			}
+			return convertUnit(&tu), nil
+		}
+	}
+}
+
+func convertUnit(tu *xmlTu) *Unit {
+	unit := &Unit{CreationDate: parseDate(tu.CreationDate), ChangeDate: parseDate(tu.ChangeDate)}
+	if len(tu.Props) > 0 {
+		unit.Props = make(map[string]string, len(tu.Props))
+		for _, prop := range tu.Props {
+			unit.Props[prop.Type] = prop.Value
+		}
+	}
+	for _, tuv := range tu.Tuvs {
+		unit.Variants = append(unit.Variants, Variant{Lang: tuv.Lang, Text: string(tuv.Seg)})
+	}
+	return unit
+}
+
+func attr(e xml.StartElement, name string) string {
+	for _, a := range e.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(dateLayout)
+}
+
+func parseDate(s string) time.Time {
+	t, _ := time.Parse(dateLayout, s)
+	return t
+}
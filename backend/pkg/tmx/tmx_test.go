@@ -0,0 +1,68 @@
+package tmx_test
+
+import (
+	"bytes"
+	"io"
+	"paper-translation/pkg/tmx"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+/**
+ * TestEncodeDecode 测试写出的 TMX 文件可以原样读回。
+ */
+func TestEncodeDecode(t *testing.T) {
+	var buf bytes.Buffer
+	encoder := tmx.NewEncoder(&buf, tmx.Header{CreationTool: "paper-translation", CreationToolVersion: "1.0", SrcLang: "en"})
+	date := time.Date(2023, 10, 18, 12, 0, 0, 0, time.UTC)
+	unit := &tmx.Unit{
+		Props:        map[string]string{"x-provider": "xf-spark", "x-prompt-version": "v1"},
+		CreationDate: date,
+		ChangeDate:   date,
+		Variants:     []tmx.Variant{{Lang: "en", Text: "a < b & c"}, {Lang: "zh", Text: "a 小于 b 和 c"}},
+	}
+	assert.NoError(t, encoder.Encode(unit))
+	assert.NoError(t, encoder.Close())
+	assert.Contains(t, buf.String(), `<tmx version="1.4">`)
+	assert.Contains(t, buf.String(), `<tuv xml:lang="zh">`)
+
+	decoder := tmx.NewDecoder(&buf)
+	decoded, err := decoder.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, unit, decoded)
+	assert.Equal(t, "en", decoder.Header().SrcLang)
+	_, err = decoder.Next()
+	assert.ErrorIs(t, err, io.EOF)
+
+	// 没有翻译单元时也是合法的文件
+	buf.Reset()
+	assert.NoError(t, tmx.NewEncoder(&buf, tmx.Header{}).Close())
+	_, err = tmx.NewDecoder(&buf).Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+/**
+ * TestDecodeInlineCodes 测试读取 CAT 工具导出的文件时忽略内联格式代码。
+ */
+func TestDecodeInlineCodes(t *testing.T) {
+	const file = `<?xml version="1.0" encoding="UTF-8"?>
+<tmx version="1.4">
+  <header creationtool="Trados" creationtoolversion="16" segtype="sentence" o-tmf="tw4win" adminlang="en-US" srclang="en-US" datatype="rtf">
+    <prop type="x-note">header note</prop>
+  </header>
+  <body>
+    <tu>
+      <tuv xml:lang="en-US"><seg>Press <bpt i="1">&lt;b&gt;</bpt>OK<ept i="1">&lt;/b&gt;</ept> now.</seg></tuv>
+      <tuv xml:lang="zh-CN"><seg>现在按<ph>&lt;br/&gt;</ph><hi>确定</hi>。</seg></tuv>
+    </tu>
+  </body>
+</tmx>`
+	decoder := tmx.NewDecoder(strings.NewReader(file))
+	unit, err := decoder.Next()
+	assert.NoError(t, err)
+	assert.Equal(t, []tmx.Variant{{Lang: "en-US", Text: "Press OK now."}, {Lang: "zh-CN", Text: "现在按确定。"}}, unit.Variants)
+	assert.Equal(t, "en-US", decoder.Header().SrcLang)
+}
@@ -22,17 +22,40 @@ const (
 	HostUrl = "wss://aichat.xf-yun.com/v1/chat"
 )
 
+// 消息的角色
+const (
+	RoleUser      = "user"      // 用户
+	RoleAssistant = "assistant" // 模型
+)
+
 // Message 表示与 XFSpark 通信的消息结构。
 type Message struct {
 	Role    string `json:"role"`
 	Content string `json:"content"`
 }
 
+// ChatOptions 是对话参数。
+type ChatOptions struct {
+	Domain      string  // 模型版本，如 general、generalv2
+	Temperature float64 // 采样温度
+	TopK        int64   // 候选数量
+	MaxTokens   int64   // 最大回复 token 数
+}
+
+// DefaultChatOptions 是默认的对话参数。
+var DefaultChatOptions = ChatOptions{
+	Domain:      "general",
+	Temperature: 0.8,
+	TopK:        6,
+	MaxTokens:   2048,
+}
+
 // XFSparkClient 是与 XFSpark 服务通信的客户端。
 type XFSparkClient struct {
 	appID     string
 	apiSecret string
 	apiKey    string
+	options   ChatOptions
 }
 
 // NewXFSparkClient 创建一个新的 XFSparkClient 实例。
@@ -45,7 +68,17 @@ type XFSparkClient struct {
 // 返回值:
 // - *XFSparkClient: XFSparkClient 实例。
 func NewXFSparkClient(appID string, apiSecret string, apiKey string) *XFSparkClient {
-	return &XFSparkClient{appID: appID, apiSecret: apiSecret, apiKey: apiKey}
+	return &XFSparkClient{appID: appID, apiSecret: apiSecret, apiKey: apiKey, options: DefaultChatOptions}
+}
+
+// SetChatOptions 设置对话参数。
+func (t *XFSparkClient) SetChatOptions(options ChatOptions) {
+	t.options = options
+}
+
+// ChatOptions 返回对话参数。
+func (t *XFSparkClient) ChatOptions() ChatOptions {
+	return t.options
 }
 
 // CreateChat 启动与 XFSpark 服务的对话。
@@ -58,6 +91,19 @@ func NewXFSparkClient(appID string, apiSecret string, apiKey string) *XFSparkCli
 // 返回值:
 // - error: 错误信息，如果发生错误。
 func (t *XFSparkClient) CreateChat(ctx context.Context, prompt string, fc func(text string)) error {
+	return t.CreateChatMessages(ctx, []Message{{Role: RoleUser, Content: prompt}}, fc)
+}
+
+// CreateChatMessages 带历史消息启动与 XFSpark 服务的对话。
+//
+// 参数:
+// - ctx (context.Context): 上下文。
+// - messages ([]Message): 按时间顺序排列的多轮对话，最后一条为用户的提示信息。
+// - fc (func(text string)): 处理接收到的文本回调函数。
+//
+// 返回值:
+// - error: 错误信息，如果发生错误。
+func (t *XFSparkClient) CreateChatMessages(ctx context.Context, messages []Message, fc func(text string)) error {
 
 	dialer := websocket.Dialer{
 		HandshakeTimeout: 5 * time.Second,
@@ -73,7 +119,7 @@ func (t *XFSparkClient) CreateChat(ctx context.Context, prompt string, fc func(t
 		return errors.New(t.readResp(resp))
 	}
 
-	err = conn.WriteJSON(t.createParams(prompt))
+	err = conn.WriteJSON(t.createParams(messages))
 	if err != nil {
 		return err
 	}
@@ -130,15 +176,11 @@ func (t *XFSparkClient) readMessages(conn *websocket.Conn, fc func(text string))
 // createParams 创建用于与 XFSpark 服务通信的参数。
 //
 // 参数:
-// - question (string): 用户的问题或提示信息。
+// - messages ([]Message): 多轮对话，用户和模型的消息交替出现，最后一条为用户的问题或提示信息。
 //
 // 返回值:
 // - map[string]interface{}: 用于通信的参数。
-func (t *XFSparkClient) createParams(question string) map[string]interface{} {
-
-	messages := []Message{
-		{Role: "user", Content: question},
-	}
+func (t *XFSparkClient) createParams(messages []Message) map[string]interface{} {
 
 	data := map[string]interface{}{ // 根据实际情况修改返回的数据结构和字段名
 		"header": map[string]interface{}{ // 根据实际情况修改返回的数据结构和字段名
@@ -146,11 +188,11 @@ func (t *XFSparkClient) createParams(question string) map[string]interface{} {
 		},
 		"parameter": map[string]interface{}{ // 根据实际情况修改返回的数据结构和字段名
 			"chat": map[string]interface{}{ // 根据实际情况修改返回的数据结构和字段名
-				"domain":      "general",   // 根据实际情况修改返回的数据结构和字段名
-				"temperature": 0.8,         // 根据实际情况修改返回的数据结构和字段名
-				"top_k":       int64(6),    // 根据实际情况修改返回的数据结构和字段名
-				"max_tokens":  int64(2048), // 根据实际情况修改返回的数据结构和字段名
-				"auditing":    "default",   // 根据实际情况修改返回的数据结构和字段名
+				"domain":      t.options.Domain,      // 模型版本
+				"temperature": t.options.Temperature, // 采样温度
+				"top_k":       t.options.TopK,        // 候选数量
+				"max_tokens":  t.options.MaxTokens,   // 最大回复 token 数
+				"auditing":    "default",             // 根据实际情况修改返回的数据结构和字段名
 			},
 		},
 		"payload": map[string]interface{}{ // 根据实际情况修改返回的数据结构和字段名